@@ -29,23 +29,28 @@ func NewClient(
 	restURL := okex.RestURL
 	wsPubURL := okex.PublicWsURL
 	wsPriURL := okex.PrivateWsURL
+	wsBizURL := okex.BusinessWsURL
 	switch destination {
 	case okex.AwsServer:
 		restURL = okex.AwsRestURL
 		wsPubURL = okex.AwsPublicWsURL
 		wsPriURL = okex.AwsPrivateWsURL
+		wsBizURL = okex.AwsBusinessWsURL
 	case okex.DemoServer:
 		restURL = okex.DemoRestURL
 		wsPubURL = okex.DemoPublicWsURL
 		wsPriURL = okex.DemoPrivateWsURL
+		wsBizURL = okex.DemoBusinessWsURL
 	case okex.CandleWsServer:
 		restURL = okex.AwsRestURL
 		wsPubURL = okex.HandleWsURL
 		wsPriURL = okex.AwsPrivateWsURL
+		wsBizURL = okex.AwsBusinessWsURL
 	}
 
 	r := rest.NewClient(logger, apiKey, secretKey, passphrase, restURL, destination)
 	c := ws.NewClient(ctx, logger, apiKey, secretKey, passphrase, map[bool]okex.BaseURL{true: wsPriURL, false: wsPubURL})
+	c.SetBusinessURL(wsBizURL)
 
 	return &Client{
 		Rest:   r,
@@ -3,11 +3,15 @@ package rest
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	okex "github.com/pefish/go-okx"
+	models "github.com/pefish/go-okx/models/account"
 	requests "github.com/pefish/go-okx/requests/rest/account"
 	responses "github.com/pefish/go-okx/responses/account"
+	"github.com/pkg/errors"
 )
 
 // Account
@@ -85,19 +89,127 @@ func (c *Account) GetAccountAndPositionRisk(req requests.GetAccountAndPositionRi
 	return
 }
 
-// func (c *Account) GetHistoryPositions(req requests.GetHistoryPositions) (response responses.GetHistoryPositions, err error) {
-// 	p := "/priapi/v5/account/history-positions"
-// 	m := okex.S2M(req)
-// 	res, err := c.client.Do(http.MethodGet, p, true, m)
-// 	if err != nil {
-// 		return
-// 	}
-// 	defer res.Body.Close()
-// 	d := json.NewDecoder(res.Body)
-// 	err = d.Decode(&response)
+// GetHistoryPositions
+// Retrieve the updated position data for the last 3 months. Return in reverse chronological order using utime.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-positions-history
+func (c *Account) GetHistoryPositions(req requests.GetHistoryPositions) (response responses.GetHistoryPositions, err error) {
+	p := "/api/v5/account/positions-history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// HistoryPositions returns an iterator over the positions closed within [begin, end), the most recent first.
+// The filters of req are kept, its After and Before are replaced. A zero end is now.
+func (c *Account) HistoryPositions(req requests.GetHistoryPositions, begin, end time.Time) *HistoryPositionIterator {
+	if end.IsZero() {
+		end = time.Now()
+	}
+	req.Before = 0
+	req.After = end.UnixMilli()
+	if req.Limit == 0 {
+		req.Limit = historyPositionsLimit
+	}
+	return &HistoryPositionIterator{account: c, req: req, begin: begin.UnixMilli()}
+}
 
-// 	return
-// }
+const historyPositionsLimit = 100
+
+// HistoryPositionIterator pages backwards through the history positions, it's not safe for concurrent use
+//
+//	it := client.Rest.Account.HistoryPositions(req, begin, end)
+//	for it.Next() {
+//		p := it.Position()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type HistoryPositionIterator struct {
+	account *Account
+	req     requests.GetHistoryPositions
+	begin   int64
+	page    []*models.HistoryPosition
+	seen    map[string]bool // the positions of the previous page having its oldest uTime, the next page starts at that uTime again
+	cur     *models.HistoryPosition
+	done    bool
+	err     error
+}
+
+// Next advances to the next position, it returns false at the end of the range or on error
+func (it *HistoryPositionIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			it.cur = nil
+			return false
+		}
+		it.fetch()
+	}
+	it.cur, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+// Position returns the current position
+func (it *HistoryPositionIterator) Position() *models.HistoryPosition {
+	return it.cur
+}
+
+// Err returns the error that stopped the iteration
+func (it *HistoryPositionIterator) Err() error {
+	return it.err
+}
+
+func (it *HistoryPositionIterator) fetch() {
+	res, err := it.account.GetHistoryPositions(it.req)
+	if err != nil {
+		it.err = err
+		return
+	}
+	if res.Code != 0 {
+		it.err = errors.Errorf("GetHistoryPositions failed. err: %s, code: %d", res.Msg, res.Code)
+		return
+	}
+	list := res.HistoryPositions
+	if int64(len(list)) < it.req.Limit {
+		it.done = true
+	}
+	if len(list) == 0 {
+		return
+	}
+
+	oldest := time.Time(list[len(list)-1].UTime).UnixMilli()
+	seen := make(map[string]bool)
+	for _, p := range list {
+		t := time.Time(p.UTime).UnixMilli()
+		key := p.PosID + "-" + strconv.FormatInt(t, 10)
+		if t < it.begin {
+			it.done = true
+			break
+		}
+		if t == oldest {
+			seen[key] = true
+		}
+		if it.seen[key] {
+			continue
+		}
+		it.page = append(it.page, p)
+	}
+	if oldest == it.req.After-1 && len(it.page) == 0 {
+		// a whole page shares the same uTime, skip it rather than loop forever
+		it.req.After = oldest
+		it.seen = nil
+		return
+	}
+	// after is exclusive, it restarts one millisecond later so the positions sharing the oldest uTime are not skipped
+	it.req.After = oldest + 1
+	it.seen = seen
+}
 
 // GetBills
 // Retrieve the bills of the account. The bill refers to all transaction records that result in changing the balance of an account. Pagination is supported, and the response is sorted with the most recent first. This endpoint can retrieve data from the last 7 days.
@@ -349,6 +461,95 @@ func (c *Account) SetGreeks(req requests.SetGreeks) (response responses.SetGreek
 	return
 }
 
+// SetAccountLevel
+// Switch the account mode between spot, single-currency margin, multi-currency margin and portfolio margin. The positions, orders and borrowings blocking the switch have to be closed first.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-account-mode
+func (c *Account) SetAccountLevel(req requests.SetAccountLevel) (response responses.SetAccountLevel, err error) {
+	p := "/api/v5/account/set-account-level"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetIsolatedMode
+// Set how the margin of isolated MARGIN or CONTRACTS positions is transferred when they are opened.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-isolated-margin-trading-settings
+func (c *Account) SetIsolatedMode(req requests.SetIsolatedMode) (response responses.SetIsolatedMode, err error) {
+	p := "/api/v5/account/set-isolated-mode"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetAutoLoan
+// Turn automatic borrowing on or off, only applicable to multi-currency margin and portfolio margin.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-auto-loan
+func (c *Account) SetAutoLoan(req requests.SetAutoLoan) (response responses.SetAutoLoan, err error) {
+	p := "/api/v5/account/set-auto-loan"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetRiskOffsetType
+// Set the risk offset type of portfolio margin.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-risk-offset-type
+func (c *Account) SetRiskOffsetType(req requests.SetRiskOffsetType) (response responses.SetRiskOffsetType, err error) {
+	p := "/api/v5/account/set-riskOffset-type"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetRiskOffsetAmount
+// Set the user-defined amount of a currency offsetting the derivatives risk in portfolio margin.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-risk-offset-amount
+func (c *Account) SetRiskOffsetAmount(req requests.SetRiskOffsetAmount) (response responses.SetRiskOffsetAmount, err error) {
+	p := "/api/v5/account/set-riskOffset-amt"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
 // GetMaxWithdrawals
 //
 // https://www.okex.com/docs-v5/en/#rest-api-account-get-maximum-withdrawals
@@ -368,3 +569,199 @@ func (c *Account) GetMaxWithdrawals(req requests.GetBalance) (response responses
 
 	return
 }
+
+// SpotBorrowRepay
+// Borrow or repay manually, only applicable to multi-currency margin and portfolio margin with spot borrowing enabled.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-spot-manual-borrow-repay
+func (c *Account) SpotBorrowRepay(req requests.SpotBorrowRepay) (response responses.SpotBorrowRepay, err error) {
+	p := "/api/v5/account/spot-manual-borrow-repay"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetAutoRepay
+// Turn the automatic repayment of spot borrowings on or off.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-set-auto-repay
+func (c *Account) SetAutoRepay(req requests.SetAutoRepay) (response responses.SetAutoRepay, err error) {
+	p := "/api/v5/account/set-auto-repay"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetSpotBorrowRepayHistory
+// Retrieve the borrowing and repaying history of multi-currency margin and portfolio margin, the most recent first.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-borrow-repay-history
+func (c *Account) GetSpotBorrowRepayHistory(req requests.GetSpotBorrowRepayHistory) (response responses.GetSpotBorrowRepayHistory, err error) {
+	p := "/api/v5/account/spot-borrow-repay-history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// VIPBorrowRepay
+// Borrow or repay a VIP loan.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-vip-loans-borrow-and-repay
+func (c *Account) VIPBorrowRepay(req requests.VIPBorrowRepay) (response responses.VIPBorrowRepay, err error) {
+	p := "/api/v5/account/borrow-repay"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetVIPBorrowRepayHistory
+// Retrieve the VIP loans borrowing and repaying history.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-borrow-and-repay-history-for-vip-loans
+func (c *Account) GetVIPBorrowRepayHistory(req requests.GetVIPBorrowRepayHistory) (response responses.GetVIPBorrowRepayHistory, err error) {
+	p := "/api/v5/account/borrow-repay-history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetInterestLimits
+// Retrieve the borrowing interest and the limits of VIP loans and market loans.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-borrow-interest-and-limit
+func (c *Account) GetInterestLimits(req requests.GetInterestLimits) (response responses.GetInterestLimits, err error) {
+	p := "/api/v5/account/interest-limits"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetVIPInterestAccrued
+// Retrieve the interest accrued by VIP loans.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-vip-interest-accrued-data
+func (c *Account) GetVIPInterestAccrued(req requests.GetVIPInterest) (response responses.GetVIPInterest, err error) {
+	p := "/api/v5/account/vip-interest-accrued"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetVIPInterestDeducted
+// Retrieve the interest deducted for VIP loans.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-vip-interest-deducted-data
+func (c *Account) GetVIPInterestDeducted(req requests.GetVIPInterest) (response responses.GetVIPInterest, err error) {
+	p := "/api/v5/account/vip-interest-deducted"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetVIPLoanOrders
+// Retrieve the VIP loan orders.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-vip-loan-order-list
+func (c *Account) GetVIPLoanOrders(req requests.GetVIPLoanOrders) (response responses.GetVIPLoanOrders, err error) {
+	p := "/api/v5/account/vip-loan-order-list"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetVIPLoanOrderDetail
+// Retrieve the borrowings, repayments and rate changes of a VIP loan order.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-get-vip-loan-order-detail
+func (c *Account) GetVIPLoanOrderDetail(req requests.GetVIPLoanOrderDetail) (response responses.GetVIPLoanOrderDetail, err error) {
+	p := "/api/v5/account/vip-loan-order-detail"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// PositionBuilder
+// Calculate the margin requirements of hypothetical positions and assets, added to the real ones or alone.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-position-builder-new
+func (c *Account) PositionBuilder(req requests.PositionBuilder) (response responses.PositionBuilder, err error) {
+	p := "/api/v5/account/position-builder"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
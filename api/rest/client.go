@@ -29,6 +29,10 @@ type ClientRest struct {
 	Market      *Market
 	PublicData  *PublicData
 	TradeData   *TradeData
+	Convert     *Convert
+	TradingBot  *TradingBot
+	CopyTrading *CopyTrading
+	RFQ         *RFQ
 	apiKey      string
 	secretKey   []byte
 	passphrase  string
@@ -63,16 +67,44 @@ func NewClient(
 	c.Market = NewMarket(c)
 	c.PublicData = NewPublicData(c)
 	c.TradeData = NewTradeData(c)
+	c.Convert = NewConvert(c)
+	c.TradingBot = NewTradingBot(c)
+	c.CopyTrading = NewCopyTrading(c)
+	c.RFQ = NewRFQ(c)
 	return c
 }
 
-// Do the http request to the server
+// Do the http request to the server, params are sent as the query of GET requests and as a json object otherwise
 func (c *ClientRest) Do(method, path string, private bool, params ...map[string]string) (*http.Response, error) {
+	if method == http.MethodGet {
+		var query map[string]string
+		if len(params) > 0 {
+			query = params[0]
+		}
+		return c.do(method, path, private, query, nil, nil)
+	}
+	j, err := json.Marshal(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return c.do(method, path, private, nil, j, nil)
+}
+
+// DoBody sends body marshalled as is, it's used by the requests having arrays, nested objects or booleans which params can't carry.
+// header is added to the request, it can be nil.
+func (c *ClientRest) DoBody(method, path string, private bool, body interface{}, header http.Header) (*http.Response, error) {
+	j, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(method, path, private, nil, j, header)
+}
+
+func (c *ClientRest) do(method, path string, private bool, query map[string]string, j []byte, header http.Header) (*http.Response, error) {
 	u := fmt.Sprintf("%s%s", c.baseURL, path)
 	var (
 		r    *http.Request
 		err  error
-		j    []byte
 		body string
 	)
 	if method == http.MethodGet {
@@ -81,21 +113,15 @@ func (c *ClientRest) Do(method, path string, private bool, params ...map[string]
 			return nil, err
 		}
 
-		if len(params) > 0 {
+		if len(query) > 0 {
 			q := r.URL.Query()
-			for k, v := range params[0] {
+			for k, v := range query {
 				q.Add(k, strings.ReplaceAll(v, "\"", ""))
 			}
 			r.URL.RawQuery = q.Encode()
-			if len(params[0]) > 0 {
-				path += "?" + r.URL.RawQuery
-			}
+			path += "?" + r.URL.RawQuery
 		}
 	} else {
-		j, err = json.Marshal(params[0])
-		if err != nil {
-			return nil, err
-		}
 		body = string(j)
 		if body == "{}" {
 			body = ""
@@ -106,8 +132,10 @@ func (c *ClientRest) Do(method, path string, private bool, params ...map[string]
 		}
 		r.Header.Add("Content-Type", "application/json")
 	}
-	if err != nil {
-		return nil, err
+	for k, v := range header {
+		for _, h := range v {
+			r.Header.Add(k, h)
+		}
 	}
 	if private {
 		timestamp, sign := c.sign(method, path, body)
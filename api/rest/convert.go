@@ -0,0 +1,112 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pefish/go-okx"
+	requests "github.com/pefish/go-okx/requests/rest/convert"
+	responses "github.com/pefish/go-okx/responses/convert"
+)
+
+// Convert
+// Trade one currency for another at a quoted price, from and to the funding account.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-get-convert-currencies
+type Convert struct {
+	client *ClientRest
+}
+
+// NewConvert returns a pointer to a fresh Convert
+func NewConvert(c *ClientRest) *Convert {
+	return &Convert{c}
+}
+
+// GetCurrencies
+// Retrieve the currencies that can be converted.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-get-convert-currencies
+func (c *Convert) GetCurrencies() (response responses.GetCurrencies, err error) {
+	p := "/api/v5/asset/convert/currencies"
+	res, err := c.client.Do(http.MethodGet, p, true)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetCurrencyPair
+// Retrieve the pair of two currencies and the amounts that can be converted.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-get-convert-currency-pair
+func (c *Convert) GetCurrencyPair(req requests.GetCurrencyPair) (response responses.GetCurrencyPair, err error) {
+	p := "/api/v5/asset/convert/currency-pair"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// EstimateQuote
+// Get a quote, it can be traded until quoteTime + ttlMs.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-estimate-quote
+func (c *Convert) EstimateQuote(req requests.EstimateQuote) (response responses.EstimateQuote, err error) {
+	p := "/api/v5/asset/convert/estimate-quote"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// Trade
+// Convert at the price of a quote.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-convert-trade
+func (c *Convert) Trade(req requests.Trade) (response responses.Trade, err error) {
+	p := "/api/v5/asset/convert/trade"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetHistory
+// Retrieve the convert trades of the last 3 months.
+//
+// https://www.okx.com/docs-v5/en/#funding-account-rest-api-get-convert-history
+func (c *Convert) GetHistory(req requests.GetHistory) (response responses.Trade, err error) {
+	p := "/api/v5/asset/convert/history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
@@ -0,0 +1,176 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/pefish/go-okx"
+	requests "github.com/pefish/go-okx/requests/rest/copytrading"
+	responses "github.com/pefish/go-okx/responses/copytrading"
+)
+
+// CopyTrading
+// Lead trading: the lead positions, their take profit and stop loss, the instruments and the profit sharing.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading
+type CopyTrading struct {
+	client *ClientRest
+}
+
+// NewCopyTrading returns a pointer to a fresh CopyTrading
+func NewCopyTrading(c *ClientRest) *CopyTrading {
+	return &CopyTrading{c}
+}
+
+// GetSubPositions
+// Retrieve the open lead positions.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-existing-leading-positions
+//
+// Retrieve the closed lead positions of the last 3 months.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-leading-position-history
+func (c *CopyTrading) GetSubPositions(req requests.GetSubPositions, arch bool) (response responses.GetSubPositions, err error) {
+	p := "/api/v5/copytrading/current-subpositions"
+	if arch {
+		p = "/api/v5/copytrading/subpositions-history"
+	}
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// PlaceSubPositionTpSl
+// Place or amend the take profit and the stop loss of a lead position.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-post-place-leading-stop-order
+func (c *CopyTrading) PlaceSubPositionTpSl(req requests.PlaceSubPositionTpSl) (response responses.SubPositionAction, err error) {
+	p := "/api/v5/copytrading/algo-order"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CloseSubPosition
+// Close a lead position, its followers close theirs.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-post-close-leading-position
+func (c *CopyTrading) CloseSubPosition(req requests.CloseSubPosition) (response responses.SubPositionAction, err error) {
+	p := "/api/v5/copytrading/close-subposition"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetInstruments
+// Retrieve the instruments that can be lead traded and whether they are.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-leading-instruments
+func (c *CopyTrading) GetInstruments(req requests.GetInstruments) (response responses.GetInstruments, err error) {
+	p := "/api/v5/copytrading/instruments"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// SetInstruments
+// Set the instruments that are lead traded.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-post-amend-leading-instruments
+func (c *CopyTrading) SetInstruments(req requests.SetInstruments) (response responses.GetInstruments, err error) {
+	p := "/api/v5/copytrading/set-instruments"
+	m := okex.S2M(req)
+	m["instId"] = strings.Join(req.InstID, ",")
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetProfitSharingDetails
+// Retrieve the profit shared by the followers in the last 3 months.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-profit-sharing-details
+func (c *CopyTrading) GetProfitSharingDetails(req requests.GetProfitSharingDetails) (response responses.GetProfitSharingDetails, err error) {
+	p := "/api/v5/copytrading/profit-sharing-details"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetTotalProfitSharing
+// Retrieve the total profit shared by the followers since becoming a lead trader.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-total-profit-sharing
+func (c *CopyTrading) GetTotalProfitSharing(req requests.GetTotalProfitSharing) (response responses.GetTotalProfitSharing, err error) {
+	p := "/api/v5/copytrading/total-profit-sharing"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetUnrealizedProfitSharing
+// Retrieve the profit expected to be shared by the followers.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-get-unrealized-profit-sharing-details
+func (c *CopyTrading) GetUnrealizedProfitSharing(req requests.GetUnrealizedProfitSharing) (response responses.GetUnrealizedProfitSharing, err error) {
+	p := "/api/v5/copytrading/unrealized-profit-sharing-details"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
@@ -252,3 +252,20 @@ func (c *PublicData) GetUnderlying(req requests.GetUnderlying) (response respons
 	err = d.Decode(&response)
 	return
 }
+
+// ConvertContractCoin
+// Convert the crypto value to the number of contracts, or vice versa
+//
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-unit-convert
+func (c *PublicData) ConvertContractCoin(req requests.ConvertContractCoin) (response responses.ConvertContractCoin, err error) {
+	p := "/api/v5/public/convert-contract-coin"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, false, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+	return
+}
@@ -0,0 +1,284 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pefish/go-okx"
+	requests "github.com/pefish/go-okx/requests/rest/rfq"
+	responses "github.com/pefish/go-okx/responses/rfq"
+)
+
+// RFQ
+// Block trading: requests for quote of multi-leg structures, the quotes of the makers and the executed block trades.
+//
+// https://www.okx.com/docs-v5/en/#block-trading
+type RFQ struct {
+	client *ClientRest
+}
+
+// NewRFQ returns a pointer to a fresh RFQ
+func NewRFQ(c *ClientRest) *RFQ {
+	return &RFQ{c}
+}
+
+// GetCounterparties
+// Retrieve the counterparties an RFQ can be sent to.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-get-counterparties
+func (c *RFQ) GetCounterparties() (response responses.GetCounterparties, err error) {
+	p := "/api/v5/rfq/counterparties"
+	res, err := c.client.Do(http.MethodGet, p, true)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CreateRfq
+// Create an RFQ of up to 15 legs and send it to the counterparties.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-create-rfq
+func (c *RFQ) CreateRfq(req requests.CreateRfq) (response responses.Rfq, err error) {
+	p := "/api/v5/rfq/create-rfq"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelRfq
+// Cancel an active RFQ.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-rfq
+func (c *RFQ) CancelRfq(req requests.CancelRfq) (response responses.CancelRfq, err error) {
+	p := "/api/v5/rfq/cancel-rfq"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelBatchRfqs
+// Cancel up to 100 active RFQs.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-multiple-rfqs
+func (c *RFQ) CancelBatchRfqs(req requests.CancelBatchRfqs) (response responses.CancelRfq, err error) {
+	p := "/api/v5/rfq/cancel-batch-rfqs"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelAllRfqs
+// Cancel all the active RFQs.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-all-rfqs
+func (c *RFQ) CancelAllRfqs() (response responses.CancelRfq, err error) {
+	p := "/api/v5/rfq/cancel-all-rfqs"
+	res, err := c.client.Do(http.MethodPost, p, true, map[string]string{})
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CreateQuote
+// Quote an RFQ the account is a counterparty of.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-create-quote
+func (c *RFQ) CreateQuote(req requests.CreateQuote) (response responses.Quote, err error) {
+	p := "/api/v5/rfq/create-quote"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelQuote
+// Cancel an active quote.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-quote
+func (c *RFQ) CancelQuote(req requests.CancelQuote) (response responses.CancelQuote, err error) {
+	p := "/api/v5/rfq/cancel-quote"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelBatchQuotes
+// Cancel up to 100 active quotes.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-multiple-quotes
+func (c *RFQ) CancelBatchQuotes(req requests.CancelBatchQuotes) (response responses.CancelQuote, err error) {
+	p := "/api/v5/rfq/cancel-batch-quotes"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelAllQuotes
+// Cancel all the active quotes.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-cancel-all-quotes
+func (c *RFQ) CancelAllQuotes() (response responses.CancelQuote, err error) {
+	p := "/api/v5/rfq/cancel-all-quotes"
+	res, err := c.client.Do(http.MethodPost, p, true, map[string]string{})
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// ExecuteQuote
+// Execute a quote of an RFQ created by the account, only the creator of the RFQ can execute it.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-execute-quote
+func (c *RFQ) ExecuteQuote(req requests.ExecuteQuote) (response responses.BlockTrade, err error) {
+	p := "/api/v5/rfq/execute-quote"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// ResetMMP
+// Reset the market maker protection of block trading, quoting is possible again once it was triggered.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-reset-mmp-status
+func (c *RFQ) ResetMMP() (response responses.MMPReset, err error) {
+	p := "/api/v5/rfq/mmp-reset"
+	res, err := c.client.Do(http.MethodPost, p, true, map[string]string{})
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetRfqs
+// Retrieve the RFQs the account created or received, the newest first.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-get-rfqs
+func (c *RFQ) GetRfqs(req requests.GetRfqs) (response responses.Rfq, err error) {
+	p := "/api/v5/rfq/rfqs"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetQuotes
+// Retrieve the quotes the account created or received, the newest first.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-get-quotes
+func (c *RFQ) GetQuotes(req requests.GetQuotes) (response responses.Quote, err error) {
+	p := "/api/v5/rfq/quotes"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetTrades
+// Retrieve the block trades of the account, the newest first.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-get-trades
+func (c *RFQ) GetTrades(req requests.GetTrades) (response responses.BlockTrade, err error) {
+	p := "/api/v5/rfq/trades"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetPublicTrades
+// Retrieve the block trades executed on OKX, the legs are only visible once the trades are 15 minutes old.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-rest-api-get-public-multi-leg-transactions-of-block-trades
+func (c *RFQ) GetPublicTrades(req requests.GetPublicTrades) (response responses.GetPublicTrades, err error) {
+	p := "/api/v5/rfq/public-trades"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, false, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
@@ -2,10 +2,14 @@ package rest
 
 import (
 	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
 	"github.com/pefish/go-okx"
 	requests "github.com/pefish/go-okx/requests/rest/trade"
 	responses "github.com/pefish/go-okx/responses/trade"
-	"net/http"
+	"github.com/pkg/errors"
 )
 
 // Trade
@@ -23,17 +27,29 @@ func NewTrade(c *ClientRest) *Trade {
 // PlaceOrder
 // You can place an order only if you have sufficient funds.
 //
-// https://www.okex.com/docs-v5/en/#rest-api-trade-get-positions
+// https://www.okex.com/docs-v5/en/#rest-api-trade-place-order
+//
+// Orders are validated first, the earliest ExpTime is sent as the expTime of the request.
 func (c *Trade) PlaceOrder(req []requests.PlaceOrder) (response responses.PlaceOrder, err error) {
+	if len(req) == 0 {
+		err = errors.New("no order in the request")
+		return
+	}
 	p := "/api/v5/trade/order"
 	var tmp interface{}
 	tmp = req[0]
 	if len(req) > 1 {
 		tmp = req
-		p = "/api/trade/batch-orders"
+		p = "/api/v5/trade/batch-orders"
 	}
-	m := okex.S2M(tmp)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	expTimes := make([]time.Time, len(req))
+	for i, order := range req {
+		if err = order.Validate(); err != nil {
+			return
+		}
+		expTimes[i] = order.ExpTime
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, tmp, expTimeHeader(expTimes))
 	if err != nil {
 		return
 	}
@@ -45,13 +61,23 @@ func (c *Trade) PlaceOrder(req []requests.PlaceOrder) (response responses.PlaceO
 }
 
 // PlaceMultipleOrders
-// Cancel an incomplete order.
+// Place orders in batches. Maximum 20 orders can be placed per request.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-trade-place-multiple-orders
 func (c *Trade) PlaceMultipleOrders(req []requests.PlaceOrder) (response responses.PlaceOrder, err error) {
-	p := "/api/v5/trade/batch-order"
-	m := okex.S2M(req)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if len(req) == 0 {
+		err = errors.New("no order in the request")
+		return
+	}
+	p := "/api/v5/trade/batch-orders"
+	expTimes := make([]time.Time, len(req))
+	for i, order := range req {
+		if err = order.Validate(); err != nil {
+			return
+		}
+		expTimes[i] = order.ExpTime
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, expTimeHeader(expTimes))
 	if err != nil {
 		return
 	}
@@ -71,15 +97,23 @@ func (c *Trade) PlaceMultipleOrders(req []requests.PlaceOrder) (response respons
 //
 // https://www.okex.com/docs-v5/en/#rest-api-trade-cancel-multiple-orders
 func (c *Trade) CandleOrder(req []requests.CancelOrder) (response responses.PlaceOrder, err error) {
+	if len(req) == 0 {
+		err = errors.New("no order in the request")
+		return
+	}
 	p := "/api/v5/trade/cancel-order"
 	var tmp interface{}
 	tmp = req[0]
 	if len(req) > 1 {
 		tmp = req
-		p = "/api/trade/cancel-batch-orders"
+		p = "/api/v5/trade/cancel-batch-orders"
 	}
-	m := okex.S2M(tmp)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	for _, order := range req {
+		if err = order.Validate(); err != nil {
+			return
+		}
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, tmp, nil)
 	if err != nil {
 		return
 	}
@@ -97,16 +131,28 @@ func (c *Trade) CandleOrder(req []requests.CancelOrder) (response responses.Plac
 // Amend incomplete orders in batches. Maximum 20 orders can be amended at a time. Request parameters should be passed in the form of an array.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-trade-amend-multiple-orders
-func (c *Trade) AmendOrder(req []requests.OrderList) (response responses.AmendOrder, err error) {
+//
+// Amendments are validated first, the earliest ExpTime is sent as the expTime of the request.
+func (c *Trade) AmendOrder(req []requests.AmendOrder) (response responses.AmendOrder, err error) {
+	if len(req) == 0 {
+		err = errors.New("no order in the request")
+		return
+	}
 	p := "/api/v5/trade/amend-order"
 	var tmp interface{}
 	tmp = req[0]
 	if len(req) > 1 {
 		tmp = req
-		p = "/api/trade/amend-batch-orders"
+		p = "/api/v5/trade/amend-batch-orders"
+	}
+	expTimes := make([]time.Time, len(req))
+	for i, order := range req {
+		if err = order.Validate(); err != nil {
+			return
+		}
+		expTimes[i] = order.ExpTime
 	}
-	m := okex.S2M(tmp)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	res, err := c.client.DoBody(http.MethodPost, p, true, tmp, expTimeHeader(expTimes))
 	if err != nil {
 		return
 	}
@@ -122,8 +168,7 @@ func (c *Trade) AmendOrder(req []requests.OrderList) (response responses.AmendOr
 // https://www.okex.com/docs-v5/en/#rest-api-trade-close-positions
 func (c *Trade) ClosePosition(req requests.ClosePosition) (response responses.ClosePosition, err error) {
 	p := "/api/v5/trade/close-position"
-	m := okex.S2M(req)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
 	if err != nil {
 		return
 	}
@@ -177,7 +222,7 @@ func (c *Trade) GetOrderList(req requests.OrderList) (response responses.OrderLi
 func (c *Trade) GetOrderHistory(req requests.OrderList, arch bool) (response responses.OrderList, err error) {
 	p := "/api/v5/trade/orders-history"
 	if arch {
-		p = "/api/trade/orders-history-archive"
+		p = "/api/v5/trade/orders-history-archive"
 	}
 	m := okex.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
@@ -201,7 +246,7 @@ func (c *Trade) GetOrderHistory(req requests.OrderList, arch bool) (response res
 func (c *Trade) GetTransactionDetails(req requests.TransactionDetails, arch bool) (response responses.TransactionDetail, err error) {
 	p := "/api/v5/trade/fills"
 	if arch {
-		p = "/api/trade/fills-history"
+		p = "/api/v5/trade/fills-history"
 	}
 	m := okex.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
@@ -214,16 +259,33 @@ func (c *Trade) GetTransactionDetails(req requests.TransactionDetails, arch bool
 	return
 }
 
-// PlaceAlgoOrder
-// The algo order includes trigger order, oco order, conditional order,iceberg order and twap order.
+// GetFillsHistory
+// Retrieve the fills of the last 3 months of an instrument type, most recent first.
 //
-// `iceberg` order and `twap` order just supported on demo trading
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-transaction-details-last-3-months
+func (c *Trade) GetFillsHistory(req requests.FillsHistory) (response responses.GetFillsHistory, err error) {
+	p := "/api/v5/trade/fills-history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+	return
+}
+
+// PlaceAlgoOrder
+// The algo order includes trigger order, oco order, conditional order, iceberg order, twap order, trailing stop order and chase order.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-trade-place-algo-order
 func (c *Trade) PlaceAlgoOrder(req requests.PlaceAlgoOrder) (response responses.PlaceAlgoOrder, err error) {
 	p := "/api/v5/trade/order-algo"
-	m := okex.S2M(req)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	if err = req.Validate(); err != nil {
+		return
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
 	if err != nil {
 		return
 	}
@@ -235,13 +297,12 @@ func (c *Trade) PlaceAlgoOrder(req requests.PlaceAlgoOrder) (response responses.
 }
 
 // CancelAlgoOrder
-// Cancel unfilled algo orders(trigger order, oco order, conditional order). A maximum of 10 orders can be canceled at a time. Request parameters should be passed in the form of an array.
+// Cancel unfilled algo orders(trigger order, oco order, conditional order, trailing stop order and chase order). A maximum of 10 orders can be canceled at a time. Request parameters should be passed in the form of an array.
 //
 // https://www.okex.com/docs-v5/en/#rest-api-trade-cancel-algo-order
-func (c *Trade) CancelAlgoOrder(req requests.CancelAlgoOrder) (response responses.CancelAlgoOrder, err error) {
+func (c *Trade) CancelAlgoOrder(req []requests.CancelAlgoOrder) (response responses.CancelAlgoOrder, err error) {
 	p := "/api/v5/trade/cancel-algos"
-	m := okex.S2M(req)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
 	if err != nil {
 		return
 	}
@@ -255,13 +316,51 @@ func (c *Trade) CancelAlgoOrder(req requests.CancelAlgoOrder) (response response
 // CancelAdvanceAlgoOrder
 // Cancel unfilled algo orders(iceberg order and twap order). A maximum of 10 orders can be canceled at a time. Request parameters should be passed in the form of an array.
 //
-// # Only released on demo trading
-//
 // https://www.okex.com/docs-v5/en/#rest-api-trade-cancel-advance-algo-order
-func (c *Trade) CancelAdvanceAlgoOrder(req requests.CancelAlgoOrder) (response responses.CancelAlgoOrder, err error) {
+func (c *Trade) CancelAdvanceAlgoOrder(req []requests.CancelAlgoOrder) (response responses.CancelAlgoOrder, err error) {
 	p := "/api/v5/trade/cancel-advance-algos"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// AmendAlgoOrder
+// Amend the size or the prices of an untriggered trigger, conditional or oco order.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-post-amend-algo-order
+func (c *Trade) AmendAlgoOrder(req requests.AmendAlgoOrder) (response responses.AmendAlgoOrder, err error) {
+	p := "/api/v5/trade/amend-algos"
+	if err = req.Validate(); err != nil {
+		return
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetAlgoOrderDetail
+// Retrieve an algo order by algoId or algoClOrdId.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-get-algo-order-details
+func (c *Trade) GetAlgoOrderDetail(req requests.AlgoOrderDetails) (response responses.AlgoOrderList, err error) {
+	p := "/api/v5/trade/order-algo"
+	if err = req.Validate(); err != nil {
+		return
+	}
 	m := okex.S2M(req)
-	res, err := c.client.Do(http.MethodPost, p, true, m)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
 		return
 	}
@@ -275,20 +374,175 @@ func (c *Trade) CancelAdvanceAlgoOrder(req requests.CancelAlgoOrder) (response r
 // GetAlgoOrderList
 // Retrieve a list of untriggered Algo orders under the current account.
 //
-// `iceberg` order and `twap` order just supported on demo trading
-//
 // https://www.okex.com/docs-v5/en/#rest-api-trade-get-algo-order-list
 //
 // Retrieve a list of all algo orders under the current account in the last 3 months.
 //
-// `iceberg` order and `twap` order just supported on demo trading
-//
 // https://www.okex.com/docs-v5/en/#rest-api-trade-get-algo-order-history
 func (c *Trade) GetAlgoOrderList(req requests.AlgoOrderList, arch bool) (response responses.AlgoOrderList, err error) {
 	p := "/api/v5/trade/orders-algo-pending"
 	if arch {
-		p = "/api/trade/orders-algo-history"
+		p = "/api/v5/trade/orders-algo-history"
+	}
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// MassCancel
+// Cancel all the MMP pending orders of an instrument family, only OPTION is supported.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-mass-cancel-order
+func (c *Trade) MassCancel(req requests.MassCancel) (response responses.MassCancel, err error) {
+	p := "/api/v5/trade/mass-cancel"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// CancelAllAfter
+// Cancel all pending orders after the countdown timeout, it's a dead man's switch: the countdown restarts on every call and a timeout of 0 disarms it.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-cancel-all-after
+func (c *Trade) CancelAllAfter(req requests.CancelAllAfter) (response responses.CancelAllAfter, err error) {
+	p := "/api/v5/trade/cancel-all-after"
+	if err = req.Validate(); err != nil {
+		return
+	}
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
 	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetOneClickRepayCurrencies
+// Retrieve the debts and the assets that can repay them, only applicable to multi-currency margin.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-one-click-repay-currency-list
+func (c *Trade) GetOneClickRepayCurrencies(req requests.GetOneClickRepayCurrencies) (response responses.GetOneClickRepayCurrencies, err error) {
+	p := "/api/v5/trade/one-click-repay-currency-list"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// OneClickRepay
+// Trade an asset for the debt currencies and repay them.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-trade-one-click-repay
+func (c *Trade) OneClickRepay(req requests.OneClickRepay) (response responses.OneClickRepay, err error) {
+	p := "/api/v5/trade/one-click-repay"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetOneClickRepayHistory
+// Retrieve the one-click repay history of the last 7 days.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-one-click-repay-history
+func (c *Trade) GetOneClickRepayHistory(req requests.GetOneClickRepayHistory) (response responses.OneClickRepay, err error) {
+	p := "/api/v5/trade/one-click-repay-history"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// expTimeHeader returns the expTime header of the earliest non zero time of times, nil when there is none
+func expTimeHeader(times []time.Time) http.Header {
+	var exp time.Time
+	for _, t := range times {
+		if !t.IsZero() && (exp.IsZero() || t.Before(exp)) {
+			exp = t
+		}
+	}
+	if exp.IsZero() {
+		return nil
+	}
+	h := http.Header{}
+	h.Set("expTime", strconv.FormatInt(exp.UnixMilli(), 10))
+	return h
+}
+
+// GetEasyConvertCurrencies
+// Retrieve the small balances that can be converted and the currencies they can be converted into.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-easy-convert-currency-list
+func (c *Trade) GetEasyConvertCurrencies(req requests.GetEasyConvertCurrencies) (response responses.GetEasyConvertCurrencies, err error) {
+	p := "/api/v5/trade/easy-convert-currency-list"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// EasyConvert
+// Convert small balances into another currency, up to 5 currencies at once.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-place-easy-convert
+func (c *Trade) EasyConvert(req requests.EasyConvert) (response responses.EasyConvert, err error) {
+	p := "/api/v5/trade/easy-convert"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetEasyConvertHistory
+// Retrieve the easy convert trades of the last 7 days.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-get-easy-convert-history
+func (c *Trade) GetEasyConvertHistory(req requests.GetEasyConvertHistory) (response responses.EasyConvert, err error) {
+	p := "/api/v5/trade/easy-convert-history"
 	m := okex.S2M(req)
 	res, err := c.client.Do(http.MethodGet, p, true, m)
 	if err != nil {
@@ -0,0 +1,48 @@
+package rest
+
+import (
+	"testing"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+)
+
+func TestTradeEmptyRequest(t *testing.T) {
+	c := NewClient(&i_logger.DefaultLogger, "", "", "", okex.RestURL, okex.NormalServer)
+	if _, err := c.Trade.PlaceOrder(nil); err == nil {
+		t.Error("PlaceOrder(nil) succeeded")
+	}
+	if _, err := c.Trade.PlaceMultipleOrders(nil); err == nil {
+		t.Error("PlaceMultipleOrders(nil) succeeded")
+	}
+	if _, err := c.Trade.CandleOrder(nil); err == nil {
+		t.Error("CandleOrder(nil) succeeded")
+	}
+	if _, err := c.Trade.AmendOrder(nil); err == nil {
+		t.Error("AmendOrder(nil) succeeded")
+	}
+}
+
+func TestExpTimeHeader(t *testing.T) {
+	early := time.UnixMilli(1597026383085)
+	late := early.Add(time.Second)
+	tests := []struct {
+		name  string
+		times []time.Time
+		want  string
+	}{
+		{"none", nil, ""},
+		{"zero", []time.Time{{}}, ""},
+		{"earliest", []time.Time{late, {}, early}, "1597026383085"},
+	}
+	for _, tt := range tests {
+		var got string
+		if h := expTimeHeader(tt.times); h != nil {
+			got = h.Get("expTime")
+		}
+		if got != tt.want {
+			t.Errorf("%s: expTime %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pefish/go-okx"
+	requests "github.com/pefish/go-okx/requests/rest/tradingbot"
+	responses "github.com/pefish/go-okx/responses/tradingbot"
+)
+
+// TradingBot
+// Spot and contract grid algo orders.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading
+type TradingBot struct {
+	client *ClientRest
+}
+
+// NewTradingBot returns a pointer to a fresh TradingBot
+func NewTradingBot(c *ClientRest) *TradingBot {
+	return &TradingBot{c}
+}
+
+// PlaceGridOrder
+// Create a spot or contract grid.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-post-place-grid-algo-order
+func (c *TradingBot) PlaceGridOrder(req requests.PlaceGridOrder) (response responses.PlaceGridOrder, err error) {
+	p := "/api/v5/tradingBot/grid/order-algo"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// AmendGridOrder
+// Amend the take profit, the stop loss and the triggers of a grid.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-post-amend-grid-algo-order
+func (c *TradingBot) AmendGridOrder(req requests.AmendGridOrder) (response responses.PlaceGridOrder, err error) {
+	p := "/api/v5/tradingBot/grid/amend-order-algo"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// StopGridOrder
+// Stop up to 10 grids.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-post-stop-grid-algo-order
+func (c *TradingBot) StopGridOrder(req []requests.StopGridOrder) (response responses.PlaceGridOrder, err error) {
+	p := "/api/v5/tradingBot/grid/stop-order-algo"
+	res, err := c.client.DoBody(http.MethodPost, p, true, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetGridOrderList
+// Retrieve the running grids.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-algo-order-list
+//
+// Retrieve the stopped grids of the last 3 months.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-algo-order-history
+func (c *TradingBot) GetGridOrderList(req requests.GridOrderList, arch bool) (response responses.GridOrderList, err error) {
+	p := "/api/v5/tradingBot/grid/orders-algo-pending"
+	if arch {
+		p = "/api/v5/tradingBot/grid/orders-algo-history"
+	}
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetGridOrderDetails
+// Retrieve a grid with its investment, profits and current assets.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-algo-order-details
+func (c *TradingBot) GetGridOrderDetails(req requests.GridOrderDetails) (response responses.GridOrderList, err error) {
+	p := "/api/v5/tradingBot/grid/orders-algo-details"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetGridSubOrders
+// Retrieve the live or filled orders placed by a grid.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-algo-sub-orders
+func (c *TradingBot) GetGridSubOrders(req requests.GridSubOrders) (response responses.GridSubOrders, err error) {
+	p := "/api/v5/tradingBot/grid/sub-orders"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetGridPositions
+// Retrieve the position of a contract grid.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-algo-order-positions
+func (c *TradingBot) GetGridPositions(req requests.GridPositions) (response responses.GridPositions, err error) {
+	p := "/api/v5/tradingBot/grid/positions"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, true, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// GetGridAIParam
+// Retrieve the grid parameters suggested from back testing.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-get-grid-ai-parameter-public
+func (c *TradingBot) GetGridAIParam(req requests.GridAIParam) (response responses.GridAIParam, err error) {
+	p := "/api/v5/tradingBot/grid/ai-param"
+	m := okex.S2M(req)
+	res, err := c.client.Do(http.MethodGet, p, false, m)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
+
+// ComputeGridMinInvestment
+// Compute the minimum investment of a grid.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-post-compute-min-investment-public
+func (c *TradingBot) ComputeGridMinInvestment(req requests.GridMinInvestment) (response responses.GridMinInvestment, err error) {
+	p := "/api/v5/tradingBot/grid/min-investment"
+	res, err := c.client.DoBody(http.MethodPost, p, false, req, nil)
+	if err != nil {
+		return
+	}
+	defer res.Body.Close()
+	d := json.NewDecoder(res.Body)
+	err = d.Decode(&response)
+
+	return
+}
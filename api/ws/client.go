@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -19,6 +21,8 @@ import (
 
 // ClientWs is the websocket api client
 //
+// Event channels are read by the dispatcher goroutines, assign them before the first subscription or pass them to the subscribe methods.
+//
 // https://www.okex.com/docs-v5/en/#websocket-api
 type ClientWs struct {
 	Cancel        context.CancelFunc
@@ -27,12 +31,19 @@ type ClientWs struct {
 	UnsubscribeCh chan *events.Unsubscribe
 	LoginChan     chan *events.Login
 	SuccessChan   chan *events.Success
+	NoticeChan    chan *events.Notice
 	url           map[bool]okex.BaseURL // need or not login -> url
+	businessURL   okex.BaseURL          // algo orders, candles, grid, copy trading and block trading channels
 	apiKey        string
 	secretKey     []byte
 	passphrase    string
-	AuthRequested *time.Time
-	Authorized    bool
+	authorized    bool
+	privateRead   atomic.Int64 // unix nano of the last message or pong read on a private socket
+	handlers      map[string]*handler
+	listeners     map[string][]*handler
+	outputs       map[string]*handler // channel name -> the queue feeding it
+	subscriptions []*subscription
+	mu            sync.RWMutex // guards authorized, businessURL, handlers, listeners, outputs, subscriptions and the event channels
 	Private       *Private
 	Public        *Public
 	Trade         *Trade
@@ -45,8 +56,99 @@ const (
 	writeWait  = 3 * time.Second
 	pongWait   = 30 * time.Second
 	PingPeriod = (pongWait * 8) / 10
+
+	// replyWait is how long a one-shot or unsubscribed socket waits for the reply to its op before it's closed anyway
+	replyWait = 30 * time.Second
+
+	// serviceUpgradeNotice is pushed by OKX shortly before a socket is closed for maintenance
+	serviceUpgradeNotice = "64008"
 )
 
+// loginWait is how long a login waits for its reply
+var loginWait = 10 * time.Second
+
+// loginErrors are the codes of the error events answering a login, other error events are about other ops of the socket
+//
+// https://www.okx.com/docs-v5/en/#error-code-websocket-public
+var loginErrors = map[int64]bool{
+	60001: true, // OK-ACCESS-KEY can not be empty
+	60002: true, // OK-ACCESS-SIGN can not be empty
+	60003: true, // OK-ACCESS-PASSPHRASE can not be empty
+	60004: true, // Invalid OK-ACCESS-TIMESTAMP
+	60005: true, // Invalid OK-ACCESS-KEY
+	60006: true, // Timestamp request expired
+	60007: true, // Invalid sign
+	60008: true, // Login is not supported for public channels
+	60009: true, // Login failed
+	60023: true, // Bulk login requests too frequent
+	60024: true, // Wrong passphrase
+	60026: true, // Batch login by APIKey and token simultaneously is not supported
+	60032: true, // API key doesn't exist
+}
+
+// conn is a single socket opened by Send together with the channels its goroutines talk through
+type conn struct {
+	ws           *websocket.Conn
+	senderChan   chan []byte
+	loginChan    chan error
+	errChan      chan<- *connError // the socket is closed on a send error when it's nil
+	noticeChan   chan<- *conn
+	private      bool        // the socket logs in
+	subscribed   bool        // the socket streams a subscription, reads on a private one are reported by LastPrivateRead
+	replaces     *conn       // the socket closed once this one is subscribed, it's set on a service upgrade notice
+	closeOnReply atomic.Bool // the socket is closed once the reply to its op arrived
+	loginPending atomic.Bool // a login was sent and isn't answered yet
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+// connError is a send error of a socket
+type connError struct {
+	cn  *conn
+	err error
+}
+
+func newConn(private bool, errChan chan<- *connError, noticeChan chan<- *conn) *conn {
+	return &conn{
+		senderChan: make(chan []byte, 3),
+		loginChan:  make(chan error, 1),
+		errChan:    errChan,
+		noticeChan: noticeChan,
+		private:    private,
+		done:       make(chan struct{}),
+	}
+}
+
+// close retires the socket without reporting it as a send error
+func (cn *conn) close() {
+	cn.closeOnce.Do(func() {
+		close(cn.done)
+		if cn.ws != nil {
+			_ = cn.ws.Close()
+		}
+	})
+}
+
+func (cn *conn) closed() bool {
+	select {
+	case <-cn.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// send queues data on the socket, it reports false when the socket was closed first
+func (cn *conn) send(ctx context.Context, data []byte) bool {
+	select {
+	case cn.senderChan <- data:
+		return true
+	case <-cn.done:
+	case <-ctx.Done():
+	}
+	return false
+}
+
 // NewClient returns a pointer to a fresh ClientWs
 func NewClient(
 	ctx context.Context,
@@ -58,13 +160,14 @@ func NewClient(
 ) *ClientWs {
 	ctx, cancel := context.WithCancel(ctx)
 	c := &ClientWs{
-		logger:     logger,
-		apiKey:     apiKey,
-		secretKey:  []byte(secretKey),
-		passphrase: passphrase,
-		ctx:        ctx,
-		Cancel:     cancel,
-		url:        url,
+		logger:      logger,
+		apiKey:      apiKey,
+		secretKey:   []byte(secretKey),
+		passphrase:  passphrase,
+		ctx:         ctx,
+		Cancel:      cancel,
+		url:         url,
+		businessURL: okex.BusinessWsURL,
 	}
 	c.Private = NewPrivate(c)
 	c.Public = NewPublic(c)
@@ -73,29 +176,50 @@ func NewClient(
 }
 
 // Login
+// Verifies the credentials on a short-lived private connection. Every private connection opened by Send logs in by itself.
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-login
 func (c *ClientWs) Login() error {
-	if c.Authorized {
+	if c.Authorized() {
 		return nil
 	}
-	if c.AuthRequested != nil && time.Since(*c.AuthRequested).Seconds() < 30 {
-		return nil
+	cn := newConn(false, nil, nil)
+	if err := c.dial(c.url[true], cn); err != nil {
+		return err
 	}
-	now := time.Now()
-	c.AuthRequested = &now
-	method := http.MethodGet
-	path := "/users/self/verify"
-	ts, sign := c.sign(method, path)
-	args := []map[string]string{
-		{
-			"apiKey":     c.apiKey,
-			"passphrase": c.passphrase,
-			"timestamp":  ts,
-			"sign":       sign,
-		},
+	defer cn.close()
+	return c.authenticate(cn)
+}
+
+// SetBusinessURL sets the url of the business channels, it defaults to BusinessWsURL
+func (c *ClientWs) SetBusinessURL(url okex.BaseURL) {
+	c.mu.Lock()
+	c.businessURL = url
+	c.mu.Unlock()
+}
+
+// Authorized reports whether the latest login succeeded
+func (c *ClientWs) Authorized() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.authorized
+}
+
+// LastPrivateRead returns when a message or a pong was last read on any private subscription socket, it's zero before the first one.
+// The sockets are pinged every 300ms, a stale time means every private subscription is down or stuck.
+// The sockets of the trade ops and of Login don't count, they are closed once answered.
+func (c *ClientWs) LastPrivateRead() time.Time {
+	n := c.privateRead.Load()
+	if n == 0 {
+		return time.Time{}
 	}
-	return c.Send(true, okex.LoginOperation, args)
+	return time.Unix(0, n)
+}
+
+// PrivateAlive reports whether a private subscription socket was read from within maxAge
+func (c *ClientWs) PrivateAlive(maxAge time.Duration) bool {
+	t := c.LastPrivateRead()
+	return !t.IsZero() && time.Since(t) <= maxAge
 }
 
 // Subscribe
@@ -113,169 +237,296 @@ func (c *ClientWs) Unsubscribe(needLogin bool, args []map[string]string) error {
 	return c.Send(needLogin, okex.UnsubscribeOperation, args)
 }
 
-func (c *ClientWs) connect(
-	needLogin bool,
-	senderChan chan []byte,
-	sendErrChan chan error,
-) error {
-	err := c.dial(senderChan, needLogin, sendErrChan)
-	if err != nil {
+// SubscribeBusiness subscribes to channel(s) of the business url, like the algo orders, grid and copy trading ones
+//
+// https://www.okx.com/docs-v5/en/#overview-websocket-connect
+func (c *ClientWs) SubscribeBusiness(needLogin bool, args []map[string]string) error {
+	return c.sendTo(c.business(), needLogin, map[string]interface{}{
+		"op":   okex.SubscribeOperation,
+		"args": args,
+	})
+}
+
+// UnsubscribeBusiness unsubscribes from channel(s) of the business url
+func (c *ClientWs) UnsubscribeBusiness(needLogin bool, args []map[string]string) error {
+	return c.sendTo(c.business(), needLogin, map[string]interface{}{
+		"op":   okex.UnsubscribeOperation,
+		"args": args,
+	})
+}
+
+func (c *ClientWs) business() okex.BaseURL {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.businessURL
+}
+
+// connect dials cn, logs in if it's private and sends sendData on it
+func (c *ClientWs) connect(url okex.BaseURL, cn *conn, sendData []byte) error {
+	if err := c.dial(url, cn); err != nil {
 		return err
 	}
 
-	if needLogin {
-		err = c.WaitForAuthorization()
-		if err != nil {
+	if cn.private {
+		if err := c.authenticate(cn); err != nil {
+			cn.close()
 			return err
 		}
 	}
+	if !cn.send(c.ctx, sendData) {
+		return errors.New("connection closed before sending")
+	}
 
 	return nil
 }
 
+// authenticate logs in on the given socket and waits for the result, private channels are bound to the socket that logged in
+func (c *ClientWs) authenticate(cn *conn) error {
+	ts, sign := c.sign(http.MethodGet, "/users/self/verify")
+	loginData, err := json.Marshal(map[string]interface{}{
+		"op": okex.LoginOperation,
+		"args": []map[string]string{
+			{
+				"apiKey":     c.apiKey,
+				"passphrase": c.passphrase,
+				"timestamp":  ts,
+				"sign":       sign,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	cn.loginPending.Store(true)
+	defer cn.loginPending.Store(false)
+	if !cn.send(c.ctx, loginData) {
+		return errors.New("connection closed before login")
+	}
+
+	timer := time.NewTimer(loginWait)
+	defer timer.Stop()
+	select {
+	case err = <-cn.loginChan:
+	case <-timer.C:
+		err = errors.New("login timed out")
+	case <-c.ctx.Done():
+		return errors.New("operation cancelled: login")
+	}
+	c.mu.Lock()
+	c.authorized = err == nil
+	c.mu.Unlock()
+	return err
+}
+
+// Resubscribe replaces the connection of a previous subscription with a fresh one, snapshot channels push their snapshot again.
+// Every subscription holding all of args is resubscribed.
+func (c *ClientWs) Resubscribe(needLogin bool, args []map[string]string) error {
+	keys := argKeys(args)
+	found := false
+	c.mu.RLock()
+	for _, s := range c.subscriptions {
+		if s.needLogin != needLogin || !s.has(keys) {
+			continue
+		}
+		found = true
+		select {
+		case s.resubChan <- struct{}{}:
+		default:
+		}
+	}
+	c.mu.RUnlock()
+	if !found {
+		return errors.Errorf("<%s> Subscription not found.", opData(okex.SubscribeOperation, args))
+	}
+	return nil
+}
+
 // Send message through either connections
 func (c *ClientWs) Send(needLogin bool, op okex.Operation, args []map[string]string) error {
-	sendData, err := json.Marshal(map[string]interface{}{
+	return c.send(needLogin, map[string]interface{}{
 		"op":   op,
 		"args": args,
 	})
+}
+
+// send marshals msg as is, it's used by the operations having an id, an expTime or nested args
+func (c *ClientWs) send(needLogin bool, msg map[string]interface{}) error {
+	return c.sendTo(c.url[needLogin], needLogin, msg)
+}
+
+// sendTo is send on the socket of url. A subscription gets a socket of its own, an unsubscribe is sent on the sockets of its
+// subscriptions and every other op is sent once on a socket that is closed as soon as its reply arrived.
+func (c *ClientWs) sendTo(url okex.BaseURL, needLogin bool, msg map[string]interface{}) error {
+	switch msg["op"] {
+	case okex.SubscribeOperation, okex.UnsubscribeOperation:
+		args, ok := msg["args"].([]map[string]string)
+		if !ok {
+			return errors.Errorf("%s args must be a []map[string]string", msg["op"])
+		}
+		if msg["op"] == okex.SubscribeOperation {
+			return c.subscribe(url, needLogin, args)
+		}
+		return c.unsubscribe(url, needLogin, args)
+	}
+	sendData, err := json.Marshal(msg)
 	if err != nil {
 		return err
 	}
-	senderChan := make(chan []byte, 3)
-	sendErrChan := make(chan error, 2)
-
-	err = c.connect(needLogin, senderChan, sendErrChan)
-	if err != nil {
+	// trade ops are one-shot, replaying them on a new socket would place or cancel orders twice
+	cn := newConn(needLogin, nil, nil)
+	cn.closeOnReply.Store(true)
+	if err := c.connect(url, cn, sendData); err != nil {
 		return err
 	}
-	senderChan <- sendData
+	c.closeAfterReply(cn)
+	return nil
+}
 
-	// 监控重连
+// closeAfterReply closes cn once the reply to its op arrived, or after replyWait when it didn't
+func (c *ClientWs) closeAfterReply(cn *conn) {
+	cn.closeOnReply.Store(true)
 	go func() {
-		for {
-			select {
-			case err := <-sendErrChan:
-				c.logger.ErrorF("<%s> Send error <%+v>, reconnect...\n", string(sendData), err)
-				err = c.connect(needLogin, senderChan, sendErrChan)
-				if err != nil {
-					time.Sleep(3 * time.Second)
-					sendErrChan <- errors.Errorf("<%s> Connect failed. %+v\n", string(sendData), err)
-					continue
-				}
-				c.logger.InfoF("<%s> Connect success.", string(sendData))
-				senderChan <- sendData
-			case <-c.ctx.Done():
-				return
-			}
+		timer := time.NewTimer(replyWait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			c.logger.ErrorF("No reply within %s, closing the connection.\n", replyWait)
+		case <-cn.done:
+		case <-c.ctx.Done():
 		}
+		cn.close()
 	}()
+}
 
-	return nil
+// replied closes the socket if it was waiting for a reply, it's called by process once one arrived
+func (c *ClientWs) replied(cn *conn) {
+	if cn.closeOnReply.Load() && !cn.loginPending.Load() {
+		cn.close()
+	}
 }
 
-// WaitForAuthorization waits for the auth response and try to log in if it was needed
-func (c *ClientWs) WaitForAuthorization() error {
-	if c.Authorized {
+// WaitForAuthorization logs in if it was needed and waits for the result, the login error is returned as is
+func (c *ClientWs) WaitForAuthorization(timeout time.Duration) error {
+	if c.Authorized() {
 		return nil
 	}
-	if err := c.Login(); err != nil {
+	done := make(chan error, 1)
+	go func() {
+		done <- c.Login()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
 		return err
+	case <-timer.C:
+		return errors.New("authorization timed out")
+	case <-c.ctx.Done():
+		return errors.New("operation cancelled: authorization")
 	}
-	ticker := time.NewTicker(time.Millisecond * 300)
-	defer ticker.Stop()
-	for range ticker.C {
-		if c.Authorized {
-			return nil
-		}
-	}
-	return nil
 }
 
-func (c *ClientWs) dial(
-	senderChan chan []byte,
-	needLogin bool,
-	sendErrChan chan<- error,
-) error {
-	conn, res, err := websocket.DefaultDialer.Dial(string(c.url[needLogin]), nil)
+func (c *ClientWs) dial(url okex.BaseURL, cn *conn) error {
+	ws, res, err := websocket.DefaultDialer.Dial(string(url), nil)
 	if err != nil {
 		var statusCode int
 		if res != nil {
 			statusCode = res.StatusCode
 		}
-		return errors.New(fmt.Sprintf("error %d: %w", statusCode, err))
+		return errors.Wrapf(err, "error %d", statusCode)
 	}
 	defer res.Body.Close()
+	cn.ws = ws
 	go func() {
-		err := c.receiver(conn)
-		if err != nil {
+		err := c.receiver(cn)
+		if err != nil && !cn.closed() {
 			c.logger.ErrorF("Receiver error: %v\n", err)
 		}
 	}()
 	go func() {
-		err := c.sender(conn, senderChan)
-		if err != nil {
-			sendErrChan <- err
+		err := c.sender(cn)
+		if err != nil && !cn.closed() {
 			c.logger.ErrorF("Sender error: %v\n", err)
+			select {
+			case cn.errChan <- &connError{cn: cn, err: err}:
+			default:
+				// nobody replays this socket
+				cn.close()
+			}
 		}
 	}()
 	return nil
 }
-func (c *ClientWs) sender(
-	conn *websocket.Conn,
-	senderChan chan []byte,
-) error {
+func (c *ClientWs) sender(cn *conn) error {
 	ticker := time.NewTicker(time.Millisecond * 300)
 	defer ticker.Stop()
 	for {
 		select {
-		case data := <-senderChan:
-			err := conn.SetWriteDeadline(time.Now().Add(writeWait))
-			if err != nil {
+		case data := <-cn.senderChan:
+			if err := c.write(cn, data); err != nil {
 				return err
 			}
-			w, err := conn.NextWriter(websocket.TextMessage)
-			if err != nil {
-				return err
-			}
-			if _, err = w.Write(data); err != nil {
-				return err
-			}
-			if err := w.Close(); err != nil {
+		case <-ticker.C:
+			// written in place, queueing it would block once senderChan is full since this is its only reader
+			if err := c.write(cn, []byte("ping")); err != nil {
 				return err
 			}
-		case <-ticker.C:
-			senderChan <- []byte("ping")
+		case <-cn.done:
+			return nil
 		case <-c.ctx.Done():
 			return errors.New("operation cancelled: sender")
 		}
 	}
 }
-func (c *ClientWs) receiver(conn *websocket.Conn) error {
+func (c *ClientWs) write(cn *conn, data []byte) error {
+	err := cn.ws.SetWriteDeadline(time.Now().Add(writeWait))
+	if err != nil {
+		return err
+	}
+	w, err := cn.ws.NextWriter(websocket.TextMessage)
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return err
+	}
+	return w.Close()
+}
+func (c *ClientWs) receiver(cn *conn) error {
 	for {
 		select {
 		case <-c.ctx.Done():
 			return errors.New("operation cancelled: receiver")
+		case <-cn.done:
+			return nil
 		default:
-			err := conn.SetReadDeadline(time.Now().Add(pongWait))
+			err := cn.ws.SetReadDeadline(time.Now().Add(pongWait))
 			if err != nil {
 				return err
 			}
-			mt, data, err := conn.ReadMessage()
+			mt, data, err := cn.ws.ReadMessage()
 			if err != nil {
 				if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
-					return conn.Close()
+					return cn.ws.Close()
 				}
 				return err
 			}
+			if cn.closed() {
+				// a retired socket, its replacement streams the same pushes
+				return nil
+			}
+			if cn.private && cn.subscribed {
+				c.privateRead.Store(time.Now().UnixNano())
+			}
 			if mt == websocket.TextMessage && string(data) != "pong" {
+				if c.Public.processFast(data) {
+					continue
+				}
 				e := &events.Basic{}
 				if err := json.Unmarshal(data, &e); err != nil {
 					return err
 				}
-				go func() {
-					c.process(data, e)
-				}()
+				c.process(cn, data, e)
 			}
 		}
 	}
@@ -291,47 +542,77 @@ func (c *ClientWs) sign(method, path string) (string, string) {
 }
 
 // TODO: break each case into a separate function
-func (c *ClientWs) process(data []byte, e *events.Basic) bool {
+//
+// process runs on the receiver goroutine so handlers and channels see events in order, both are fed from queues of their own.
+func (c *ClientWs) process(cn *conn, data []byte, e *events.Basic) bool {
 	switch e.Event {
 	case "error":
 		e := events.Error{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			c.ErrChan <- &e
-		}()
+		// a failed login is reported as an error event
+		if cn.loginPending.Load() && (e.Op == string(okex.LoginOperation) || loginErrors[int64(e.Code)]) {
+			select {
+			case cn.loginChan <- errors.Errorf("login failed. code: %d, msg: %s", e.Code, e.Msg):
+			default:
+			}
+		}
+		c.dispatch("error", &e)
+		c.mu.RLock()
+		out := c.ErrChan
+		c.mu.RUnlock()
+		deliver(c, "ErrChan", out, &e)
+		c.replied(cn)
 		return true
 	case "subscribe":
 		e := events.Subscribe{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.SubscribeChan != nil {
-				c.SubscribeChan <- &e
-			}
-		}()
+		if cn.replaces != nil {
+			// the replacement after a service upgrade notice is subscribed, the old socket would stream duplicates
+			cn.replaces.close()
+		}
+		c.dispatch("subscribe", &e)
+		c.mu.RLock()
+		out := c.SubscribeChan
+		c.mu.RUnlock()
+		deliver(c, "SubscribeChan", out, &e)
 		return true
 	case "unsubscribe":
 		e := events.Unsubscribe{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.UnsubscribeCh != nil {
-				c.UnsubscribeCh <- &e
-			}
-		}()
+		c.dispatch("unsubscribe", &e)
+		c.mu.RLock()
+		out := c.UnsubscribeCh
+		c.mu.RUnlock()
+		deliver(c, "UnsubscribeCh", out, &e)
+		c.replied(cn)
 		return true
 	case "login":
-		if time.Since(*c.AuthRequested).Seconds() > 30 {
-			c.AuthRequested = nil
-			_ = c.Login()
-			break
-		}
-		c.Authorized = true
 		e := events.Login{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.LoginChan != nil {
-				c.LoginChan <- &e
+		select {
+		case cn.loginChan <- nil:
+		default:
+		}
+		c.dispatch("login", &e)
+		c.mu.RLock()
+		out := c.LoginChan
+		c.mu.RUnlock()
+		deliver(c, "LoginChan", out, &e)
+		return true
+	case "notice":
+		e := events.Notice{}
+		_ = json.Unmarshal(data, &e)
+		if e.Code == serviceUpgradeNotice && cn.noticeChan != nil {
+			select {
+			case cn.noticeChan <- cn:
+			default:
 			}
-		}()
+		}
+		c.dispatch("notice", &e)
+		c.mu.RLock()
+		out := c.NoticeChan
+		c.mu.RUnlock()
+		deliver(c, "NoticeChan", out, &e)
 		return true
 	}
 	if c.Private.Process(data, e) {
@@ -344,15 +625,16 @@ func (c *ClientWs) process(data []byte, e *events.Basic) bool {
 		if e.Code != 0 {
 			ee := *e
 			ee.Event = "error"
-			return c.process(data, &ee)
+			return c.process(cn, data, &ee)
 		}
 		e := events.Success{}
 		_ = json.Unmarshal(data, &e)
-		go func() {
-			if c.SuccessChan != nil {
-				c.SuccessChan <- &e
-			}
-		}()
+		c.dispatch("success", &e)
+		c.mu.RLock()
+		out := c.SuccessChan
+		c.mu.RUnlock()
+		deliver(c, "SuccessChan", out, &e)
+		c.replied(cn)
 		return true
 	}
 	return false
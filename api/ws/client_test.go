@@ -0,0 +1,417 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/public"
+	requests "github.com/pefish/go-okx/requests/ws/public"
+	"github.com/pefish/go-okx/requests/ws/trade"
+)
+
+// fakeServer answers the pings, the logins, the subscriptions and the trade ops, and broadcasts a ticker every
+// millisecond to the subscribed sockets. The lastSz of the ticker counts the broadcasts, so a push read on two sockets
+// shows up as a duplicate.
+type fakeServer struct {
+	*httptest.Server
+	notice     bool     // a 64008 notice follows the first subscribe reply
+	login      []string // the replies to a login
+	open       atomic.Int64
+	subscribes atomic.Int64
+	mu         sync.Mutex // guards subscribed and resume
+	subscribed map[*fakeSocket]bool
+	resume     time.Time // the broadcast pauses after a subscribe reply, so the client can retire a replaced socket
+}
+
+type fakeSocket struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+}
+
+func (s *fakeSocket) write(data string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.ws.SetWriteDeadline(time.Now().Add(time.Second))
+	return s.ws.WriteMessage(websocket.TextMessage, []byte(data))
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{subscribed: make(map[*fakeSocket]bool)}
+	upgrader := websocket.Upgrader{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		s.serve(&fakeSocket{ws: ws})
+	}))
+	done := make(chan struct{})
+	go s.broadcast(done)
+	t.Cleanup(func() {
+		close(done)
+		s.Close()
+	})
+	return s
+}
+
+func (s *fakeServer) url() okex.BaseURL {
+	return okex.BaseURL("ws" + strings.TrimPrefix(s.URL, "http"))
+}
+
+func (s *fakeServer) client(ctx context.Context) *ClientWs {
+	c := NewClient(ctx, &i_logger.DefaultLogger, "", "", "", map[bool]okex.BaseURL{false: s.url(), true: s.url()})
+	c.SetBusinessURL(s.url())
+	return c
+}
+
+func (s *fakeServer) broadcast(done chan struct{}) {
+	var seq int64
+	for {
+		select {
+		case <-done:
+			return
+		case <-time.After(time.Millisecond):
+		}
+		s.mu.Lock()
+		if time.Now().After(s.resume) {
+			seq++
+			push := `{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instType":"SPOT","instId":"BTC-USDT","last":"9999.99","lastSz":"` +
+				strconv.FormatInt(seq, 10) + `","ts":"1597026383085"}]}`
+			for fs := range s.subscribed {
+				_ = fs.write(push)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *fakeServer) serve(fs *fakeSocket) {
+	s.open.Add(1)
+	defer s.open.Add(-1)
+	defer func() {
+		s.mu.Lock()
+		delete(s.subscribed, fs)
+		s.mu.Unlock()
+		_ = fs.ws.Close()
+	}()
+	for {
+		_, data, err := fs.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		if string(data) == "ping" {
+			if fs.write("pong") != nil {
+				return
+			}
+			continue
+		}
+		msg := struct {
+			ID   string            `json:"id"`
+			Op   string            `json:"op"`
+			Args []json.RawMessage `json:"args"`
+		}{}
+		if json.Unmarshal(data, &msg) != nil {
+			continue
+		}
+		switch msg.Op {
+		case "login":
+			for _, reply := range s.login {
+				if fs.write(reply) != nil {
+					return
+				}
+			}
+		case "subscribe", "unsubscribe":
+			s.mu.Lock()
+			for _, arg := range msg.Args {
+				_ = fs.write(`{"event":"` + msg.Op + `","arg":` + string(arg) + `}`)
+			}
+			if msg.Op == "subscribe" {
+				s.subscribed[fs] = true
+				s.resume = time.Now().Add(20 * time.Millisecond)
+			} else {
+				delete(s.subscribed, fs)
+			}
+			s.mu.Unlock()
+			if msg.Op == "subscribe" && s.subscribes.Add(1) == 1 && s.notice {
+				if fs.write(`{"event":"notice","code":"64008","msg":"The connection will soon be closed for a service upgrade.","connId":"a4d3ae55"}`) != nil {
+					return
+				}
+			}
+		default:
+			if msg.ID != "" && fs.write(`{"id":"`+msg.ID+`","op":"`+msg.Op+`","code":"0","msg":"","data":[{"ordId":"1","clOrdId":"","sCode":"0","sMsg":""}]}`) != nil {
+				return
+			}
+		}
+	}
+}
+
+// waitFor fails the test when cond isn't met within 3 seconds
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestClientRace subscribes, receives and swaps the channels and handlers at the same time, it's meant for go test -race
+func TestClientRace(t *testing.T) {
+	srv := newFakeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := srv.client(ctx)
+
+	var received atomic.Int64
+	req := []requests.Tickers{{InstID: "BTC-USDT"}}
+	args := okex.StructSlice2MapSlice(req)
+	args[0]["channel"] = "tickers"
+	if err := c.Public.Tickers(req); err != nil {
+		t.Fatal(err)
+	}
+	// subscribed and unsubscribed over and over, the BTC-USDT subscription stays
+	churn := []requests.Tickers{{InstID: "ETH-USDT"}}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	loop := func(fn func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; ; i++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				fn(i)
+				time.Sleep(2 * time.Millisecond)
+			}
+		}()
+	}
+	loop(func(i int) {
+		if i%2 == 0 {
+			c.Public.OnTicker(func(*public.Tickers) { received.Add(1) })
+		} else {
+			c.Public.OnTicker(nil)
+		}
+	})
+	loop(func(i int) {
+		remove := c.Public.ListenOrderBook(func(*public.OrderBook) {})
+		c.OnSubscribe(func(*events.Subscribe) {})
+		remove()
+	})
+	loop(func(i int) {
+		if i%10 != 0 {
+			return
+		}
+		ch := make(chan *public.Tickers)
+		go func() {
+			for {
+				select {
+				case <-ch:
+					received.Add(1)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		if err := c.Public.Tickers(churn, ch); err != nil {
+			t.Error(err)
+		}
+		if err := c.Public.UTickers(churn, i%20 == 0); err != nil {
+			t.Error(err)
+		}
+	})
+	loop(func(i int) {
+		_ = c.Authorized()
+		_ = c.LastPrivateRead()
+		if i%25 == 0 {
+			if err := c.Public.Resubscribe(false, args); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	time.Sleep(300 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+	deadline := time.Now().Add(2 * time.Second)
+	for received.Load() == 0 && time.Now().Before(deadline) {
+		c.Public.OnTicker(func(*public.Tickers) { received.Add(1) })
+		time.Sleep(10 * time.Millisecond)
+	}
+	if received.Load() == 0 {
+		t.Fatal("no ticker received")
+	}
+}
+
+// TestClientServiceUpgrade switches the socket after a 64008 notice, the old socket must be closed once the new one is
+// subscribed and no push may be seen twice
+func TestClientServiceUpgrade(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.notice = true
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := srv.client(ctx)
+
+	var mu sync.Mutex
+	var seqs []float64
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(seqs)
+	}
+	c.Public.OnTicker(func(e *public.Tickers) {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, ticker := range e.Tickers {
+			seqs = append(seqs, float64(ticker.LastSz))
+		}
+	})
+	if err := c.Public.Tickers([]requests.Tickers{{InstID: "BTC-USDT"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, "the switch", func() bool { return srv.subscribes.Load() == 2 && srv.open.Load() == 1 })
+	switched := count()
+	waitFor(t, "pushes on the new socket", func() bool { return count() > switched+20 })
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			t.Fatalf("push %v after %v, the old socket is still streaming", seqs[i], seqs[i-1])
+		}
+	}
+}
+
+// TestClientUnsubscribe unsubscribes on the socket of the subscription, which is closed once nothing is left on it
+func TestClientUnsubscribe(t *testing.T) {
+	srv := newFakeServer(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := srv.client(ctx)
+
+	btc := []requests.Tickers{{InstID: "BTC-USDT"}}
+	eth := []requests.Tickers{{InstID: "ETH-USDT"}}
+	if err := c.Public.Tickers(append(btc, eth...)); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the subscription", func() bool { return srv.open.Load() == 1 })
+
+	if err := c.Public.UTickers(btc); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := srv.open.Load(); n != 1 {
+		t.Fatalf("%d sockets open with ETH-USDT still subscribed, want 1", n)
+	}
+	ethArgs := okex.StructSlice2MapSlice(eth)
+	ethArgs[0]["channel"] = "tickers"
+	if err := c.Public.Resubscribe(false, ethArgs); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the resubscription", func() bool { return srv.subscribes.Load() == 2 && srv.open.Load() == 1 })
+
+	if err := c.Public.UTickers(eth); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the socket to close", func() bool { return srv.open.Load() == 0 })
+	c.mu.RLock()
+	n := len(c.subscriptions)
+	c.mu.RUnlock()
+	if n != 0 {
+		t.Fatalf("%d subscriptions left", n)
+	}
+	if err := c.Public.Resubscribe(false, ethArgs); err == nil {
+		t.Fatal("resubscribed an unsubscribed channel")
+	}
+	if err := c.Public.UTickers(eth); err == nil {
+		t.Fatal("unsubscribed twice")
+	}
+}
+
+// TestClientOneShot closes the socket of a trade op once its reply arrived
+func TestClientOneShot(t *testing.T) {
+	srv := newFakeServer(t)
+	srv.login = []string{`{"event":"login","code":"0","msg":""}`}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := srv.client(ctx)
+
+	var success atomic.Bool
+	c.OnSuccess(func(*events.Success) { success.Store(true) })
+	err := c.Trade.PlaceOrder(trade.PlaceOrder{
+		ID:      "1",
+		InstID:  "BTC-USDT",
+		TdMode:  okex.TradeCashMode,
+		Side:    okex.OrderBuy,
+		OrdType: okex.OrderLimit,
+		Sz:      "1",
+		Px:      "1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the reply", success.Load)
+	waitFor(t, "the socket to close", func() bool { return srv.open.Load() == 0 })
+}
+
+func TestClientLogin(t *testing.T) {
+	defer func(wait time.Duration) { loginWait = wait }(loginWait)
+	loginWait = 100 * time.Millisecond
+
+	tests := []struct {
+		name  string
+		login []string
+		err   string
+	}{
+		{"success", []string{`{"event":"login","code":"0","msg":""}`}, ""},
+		{"timeout", nil, "login timed out"},
+		{"error", []string{`{"event":"error","code":"60009","msg":"Login failed.","connId":"a4d3ae55"}`}, "code: 60009"},
+		{
+			"unrelated error first",
+			[]string{`{"event":"error","code":"60012","msg":"Invalid request","connId":"a4d3ae55"}`, `{"event":"login","code":"0","msg":""}`},
+			"",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newFakeServer(t)
+			srv.login = tt.login
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			c := srv.client(ctx)
+
+			err := c.Login()
+			if tt.err == "" {
+				if err != nil || !c.Authorized() {
+					t.Fatalf("Login() = %v, authorized %v", err, c.Authorized())
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Login() = %v, want %q", err, tt.err)
+			}
+			if c.Authorized() {
+				t.Fatal("authorized after a failed login")
+			}
+		})
+	}
+}
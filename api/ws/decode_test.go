@@ -0,0 +1,237 @@
+package ws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/public"
+	"github.com/pefish/go-okx/models/market"
+)
+
+var pushes = []struct {
+	name string
+	data []byte
+}{
+	{"books-l2-tbt", booksMessage(400)},
+	{"bbo-tbt", []byte(`{"arg":{"channel":"bbo-tbt","instId":"BTC-USDT"},"data":[{"asks":[["42000.1","1","0","1"]],"bids":[["41999.9","2","0","3"]],"ts":"1597026383085","seqId":123456}]}`)},
+	{"trades", []byte(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"130639474","px":"42219.9","sz":"0.12060306","side":"buy","ts":"1630048897897"}]}`)},
+	{"tickers", []byte(`{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instType":"SPOT","instId":"BTC-USDT","last":"9999.99","lastSz":"0.1","askPx":"9999.99","askSz":"11","bidPx":"8888.88","bidSz":"5","open24h":"9000","high24h":"10000","low24h":"8888.88","volCcy24h":"2222","vol24h":"2222","sodUtc0":"2222","sodUtc8":"2222","ts":"1597026383085"}]}`)},
+	{"candle1m", []byte(`{"arg":{"channel":"candle1m","instId":"BTC-USDT"},"data":[["1597026383085","8533.02","8553.74","8527.17","8548.26","45247","529.5858061","5.30","0"]]}`)},
+}
+
+func booksMessage(depth int) []byte {
+	var asks, bids []string
+	for i := 0; i < depth; i++ {
+		asks = append(asks, fmt.Sprintf(`["%.1f","%d","0","%d"]`, 42000.1+float64(i)/10, i+1, i%7+1))
+		bids = append(bids, fmt.Sprintf(`["%.1f","%d","0","%d"]`, 41999.9-float64(i)/10, i+1, i%5+1))
+	}
+	return []byte(`{"arg":{"channel":"books-l2-tbt","instId":"BTC-USDT"},"action":"snapshot","data":[{"asks":[` +
+		strings.Join(asks, ",") + `],"bids":[` + strings.Join(bids, ",") +
+		`],"ts":"1597026383085","checksum":-855196043,"prevSeqId":-1,"seqId":123456}]}`)
+}
+
+func newTestClient(tb testing.TB) *ClientWs {
+	ctx, cancel := context.WithCancel(context.Background())
+	tb.Cleanup(cancel)
+	return NewClient(ctx, &i_logger.DefaultLogger, "", "", "", map[bool]okex.BaseURL{})
+}
+
+// processGeneric is the path of the receiver for the messages processFast doesn't take
+func processGeneric(c *ClientWs, data []byte) bool {
+	e := &events.Basic{}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	return c.Public.Process(data, e)
+}
+
+// The decoder of the pushes before processFast: events.Basic first, then the concrete type through reflection,
+// book levels and candles through []interface{}
+type (
+	legacyOrderBook struct {
+		Arg    *events.Argument `json:"arg"`
+		Books  []*legacyBook    `json:"data"`
+		Action string           `json:"action"`
+	}
+	legacyBook struct {
+		Asks     []*legacyEntity `json:"asks"`
+		Bids     []*legacyEntity `json:"bids"`
+		Checksum int             `json:"checksum"`
+	}
+	legacyEntity struct {
+		DepthPrice      float64
+		Size            float64
+		LiquidatedOrder int
+		OrderNumbers    int
+	}
+	legacyCandlesticks struct {
+		Arg     *events.Argument `json:"arg"`
+		Candles []*legacyCandle  `json:"data"`
+	}
+	legacyCandle struct {
+		O, H, L, C, Vol, VolCcy, VolCcyQuote float64
+		TS                                   int64
+		Confirm                              bool
+	}
+	// the models without their hand-scanning UnmarshalJSON
+	legacyTicker  market.Ticker
+	legacyTickers struct {
+		Arg     *events.Argument `json:"arg"`
+		Tickers []*legacyTicker  `json:"data"`
+	}
+	legacyTrade  market.Trade
+	legacyTrades struct {
+		Arg    *events.Argument `json:"arg"`
+		Trades []*legacyTrade   `json:"data"`
+	}
+)
+
+func (o *legacyEntity) UnmarshalJSON(buf []byte) error {
+	var (
+		dp, s, lo, on string
+		err           error
+	)
+	tmp := []interface{}{&dp, &s, &lo, &on}
+	if err := json.Unmarshal(buf, &tmp); err != nil {
+		return err
+	}
+	if o.DepthPrice, err = strconv.ParseFloat(dp, 64); err != nil {
+		return err
+	}
+	if o.Size, err = strconv.ParseFloat(s, 64); err != nil {
+		return err
+	}
+	if o.LiquidatedOrder, err = strconv.Atoi(lo); err != nil {
+		return err
+	}
+	o.OrderNumbers, err = strconv.Atoi(on)
+	return err
+}
+
+func (c *legacyCandle) UnmarshalJSON(buf []byte) error {
+	var o, h, l, cl, vol, volCcy, ts, confirm, volCcyQuote string
+	tmp := []interface{}{&ts, &o, &h, &l, &cl, &vol, &volCcy, &volCcyQuote, &confirm}
+	if err := json.Unmarshal(buf, &tmp); err != nil {
+		return err
+	}
+	var err error
+	if c.TS, err = strconv.ParseInt(ts, 10, 64); err != nil {
+		return err
+	}
+	values := []string{o, h, l, cl, vol, volCcy, volCcyQuote}
+	for i, dst := range []*float64{&c.O, &c.H, &c.L, &c.C, &c.Vol, &c.VolCcy, &c.VolCcyQuote} {
+		if *dst, err = strconv.ParseFloat(values[i], 64); err != nil {
+			return err
+		}
+	}
+	c.Confirm = confirm != "0"
+	return nil
+}
+
+func decodeLegacy(data []byte) error {
+	e := &events.Basic{}
+	if err := json.Unmarshal(data, &e); err != nil {
+		return err
+	}
+	ch, _ := e.Arg.Get("channel")
+	var v interface{}
+	switch ch := fmt.Sprint(ch); {
+	case ch == "tickers":
+		v = &legacyTickers{}
+	case ch == "trades":
+		v = &legacyTrades{}
+	case strings.HasPrefix(ch, "candle"):
+		v = &legacyCandlesticks{}
+	default:
+		v = &legacyOrderBook{}
+	}
+	return json.Unmarshal(data, v)
+}
+
+func TestProcessPushes(t *testing.T) {
+	c := newTestClient(t)
+	for _, p := range pushes {
+		if !c.Public.processFast(p.data) {
+			t.Errorf("%s: not handled by the fast path", p.name)
+		}
+		if !processGeneric(c, p.data) {
+			t.Errorf("%s: not handled by the generic path", p.name)
+		}
+	}
+}
+
+func TestDecodeMatchesLegacy(t *testing.T) {
+	tickers := []struct {
+		name string
+		data string
+	}{
+		{"tickers", string(pushes[3].data)},
+		{"escaped", `{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instType":"SPOT","instId":"BTC\u002dUSDT","last":"1.5","ts":"1597026383085"}]}`},
+		{"non-string", `{"arg":{"channel":"tickers","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","last":"1.5","extra":{"a":1},"lastSz":""}]}`},
+	}
+	for _, tt := range tickers {
+		var got public.Tickers
+		var want legacyTickers
+		if err := json.Unmarshal([]byte(tt.data), &got); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if err := json.Unmarshal([]byte(tt.data), &want); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if g, w := *got.Tickers[0], market.Ticker(*want.Tickers[0]); !reflect.DeepEqual(g, w) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, g, w)
+		}
+	}
+
+	var got public.Trades
+	var want legacyTrades
+	if err := json.Unmarshal(pushes[2].data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(pushes[2].data, &want); err != nil {
+		t.Fatal(err)
+	}
+	if g, w := *got.Trades[0], market.Trade(*want.Trades[0]); !reflect.DeepEqual(g, w) {
+		t.Errorf("trades: got %+v, want %+v", g, w)
+	}
+	if got.Trades[0].Px != 42219.9 {
+		t.Errorf("trades: got px %v, want 42219.9", got.Trades[0].Px)
+	}
+}
+
+func BenchmarkProcessFast(b *testing.B) {
+	c := newTestClient(b)
+	for _, p := range pushes {
+		b.Run(p.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if !c.Public.processFast(p.data) {
+					b.Fatal("not handled")
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkProcessGeneric is the baseline of BenchmarkProcessFast, it decodes the pushes the way the receiver did
+// before processFast
+func BenchmarkProcessGeneric(b *testing.B) {
+	for _, p := range pushes {
+		b.Run(p.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := decodeLegacy(p.data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package ws
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/pefish/go-okx/events"
+)
+
+// handlerQueueSize is how many events wait for a slow callback or channel reader, newer ones are dropped
+const handlerQueueSize = 4096
+
+// handler runs a registered callback on its own goroutine, so a slow callback never blocks the receiver.
+// Events reach the callback one at a time in the order they were received.
+type handler struct {
+	name     string
+	fn       func(interface{})
+	out      interface{} // the channel fed by deliver, nil for callbacks
+	mu       sync.Mutex
+	queue    []interface{}
+	dropping bool // the queue was full, it's reported once until the queue drains
+	signal   chan struct{}
+	done     chan struct{}
+}
+
+func newHandler(name string, fn func(interface{})) *handler {
+	return &handler{
+		name:   name,
+		fn:     fn,
+		signal: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+// push queues e, it reports false when the queue is full and e is dropped for the first time since it drained
+func (h *handler) push(e interface{}) bool {
+	h.mu.Lock()
+	if len(h.queue) >= handlerQueueSize {
+		first := !h.dropping
+		h.dropping = true
+		h.mu.Unlock()
+		return !first
+	}
+	h.queue = append(h.queue, e)
+	h.mu.Unlock()
+	select {
+	case h.signal <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+func (h *handler) pop() (interface{}, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.queue) == 0 {
+		h.dropping = false
+		return nil, false
+	}
+	e := h.queue[0]
+	h.queue[0] = nil
+	h.queue = h.queue[1:]
+	return e, true
+}
+
+// on registers fn for the named event replacing the previous one, a nil fn removes it
+func (c *ClientWs) on(name string, fn func(interface{})) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.handlers[name]; ok {
+		close(old.done)
+		delete(c.handlers, name)
+	}
+	if fn == nil {
+		return
+	}
+	if c.handlers == nil {
+		c.handlers = make(map[string]*handler)
+	}
+	h := newHandler(name, fn)
+	c.handlers[name] = h
+	go c.runHandler(h)
+}
+
+// listen adds fn for the named event alongside the handler of on, it's never replaced and the returned func removes it.
+// Components built on the client listen so the On* callbacks of the user keep working.
+func (c *ClientWs) listen(name string, fn func(interface{})) func() {
+	h := newHandler(name, fn)
+	c.mu.Lock()
+	if c.listeners == nil {
+		c.listeners = make(map[string][]*handler)
+	}
+	c.listeners[name] = append(c.listeners[name], h)
+	c.mu.Unlock()
+	go c.runHandler(h)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			// a new slice, dispatch may still range over the old one
+			list := make([]*handler, 0, len(c.listeners[name]))
+			for _, l := range c.listeners[name] {
+				if l != h {
+					list = append(list, l)
+				}
+			}
+			c.listeners[name] = list
+			c.mu.Unlock()
+			close(h.done)
+		})
+	}
+}
+
+func (c *ClientWs) dispatch(name string, e interface{}) {
+	c.mu.RLock()
+	h := c.handlers[name]
+	listeners := c.listeners[name]
+	c.mu.RUnlock()
+	if h != nil && !h.push(e) {
+		c.overflow(name)
+	}
+	for _, l := range listeners {
+		if !l.push(e) {
+			c.overflow(name)
+		}
+	}
+}
+
+// deliver sends e to out after the events sent to it before, from a queue of its own so the receiver never blocks.
+// The queue is replaced when the channel of key changes, events still queued for the old channel are dropped.
+func deliver[T any](c *ClientWs, key string, out chan T, e T) {
+	if out == nil {
+		return
+	}
+	c.mu.RLock()
+	h := c.outputs[key]
+	c.mu.RUnlock()
+	if h == nil || h.out != out {
+		c.mu.Lock()
+		h = c.outputs[key]
+		if h == nil || h.out != out {
+			if h != nil {
+				close(h.done)
+			}
+			h = newHandler(key, nil)
+			done := h.done
+			h.fn = func(e interface{}) {
+				select {
+				case out <- e.(T):
+				case <-done:
+				case <-c.ctx.Done():
+				}
+			}
+			h.out = out
+			if c.outputs == nil {
+				c.outputs = make(map[string]*handler)
+			}
+			c.outputs[key] = h
+			go c.runHandler(h)
+		}
+		c.mu.Unlock()
+	}
+	if !h.push(e) {
+		c.overflow(key)
+	}
+}
+
+// overflow reports that the queue of name is full as an error event, the queues of the error event only log it
+func (c *ClientWs) overflow(name string) {
+	msg := fmt.Sprintf("the queue of <%s> is full, events are dropped until it drains", name)
+	c.logger.ErrorF("%s\n", msg)
+	if name == "error" || name == "ErrChan" {
+		return
+	}
+	e := &events.Error{Event: "error", Msg: msg}
+	c.dispatch("error", e)
+	c.mu.RLock()
+	out := c.ErrChan
+	c.mu.RUnlock()
+	deliver(c, "ErrChan", out, e)
+}
+
+func (c *ClientWs) runHandler(h *handler) {
+	for {
+		select {
+		case <-h.signal:
+		case <-h.done:
+			return
+		case <-c.ctx.Done():
+			return
+		}
+		for {
+			e, ok := h.pop()
+			if !ok {
+				break
+			}
+			c.call(h, e)
+		}
+	}
+}
+
+func (c *ClientWs) call(h *handler, e interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.logger.ErrorF("Handler <%s> panic: %v\n%s\n", h.name, r, debug.Stack())
+		}
+	}()
+	h.fn(e)
+}
+
+// OnError registers the callback of error events, it can be used instead of ErrChan
+func (c *ClientWs) OnError(fn func(*events.Error)) {
+	if fn == nil {
+		c.on("error", nil)
+		return
+	}
+	c.on("error", func(e interface{}) { fn(e.(*events.Error)) })
+}
+
+// OnSubscribe registers the callback of subscribe events, it can be used instead of SubscribeChan
+func (c *ClientWs) OnSubscribe(fn func(*events.Subscribe)) {
+	if fn == nil {
+		c.on("subscribe", nil)
+		return
+	}
+	c.on("subscribe", func(e interface{}) { fn(e.(*events.Subscribe)) })
+}
+
+// OnUnsubscribe registers the callback of unsubscribe events, it can be used instead of UnsubscribeCh
+func (c *ClientWs) OnUnsubscribe(fn func(*events.Unsubscribe)) {
+	if fn == nil {
+		c.on("unsubscribe", nil)
+		return
+	}
+	c.on("unsubscribe", func(e interface{}) { fn(e.(*events.Unsubscribe)) })
+}
+
+// OnLogin registers the callback of login events, it can be used instead of LoginChan
+func (c *ClientWs) OnLogin(fn func(*events.Login)) {
+	if fn == nil {
+		c.on("login", nil)
+		return
+	}
+	c.on("login", func(e interface{}) { fn(e.(*events.Login)) })
+}
+
+// OnSuccess registers the callback of trade success events, it can be used instead of SuccessChan
+func (c *ClientWs) OnSuccess(fn func(*events.Success)) {
+	if fn == nil {
+		c.on("success", nil)
+		return
+	}
+	c.on("success", func(e interface{}) { fn(e.(*events.Success)) })
+}
+
+// OnNotice registers the callback of notice events, it can be used instead of NoticeChan
+func (c *ClientWs) OnNotice(fn func(*events.Notice)) {
+	if fn == nil {
+		c.on("notice", nil)
+		return
+	}
+	c.on("notice", func(e interface{}) { fn(e.(*events.Notice)) })
+}
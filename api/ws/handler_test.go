@@ -0,0 +1,54 @@
+package ws
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pefish/go-okx/events"
+)
+
+func TestDeliverOrder(t *testing.T) {
+	c := newTestClient(t)
+	out := make(chan int)
+	const n = 1000
+	for i := 0; i < n; i++ {
+		deliver(c, "test", out, i)
+	}
+	for i := 0; i < n; i++ {
+		select {
+		case got := <-out:
+			if got != i {
+				t.Fatalf("got %d, want %d", got, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("event %d not delivered", i)
+		}
+	}
+}
+
+func TestHandlerOverflow(t *testing.T) {
+	c := newTestClient(t)
+	block := make(chan struct{})
+	defer close(block)
+	c.on("test", func(interface{}) { <-block })
+	errs := make(chan *events.Error, 10)
+	c.OnError(func(e *events.Error) { errs <- e })
+
+	for i := 0; i < handlerQueueSize+10; i++ {
+		c.dispatch("test", i)
+	}
+	select {
+	case e := <-errs:
+		if !strings.Contains(e.Msg, "<test>") {
+			t.Fatalf("error %q doesn't name the queue", e.Msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("overflow not reported")
+	}
+	select {
+	case e := <-errs:
+		t.Fatalf("overflow reported twice: %q", e.Msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
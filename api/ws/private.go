@@ -17,6 +17,16 @@ type Private struct {
 	PositionCh           chan *private.Position
 	BalanceAndPositionCh chan *private.BalanceAndPosition
 	OrderCh              chan *private.Order
+	AlgoOrderCh          chan *private.AlgoOrder
+	AdvanceAlgoOrderCh   chan *private.AdvanceAlgoOrder
+	FillCh               chan *private.Fill
+	GridOrderSpotCh      chan *private.GridOrder
+	GridOrderContractCh  chan *private.GridOrder
+	GridPositionCh       chan *private.GridPosition
+	LeadNotificationCh   chan *private.LeadNotification
+	RfqCh                chan *private.Rfq
+	QuoteCh              chan *private.Quote
+	StrucBlockTradeCh    chan *private.StrucBlockTrade
 }
 
 // NewPrivate returns a pointer to a fresh Private
@@ -34,7 +44,9 @@ func (c *Private) Account(req []requests.Account, ch ...chan *private.Account) e
 		m[i]["channel"] = "account"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.AccountCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(true, m)
 }
@@ -48,7 +60,9 @@ func (c *Private) UAccount(req []requests.Account, rCh ...bool) error {
 		m[i]["channel"] = "account"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.AccountCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(true, m)
 }
@@ -63,7 +77,9 @@ func (c *Private) Position(req []requests.Position, ch ...chan *private.Position
 		m[i]["channel"] = "positions"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.PositionCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(true, m)
 }
@@ -77,7 +93,9 @@ func (c *Private) UPosition(req []requests.Position, rCh ...bool) error {
 		m[i]["channel"] = "positions"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.PositionCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(true, m)
 }
@@ -93,7 +111,9 @@ func (c *Private) BalanceAndPosition(ch ...chan *private.BalanceAndPosition) err
 		},
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.BalanceAndPositionCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(true, m)
 }
@@ -108,7 +128,9 @@ func (c *Private) UBalanceAndPosition(rCh ...bool) error {
 		},
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.BalanceAndPositionCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(true, m)
 }
@@ -123,7 +145,9 @@ func (c *Private) Order(req []requests.Order, ch ...chan *private.Order) error {
 		m[i]["channel"] = "orders"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.OrderCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(true, m)
 }
@@ -137,11 +161,478 @@ func (c *Private) UOrder(req []requests.Order, rCh ...bool) error {
 		m[i]["channel"] = "orders"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.OrderCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(true, m)
 }
 
+// Fill
+// Retrieve the fills of the orders as they happen, only available to VIP5 and above. The fills of an order in the same push are aggregated, Count tells how many.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-ws-fills-channel
+func (c *Private) Fill(req []requests.Fill, ch ...chan *private.Fill) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "fills"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.FillCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.Subscribe(true, m)
+}
+
+// UFill
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-ws-fills-channel
+func (c *Private) UFill(req []requests.Fill, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "fills"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.FillCh = nil
+		c.mu.Unlock()
+	}
+	return c.Unsubscribe(true, m)
+}
+
+// AlgoOrder
+// Retrieve algo orders (includes trigger order, oco order, conditional order, trailing stop order and chase order). Data will not be pushed when first subscribed. Data will only be pushed when triggered by events such as placing/canceling order.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-ws-algo-orders-channel
+func (c *Private) AlgoOrder(req []requests.AlgoOrder, ch ...chan *private.AlgoOrder) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "orders-algo"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.AlgoOrderCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UAlgoOrder
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-ws-algo-orders-channel
+func (c *Private) UAlgoOrder(req []requests.AlgoOrder, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "orders-algo"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.AlgoOrderCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// AdvanceAlgoOrder
+// Retrieve advance algo orders (includes iceberg order and twap order). Data will be pushed when first subscribed and when triggered by events such as placing/canceling order.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-ws-advance-algo-orders-channel
+func (c *Private) AdvanceAlgoOrder(req []requests.AdvanceAlgoOrder, ch ...chan *private.AdvanceAlgoOrder) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "algo-advance"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.AdvanceAlgoOrderCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UAdvanceAlgoOrder
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-algo-trading-ws-advance-algo-orders-channel
+func (c *Private) UAdvanceAlgoOrder(req []requests.AdvanceAlgoOrder, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "algo-advance"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.AdvanceAlgoOrderCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// GridOrderSpot
+// Retrieve the spot grids. Data will be pushed when first subscribed and when triggered by events such as placing, stopping or filling, and every 10 seconds while running.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-spot-grid-algo-orders-channel
+func (c *Private) GridOrderSpot(req []requests.GridOrder, ch ...chan *private.GridOrder) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-orders-spot"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.GridOrderSpotCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UGridOrderSpot
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-spot-grid-algo-orders-channel
+func (c *Private) UGridOrderSpot(req []requests.GridOrder, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-orders-spot"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.GridOrderSpotCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// GridOrderContract
+// Retrieve the contract grids. Data will be pushed when first subscribed and when triggered by events such as placing, stopping or filling, and every 10 seconds while running.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-contract-grid-algo-orders-channel
+func (c *Private) GridOrderContract(req []requests.GridOrder, ch ...chan *private.GridOrder) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-orders-contract"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.GridOrderContractCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UGridOrderContract
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-contract-grid-algo-orders-channel
+func (c *Private) UGridOrderContract(req []requests.GridOrder, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-orders-contract"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.GridOrderContractCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// GridPosition
+// Retrieve the position of a contract grid. Data will be pushed when first subscribed and when the position changes, and every 10 seconds while it is open.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-grid-positions-channel
+func (c *Private) GridPosition(req []requests.GridPosition, ch ...chan *private.GridPosition) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-positions"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.GridPositionCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UGridPosition
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-grid-trading-ws-grid-positions-channel
+func (c *Private) UGridPosition(req []requests.GridPosition, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "grid-positions"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.GridPositionCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// LeadNotification
+// Retrieve the notifications of the orders that failed to be lead traded, pushed only when one fails.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-ws-lead-trading-notification-channel
+func (c *Private) LeadNotification(req []requests.LeadNotification, ch ...chan *private.LeadNotification) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "copytrading-lead-notification"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.LeadNotificationCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// ULeadNotification
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-copy-trading-ws-lead-trading-notification-channel
+func (c *Private) ULeadNotification(req []requests.LeadNotification, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "copytrading-lead-notification"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.LeadNotificationCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// Rfq
+// Retrieve the RFQs the account created or received, pushed when one is created or changes state.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-rfqs-channel
+func (c *Private) Rfq(req []requests.Rfq, ch ...chan *private.Rfq) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "rfqs"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.RfqCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// URfq
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-rfqs-channel
+func (c *Private) URfq(req []requests.Rfq, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "rfqs"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.RfqCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// Quote
+// Retrieve the quotes the account created or received, pushed when one is created or changes state.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-quotes-channel
+func (c *Private) Quote(req []requests.Quote, ch ...chan *private.Quote) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "quotes"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.QuoteCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UQuote
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-quotes-channel
+func (c *Private) UQuote(req []requests.Quote, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "quotes"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.QuoteCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// StrucBlockTrade
+// Retrieve the block trades of the account, pushed when one is executed.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-structure-block-trades-channel
+func (c *Private) StrucBlockTrade(req []requests.StrucBlockTrade, ch ...chan *private.StrucBlockTrade) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "struc-block-trades"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.StrucBlockTradeCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(true, m)
+}
+
+// UStrucBlockTrade
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-private-channel-structure-block-trades-channel
+func (c *Private) UStrucBlockTrade(req []requests.StrucBlockTrade, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "struc-block-trades"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.StrucBlockTradeCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(true, m)
+}
+
+// OnAccount registers the callback of account events, it can be used instead of AccountCh
+func (c *Private) OnAccount(fn func(*private.Account)) {
+	if fn == nil {
+		c.on("account", nil)
+		return
+	}
+	c.on("account", func(e interface{}) { fn(e.(*private.Account)) })
+}
+
+// OnPosition registers the callback of positions events, it can be used instead of PositionCh
+func (c *Private) OnPosition(fn func(*private.Position)) {
+	if fn == nil {
+		c.on("positions", nil)
+		return
+	}
+	c.on("positions", func(e interface{}) { fn(e.(*private.Position)) })
+}
+
+// OnBalanceAndPosition registers the callback of balance_and_position events, it can be used instead of BalanceAndPositionCh
+func (c *Private) OnBalanceAndPosition(fn func(*private.BalanceAndPosition)) {
+	if fn == nil {
+		c.on("balance_and_position", nil)
+		return
+	}
+	c.on("balance_and_position", func(e interface{}) { fn(e.(*private.BalanceAndPosition)) })
+}
+
+// OnOrder registers the callback of orders events, it can be used instead of OrderCh
+func (c *Private) OnOrder(fn func(*private.Order)) {
+	if fn == nil {
+		c.on("orders", nil)
+		return
+	}
+	c.on("orders", func(e interface{}) { fn(e.(*private.Order)) })
+}
+
+// OnFill registers the callback of fills events, it can be used instead of FillCh
+func (c *Private) OnFill(fn func(*private.Fill)) {
+	if fn == nil {
+		c.on("fills", nil)
+		return
+	}
+	c.on("fills", func(e interface{}) { fn(e.(*private.Fill)) })
+}
+
+// OnAlgoOrder registers the callback of orders-algo events, it can be used instead of AlgoOrderCh
+func (c *Private) OnAlgoOrder(fn func(*private.AlgoOrder)) {
+	if fn == nil {
+		c.on("orders-algo", nil)
+		return
+	}
+	c.on("orders-algo", func(e interface{}) { fn(e.(*private.AlgoOrder)) })
+}
+
+// OnAdvanceAlgoOrder registers the callback of algo-advance events, it can be used instead of AdvanceAlgoOrderCh
+func (c *Private) OnAdvanceAlgoOrder(fn func(*private.AdvanceAlgoOrder)) {
+	if fn == nil {
+		c.on("algo-advance", nil)
+		return
+	}
+	c.on("algo-advance", func(e interface{}) { fn(e.(*private.AdvanceAlgoOrder)) })
+}
+
+// OnGridOrderSpot registers the callback of grid-orders-spot events, it can be used instead of GridOrderSpotCh
+func (c *Private) OnGridOrderSpot(fn func(*private.GridOrder)) {
+	if fn == nil {
+		c.on("grid-orders-spot", nil)
+		return
+	}
+	c.on("grid-orders-spot", func(e interface{}) { fn(e.(*private.GridOrder)) })
+}
+
+// OnGridOrderContract registers the callback of grid-orders-contract events, it can be used instead of GridOrderContractCh
+func (c *Private) OnGridOrderContract(fn func(*private.GridOrder)) {
+	if fn == nil {
+		c.on("grid-orders-contract", nil)
+		return
+	}
+	c.on("grid-orders-contract", func(e interface{}) { fn(e.(*private.GridOrder)) })
+}
+
+// OnGridPosition registers the callback of grid-positions events, it can be used instead of GridPositionCh
+func (c *Private) OnGridPosition(fn func(*private.GridPosition)) {
+	if fn == nil {
+		c.on("grid-positions", nil)
+		return
+	}
+	c.on("grid-positions", func(e interface{}) { fn(e.(*private.GridPosition)) })
+}
+
+// OnLeadNotification registers the callback of copytrading-lead-notification events, it can be used instead of LeadNotificationCh
+func (c *Private) OnLeadNotification(fn func(*private.LeadNotification)) {
+	if fn == nil {
+		c.on("copytrading-lead-notification", nil)
+		return
+	}
+	c.on("copytrading-lead-notification", func(e interface{}) { fn(e.(*private.LeadNotification)) })
+}
+
+// OnRfq registers the callback of rfqs events, it can be used instead of RfqCh
+func (c *Private) OnRfq(fn func(*private.Rfq)) {
+	if fn == nil {
+		c.on("rfqs", nil)
+		return
+	}
+	c.on("rfqs", func(e interface{}) { fn(e.(*private.Rfq)) })
+}
+
+// OnQuote registers the callback of quotes events, it can be used instead of QuoteCh
+func (c *Private) OnQuote(fn func(*private.Quote)) {
+	if fn == nil {
+		c.on("quotes", nil)
+		return
+	}
+	c.on("quotes", func(e interface{}) { fn(e.(*private.Quote)) })
+}
+
+// OnStrucBlockTrade registers the callback of struc-block-trades events, it can be used instead of StrucBlockTradeCh
+func (c *Private) OnStrucBlockTrade(fn func(*private.StrucBlockTrade)) {
+	if fn == nil {
+		c.on("struc-block-trades", nil)
+		return
+	}
+	c.on("struc-block-trades", func(e interface{}) { fn(e.(*private.StrucBlockTrade)) })
+}
+
 func (c *Private) Process(data []byte, e *events.Basic) bool {
 	if e.Event == "" && e.Arg != nil && e.Data != nil && len(e.Data) > 0 {
 		ch, ok := e.Arg.Get("channel")
@@ -155,9 +646,11 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			if c.AccountCh != nil {
-				c.AccountCh <- &e
-			}
+			c.dispatch("account", &e)
+			c.mu.RLock()
+			out := c.AccountCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "AccountCh", out, &e)
 			return true
 		case "positions":
 			e := private.Position{}
@@ -165,9 +658,11 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			if c.PositionCh != nil {
-				c.PositionCh <- &e
-			}
+			c.dispatch("positions", &e)
+			c.mu.RLock()
+			out := c.PositionCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "PositionCh", out, &e)
 			return true
 		case "balance_and_position":
 			e := private.BalanceAndPosition{}
@@ -175,9 +670,11 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			if c.BalanceAndPositionCh != nil {
-				c.BalanceAndPositionCh <- &e
-			}
+			c.dispatch("balance_and_position", &e)
+			c.mu.RLock()
+			out := c.BalanceAndPositionCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "BalanceAndPositionCh", out, &e)
 			return true
 		case "orders":
 			e := private.Order{}
@@ -185,9 +682,131 @@ func (c *Private) Process(data []byte, e *events.Basic) bool {
 			if err != nil {
 				return false
 			}
-			if c.OrderCh != nil {
-				c.OrderCh <- &e
+			c.dispatch("orders", &e)
+			c.mu.RLock()
+			out := c.OrderCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "OrderCh", out, &e)
+			return true
+		case "fills":
+			e := private.Fill{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("fills", &e)
+			c.mu.RLock()
+			out := c.FillCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "FillCh", out, &e)
+			return true
+		case "orders-algo":
+			e := private.AlgoOrder{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("orders-algo", &e)
+			c.mu.RLock()
+			out := c.AlgoOrderCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "AlgoOrderCh", out, &e)
+			return true
+		case "algo-advance":
+			e := private.AdvanceAlgoOrder{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("algo-advance", &e)
+			c.mu.RLock()
+			out := c.AdvanceAlgoOrderCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "AdvanceAlgoOrderCh", out, &e)
+			return true
+		case "grid-orders-spot":
+			e := private.GridOrder{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("grid-orders-spot", &e)
+			c.mu.RLock()
+			out := c.GridOrderSpotCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "GridOrderSpotCh", out, &e)
+			return true
+		case "grid-orders-contract":
+			e := private.GridOrder{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("grid-orders-contract", &e)
+			c.mu.RLock()
+			out := c.GridOrderContractCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "GridOrderContractCh", out, &e)
+			return true
+		case "grid-positions":
+			e := private.GridPosition{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("grid-positions", &e)
+			c.mu.RLock()
+			out := c.GridPositionCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "GridPositionCh", out, &e)
+			return true
+		case "copytrading-lead-notification":
+			e := private.LeadNotification{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("copytrading-lead-notification", &e)
+			c.mu.RLock()
+			out := c.LeadNotificationCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "LeadNotificationCh", out, &e)
+			return true
+		case "rfqs":
+			e := private.Rfq{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("rfqs", &e)
+			c.mu.RLock()
+			out := c.RfqCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "RfqCh", out, &e)
+			return true
+		case "quotes":
+			e := private.Quote{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
+			}
+			c.dispatch("quotes", &e)
+			c.mu.RLock()
+			out := c.QuoteCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "QuoteCh", out, &e)
+			return true
+		case "struc-block-trades":
+			e := private.StrucBlockTrade{}
+			err := json.Unmarshal(data, &e)
+			if err != nil {
+				return false
 			}
+			c.dispatch("struc-block-trades", &e)
+			c.mu.RLock()
+			out := c.StrucBlockTradeCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "StrucBlockTradeCh", out, &e)
 			return true
 		}
 	}
@@ -30,6 +30,7 @@ type Public struct {
 	IndexCandlesticksCh              chan *public.IndexCandlesticks
 	IndexTickersCh                   chan *public.IndexTickers
 	LiquidationOrdersCh              chan *public.LiquidationOrders
+	PublicBlockTradesCh              chan *public.PublicBlockTrades
 }
 
 // NewPublic returns a pointer to a fresh Public
@@ -47,7 +48,9 @@ func (c *Public) Instruments(req []requests.Instruments, ch ...chan *public.Inst
 		m[i]["channel"] = "instruments"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.InstrumentsCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -61,7 +64,9 @@ func (c *Public) UInstruments(req []requests.Instruments, rCh ...bool) error {
 		m[i]["channel"] = "instruments"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.InstrumentsCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -76,7 +81,9 @@ func (c *Public) Tickers(req []requests.Tickers, ch ...chan *public.Tickers) err
 		m[i]["channel"] = "tickers"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.TickersCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -90,7 +97,9 @@ func (c *Public) UTickers(req []requests.Tickers, rCh ...bool) error {
 		m[i]["channel"] = "tickers"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.TickersCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -105,7 +114,9 @@ func (c *Public) OpenInterest(req []requests.OpenInterest, ch ...chan *public.Op
 		m[i]["channel"] = "open-interest"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.OpenInterestCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -119,7 +130,9 @@ func (c *Public) UOpenInterest(req []requests.OpenInterest, rCh ...bool) error {
 		m[i]["channel"] = "open-interest"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.OpenInterestCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -130,7 +143,9 @@ func (c *Public) UOpenInterest(req []requests.OpenInterest, rCh ...bool) error {
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-candlesticks-channel
 func (c *Public) Candlesticks(req []requests.Candlesticks, ch ...chan *public.Candlesticks) error {
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.CandlesticksCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, okex.StructSlice2MapSlice(req))
 }
@@ -141,7 +156,9 @@ func (c *Public) Candlesticks(req []requests.Candlesticks, ch ...chan *public.Ca
 func (c *Public) UCandlesticks(req []requests.Candlesticks, rCh ...bool) error {
 	m := okex.StructSlice2MapSlice(req)
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.CandlesticksCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -156,7 +173,9 @@ func (c *Public) Trades(req []requests.Trades, ch ...chan *public.Trades) error
 		m[i]["channel"] = "trades"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.TradesCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -170,7 +189,9 @@ func (c *Public) UTrades(req []requests.Trades, rCh ...bool) error {
 		m[i]["channel"] = "trades"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.TradesCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -187,7 +208,9 @@ func (c *Public) EstimatedDeliveryExercisePrice(req []requests.EstimatedDelivery
 		m[i]["channel"] = "estimated-price"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.EstimatedDeliveryExercisePriceCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -201,7 +224,9 @@ func (c *Public) UEstimatedDeliveryExercisePrice(req []requests.EstimatedDeliver
 		m[i]["channel"] = "estimated-price"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.EstimatedDeliveryExercisePriceCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -216,7 +241,9 @@ func (c *Public) MarkPrice(req []requests.MarkPrice, ch ...chan *public.MarkPric
 		m[i]["channel"] = "mark-price"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.MarkPriceCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -230,7 +257,9 @@ func (c *Public) UMarkPrice(req []requests.MarkPrice, rCh ...bool) error {
 		m[i]["channel"] = "mark-price"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.MarkPriceCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -245,7 +274,9 @@ func (c *Public) MarkPriceCandlesticks(req []requests.MarkPriceCandlesticks, ch
 		m[i]["channel"] = "mark-price-" + m[i]["channel"]
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.MarkPriceCandlesticksCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -259,7 +290,9 @@ func (c *Public) UMarkPriceCandlesticks(req []requests.MarkPriceCandlesticks, rC
 		m[i]["channel"] = "mark-price-" + m[i]["channel"]
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.MarkPriceCandlesticksCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -274,7 +307,9 @@ func (c *Public) PriceLimit(req []requests.PriceLimit, ch ...chan *public.PriceL
 		m[i]["channel"] = "price-limit"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.PriceLimitCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -288,7 +323,9 @@ func (c *Public) UPriceLimit(req []requests.PriceLimit, rCh ...bool) error {
 		m[i]["channel"] = "price-limit"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.PriceLimitCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -296,13 +333,15 @@ func (c *Public) UPriceLimit(req []requests.PriceLimit, rCh ...bool) error {
 // OrderBook
 // Retrieve order book data.
 //
-// Use books for 400 depth levels, book5 for 5 depth levels, books50-l2-tbt tick-by-tick 50 depth levels, and books-l2-tbt for tick-by-tick 400 depth levels.
+// Use books for 400 depth levels, book5 for 5 depth levels, bbo-tbt for the tick-by-tick best bid and offer, books50-l2-tbt tick-by-tick 50 depth levels, and books-l2-tbt for tick-by-tick 400 depth levels.
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-public-channels-order-book-channel
 func (c *Public) OrderBook(req []requests.OrderBook, ch ...chan *public.OrderBook) error {
 	m := okex.StructSlice2MapSlice(req)
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.OrderBookCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -313,7 +352,9 @@ func (c *Public) OrderBook(req []requests.OrderBook, ch ...chan *public.OrderBoo
 func (c *Public) UOrderBook(req []requests.OrderBook, rCh ...bool) error {
 	m := okex.StructSlice2MapSlice(req)
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.OrderBookCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -328,7 +369,9 @@ func (c *Public) OPTIONSummary(req []requests.OPTIONSummary, ch ...chan *public.
 		m[i]["channel"] = "opt-summary"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.OptionSummaryCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -342,7 +385,9 @@ func (c *Public) UOPTIONSummary(req []requests.OPTIONSummary, rCh ...bool) error
 		m[i]["channel"] = "opt-summary"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.OptionSummaryCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -357,7 +402,9 @@ func (c *Public) FundingRate(req []requests.FundingRate, ch ...chan *public.Fund
 		m[i]["channel"] = "funding-rate"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.FundingRateCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -371,7 +418,9 @@ func (c *Public) UFundingRate(req []requests.FundingRate, rCh ...bool) error {
 		m[i]["channel"] = "funding-rate"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.FundingRateCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -383,7 +432,9 @@ func (c *Public) UFundingRate(req []requests.FundingRate, rCh ...bool) error {
 func (c *Public) IndexCandlesticks(req []requests.IndexCandlesticks, ch ...chan *public.IndexCandlesticks) error {
 	m := okex.StructSlice2MapSlice(req)
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.IndexCandlesticksCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -394,7 +445,9 @@ func (c *Public) IndexCandlesticks(req []requests.IndexCandlesticks, ch ...chan
 func (c *Public) UIndexCandlesticks(req []requests.IndexCandlesticks, rCh ...bool) error {
 	m := okex.StructSlice2MapSlice(req)
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.IndexCandlesticksCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
@@ -409,7 +462,9 @@ func (c *Public) IndexTickers(req []requests.IndexTickers, ch ...chan *public.In
 		m[i]["channel"] = "index-tickers"
 	}
 	if len(ch) > 0 {
+		c.mu.Lock()
 		c.IndexTickersCh = ch[0]
+		c.mu.Unlock()
 	}
 	return c.Subscribe(false, m)
 }
@@ -423,180 +478,433 @@ func (c *Public) UIndexTickers(req []requests.IndexTickers, rCh ...bool) error {
 		m[i]["channel"] = "index-tickers"
 	}
 	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
 		c.IndexTickersCh = nil
+		c.mu.Unlock()
 	}
 	return c.Unsubscribe(false, m)
 }
 
+// PublicBlockTrades
+// Retrieve the block trades of an instrument executed on OKX, every leg of a multi-leg trade is pushed on its own.
+// The channel is served by the business url.
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-public-channel-public-block-trades-channel
+func (c *Public) PublicBlockTrades(req []requests.PublicBlockTrades, ch ...chan *public.PublicBlockTrades) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "public-block-trades"
+	}
+	if len(ch) > 0 {
+		c.mu.Lock()
+		c.PublicBlockTradesCh = ch[0]
+		c.mu.Unlock()
+	}
+	return c.SubscribeBusiness(false, m)
+}
+
+// UPublicBlockTrades
+//
+// https://www.okx.com/docs-v5/en/#block-trading-websocket-public-channel-public-block-trades-channel
+func (c *Public) UPublicBlockTrades(req []requests.PublicBlockTrades, rCh ...bool) error {
+	m := okex.StructSlice2MapSlice(req)
+	for i, _ := range m {
+		m[i]["channel"] = "public-block-trades"
+	}
+	if len(rCh) > 0 && rCh[0] {
+		c.mu.Lock()
+		c.PublicBlockTradesCh = nil
+		c.mu.Unlock()
+	}
+	return c.UnsubscribeBusiness(false, m)
+}
+
+// OnInstrument registers the callback of instruments events, it can be used instead of InstrumentsCh
+func (c *Public) OnInstrument(fn func(*public.Instruments)) {
+	if fn == nil {
+		c.on("instruments", nil)
+		return
+	}
+	c.on("instruments", func(e interface{}) { fn(e.(*public.Instruments)) })
+}
+
+// ListenInstrument adds a callback of instruments events alongside the OnInstrument one, OnInstrument doesn't replace it.
+// The returned func removes it.
+func (c *Public) ListenInstrument(fn func(*public.Instruments)) func() {
+	return c.listen("instruments", func(e interface{}) { fn(e.(*public.Instruments)) })
+}
+
+// OnTicker registers the callback of tickers events, it can be used instead of TickersCh
+func (c *Public) OnTicker(fn func(*public.Tickers)) {
+	if fn == nil {
+		c.on("tickers", nil)
+		return
+	}
+	c.on("tickers", func(e interface{}) { fn(e.(*public.Tickers)) })
+}
+
+// OnOpenInterest registers the callback of open-interest events, it can be used instead of OpenInterestCh
+func (c *Public) OnOpenInterest(fn func(*public.OpenInterest)) {
+	if fn == nil {
+		c.on("open-interest", nil)
+		return
+	}
+	c.on("open-interest", func(e interface{}) { fn(e.(*public.OpenInterest)) })
+}
+
+// OnCandlestick registers the callback of candle events, it can be used instead of CandlesticksCh
+func (c *Public) OnCandlestick(fn func(*public.Candlesticks)) {
+	if fn == nil {
+		c.on("candle", nil)
+		return
+	}
+	c.on("candle", func(e interface{}) { fn(e.(*public.Candlesticks)) })
+}
+
+// OnTrade registers the callback of trades events, it can be used instead of TradesCh
+func (c *Public) OnTrade(fn func(*public.Trades)) {
+	if fn == nil {
+		c.on("trades", nil)
+		return
+	}
+	c.on("trades", func(e interface{}) { fn(e.(*public.Trades)) })
+}
+
+// OnEstimatedDeliveryExercisePrice registers the callback of estimated-price events, it can be used instead of EstimatedDeliveryExercisePriceCh
+func (c *Public) OnEstimatedDeliveryExercisePrice(fn func(*public.EstimatedDeliveryExercisePrice)) {
+	if fn == nil {
+		c.on("estimated-price", nil)
+		return
+	}
+	c.on("estimated-price", func(e interface{}) { fn(e.(*public.EstimatedDeliveryExercisePrice)) })
+}
+
+// OnMarkPrice registers the callback of mark-price events, it can be used instead of MarkPriceCh
+func (c *Public) OnMarkPrice(fn func(*public.MarkPrice)) {
+	if fn == nil {
+		c.on("mark-price", nil)
+		return
+	}
+	c.on("mark-price", func(e interface{}) { fn(e.(*public.MarkPrice)) })
+}
+
+// OnMarkPriceCandlestick registers the callback of mark-price-candle events, it can be used instead of MarkPriceCandlesticksCh
+func (c *Public) OnMarkPriceCandlestick(fn func(*public.MarkPriceCandlesticks)) {
+	if fn == nil {
+		c.on("mark-price-candle", nil)
+		return
+	}
+	c.on("mark-price-candle", func(e interface{}) { fn(e.(*public.MarkPriceCandlesticks)) })
+}
+
+// OnPriceLimit registers the callback of price-limit events, it can be used instead of PriceLimitCh
+func (c *Public) OnPriceLimit(fn func(*public.PriceLimit)) {
+	if fn == nil {
+		c.on("price-limit", nil)
+		return
+	}
+	c.on("price-limit", func(e interface{}) { fn(e.(*public.PriceLimit)) })
+}
+
+// OnOrderBook registers the callback of books events, it can be used instead of OrderBookCh
+func (c *Public) OnOrderBook(fn func(*public.OrderBook)) {
+	if fn == nil {
+		c.on("books", nil)
+		return
+	}
+	c.on("books", func(e interface{}) { fn(e.(*public.OrderBook)) })
+}
+
+// ListenOrderBook adds a callback of books events alongside the OnOrderBook one, OnOrderBook doesn't replace it.
+// The returned func removes it.
+func (c *Public) ListenOrderBook(fn func(*public.OrderBook)) func() {
+	return c.listen("books", func(e interface{}) { fn(e.(*public.OrderBook)) })
+}
+
+// OnOptionSummary registers the callback of opt-summary events, it can be used instead of OptionSummaryCh
+func (c *Public) OnOptionSummary(fn func(*public.OptionSummary)) {
+	if fn == nil {
+		c.on("opt-summary", nil)
+		return
+	}
+	c.on("opt-summary", func(e interface{}) { fn(e.(*public.OptionSummary)) })
+}
+
+// OnFundingRate registers the callback of funding-rate events, it can be used instead of FundingRateCh
+func (c *Public) OnFundingRate(fn func(*public.FundingRate)) {
+	if fn == nil {
+		c.on("funding-rate", nil)
+		return
+	}
+	c.on("funding-rate", func(e interface{}) { fn(e.(*public.FundingRate)) })
+}
+
+// OnIndexCandlestick registers the callback of index-candle events, it can be used instead of IndexCandlesticksCh
+func (c *Public) OnIndexCandlestick(fn func(*public.IndexCandlesticks)) {
+	if fn == nil {
+		c.on("index-candle", nil)
+		return
+	}
+	c.on("index-candle", func(e interface{}) { fn(e.(*public.IndexCandlesticks)) })
+}
+
+// OnIndexTicker registers the callback of index-tickers events, it can be used instead of IndexTickersCh
+func (c *Public) OnIndexTicker(fn func(*public.IndexTickers)) {
+	if fn == nil {
+		c.on("index-tickers", nil)
+		return
+	}
+	c.on("index-tickers", func(e interface{}) { fn(e.(*public.IndexTickers)) })
+}
+
+// OnLiquidationOrder registers the callback of liquidation-orders events, it can be used instead of LiquidationOrdersCh
+func (c *Public) OnLiquidationOrder(fn func(*public.LiquidationOrders)) {
+	if fn == nil {
+		c.on("liquidation-orders", nil)
+		return
+	}
+	c.on("liquidation-orders", func(e interface{}) { fn(e.(*public.LiquidationOrders)) })
+}
+
+// OnPublicBlockTrade registers the callback of public-block-trades events, it can be used instead of PublicBlockTradesCh
+func (c *Public) OnPublicBlockTrade(fn func(*public.PublicBlockTrades)) {
+	if fn == nil {
+		c.on("public-block-trades", nil)
+		return
+	}
+	c.on("public-block-trades", func(e interface{}) { fn(e.(*public.PublicBlockTrades)) })
+}
+
 func (c *Public) Process(data []byte, e *events.Basic) bool {
 	if e.Event == "" && e.Arg != nil && e.Data != nil && len(e.Data) > 0 {
 		ch, ok := e.Arg.Get("channel")
 		if !ok {
 			return false
 		}
-		switch ch {
-		case "instruments":
-			e := public.Instruments{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.InstrumentsCh != nil {
-				c.InstrumentsCh <- &e
-			}
-			return true
-		case "tickers":
-			e := public.Tickers{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.TickersCh != nil {
-				c.TickersCh <- &e
-			}
-			return true
-		case "open-interest":
-			e := public.OpenInterest{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.OpenInterestCh != nil {
-				c.OpenInterestCh <- &e
-			}
-			return true
-		case "trades":
-			e := public.Trades{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.TradesCh != nil {
-				c.TradesCh <- &e
-			}
-			return true
-		case "estimated-price":
-			e := public.EstimatedDeliveryExercisePrice{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.EstimatedDeliveryExercisePriceCh != nil {
-				c.EstimatedDeliveryExercisePriceCh <- &e
-			}
-			return true
-		case "mark-price":
-			e := public.MarkPrice{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.MarkPriceCh != nil {
-				c.MarkPriceCh <- &e
-			}
-			return true
-		case "price-limit":
-			e := public.PriceLimit{}
-			err := json.Unmarshal(data, &e)
-			if err != nil {
-				return false
-			}
-			if c.PriceLimitCh != nil {
-				c.PriceLimitCh <- &e
-			}
-			return true
-		case "opt-summary":
-			e := public.OptionSummary{}
+		return c.processChannel(fmt.Sprint(ch), data)
+	}
+	return false
+}
+
+// processFast handles the high rate channels straight from the raw message, skipping the generic decoding into events.Basic.
+// Their book levels, candles, tickers and trades are scanned by hand, see the UnmarshalJSON of the market models.
+func (c *Public) processFast(data []byte) bool {
+	ch, ok := events.PeekChannel(data)
+	if !ok {
+		return false
+	}
+	switch {
+	case ch == "tickers", ch == "trades", ch == "bbo-tbt", strings.HasPrefix(ch, "books"), strings.HasPrefix(ch, "candle"):
+		return c.processChannel(ch, data)
+	}
+	return false
+}
+
+func (c *Public) processChannel(ch string, data []byte) bool {
+	switch ch {
+	case "instruments":
+		e := public.Instruments{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("instruments", &e)
+		c.mu.RLock()
+		out := c.InstrumentsCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "InstrumentsCh", out, &e)
+		return true
+	case "tickers":
+		e := public.Tickers{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("tickers", &e)
+		c.mu.RLock()
+		out := c.TickersCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "TickersCh", out, &e)
+		return true
+	case "open-interest":
+		e := public.OpenInterest{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("open-interest", &e)
+		c.mu.RLock()
+		out := c.OpenInterestCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "OpenInterestCh", out, &e)
+		return true
+	case "trades":
+		e := public.Trades{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("trades", &e)
+		c.mu.RLock()
+		out := c.TradesCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "TradesCh", out, &e)
+		return true
+	case "estimated-price":
+		e := public.EstimatedDeliveryExercisePrice{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("estimated-price", &e)
+		c.mu.RLock()
+		out := c.EstimatedDeliveryExercisePriceCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "EstimatedDeliveryExercisePriceCh", out, &e)
+		return true
+	case "mark-price":
+		e := public.MarkPrice{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("mark-price", &e)
+		c.mu.RLock()
+		out := c.MarkPriceCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "MarkPriceCh", out, &e)
+		return true
+	case "price-limit":
+		e := public.PriceLimit{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("price-limit", &e)
+		c.mu.RLock()
+		out := c.PriceLimitCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "PriceLimitCh", out, &e)
+		return true
+	case "opt-summary":
+		e := public.OptionSummary{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("opt-summary", &e)
+		c.mu.RLock()
+		out := c.OptionSummaryCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "OptionSummaryCh", out, &e)
+		return true
+	case "funding-rate":
+		e := public.FundingRate{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("funding-rate", &e)
+		c.mu.RLock()
+		out := c.FundingRateCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "FundingRateCh", out, &e)
+		return true
+	case "index-tickers":
+		e := public.IndexTickers{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("index-tickers", &e)
+		c.mu.RLock()
+		out := c.IndexTickersCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "IndexTickersCh", out, &e)
+		return true
+	case "liquidation-orders":
+		e := public.LiquidationOrders{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("liquidation-orders", &e)
+		c.mu.RLock()
+		out := c.LiquidationOrdersCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "LiquidationOrdersCh", out, &e)
+		return true
+	case "public-block-trades":
+		e := public.PublicBlockTrades{}
+		err := json.Unmarshal(data, &e)
+		if err != nil {
+			return false
+		}
+		c.dispatch("public-block-trades", &e)
+		c.mu.RLock()
+		out := c.PublicBlockTradesCh
+		c.mu.RUnlock()
+		deliver(c.ClientWs, "PublicBlockTradesCh", out, &e)
+		return true
+	default:
+		// special cases
+		// market price candlestick channel
+		chName := ch
+		// market price channels
+		if strings.Contains(chName, "mark-price-candle") {
+			e := public.MarkPriceCandlesticks{}
 			err := json.Unmarshal(data, &e)
 			if err != nil {
 				return false
 			}
-			if c.OptionSummaryCh != nil {
-				c.OptionSummaryCh <- &e
-			}
+			c.dispatch("mark-price-candle", &e)
+			c.mu.RLock()
+			out := c.MarkPriceCandlesticksCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "MarkPriceCandlesticksCh", out, &e)
 			return true
-		case "funding-rate":
-			e := public.FundingRate{}
+		}
+		// index chandlestick channels
+		if strings.Contains(chName, "index-candle") {
+			e := public.IndexCandlesticks{}
 			err := json.Unmarshal(data, &e)
 			if err != nil {
 				return false
 			}
-			if c.FundingRateCh != nil {
-				c.FundingRateCh <- &e
-			}
+			c.dispatch("index-candle", &e)
+			c.mu.RLock()
+			out := c.IndexCandlesticksCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "IndexCandlesticksCh", out, &e)
 			return true
-		case "index-tickers":
-			e := public.IndexTickers{}
+		}
+		// candlestick channels
+		if strings.Contains(chName, "candle") {
+			e := public.Candlesticks{}
 			err := json.Unmarshal(data, &e)
 			if err != nil {
 				return false
 			}
-			if c.IndexTickersCh != nil {
-				c.IndexTickersCh <- &e
-			}
+			c.dispatch("candle", &e)
+			c.mu.RLock()
+			out := c.CandlesticksCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "CandlesticksCh", out, &e)
 			return true
-		case "liquidation-orders":
-			e := public.LiquidationOrders{}
+		}
+		// order book channels, bbo-tbt is the tick-by-tick best bid and offer
+		if strings.Contains(chName, "books") || chName == "bbo-tbt" {
+			e := public.OrderBook{}
 			err := json.Unmarshal(data, &e)
 			if err != nil {
 				return false
 			}
-			if c.LiquidationOrdersCh != nil {
-				c.LiquidationOrdersCh <- &e
-			}
+			c.dispatch("books", &e)
+			c.mu.RLock()
+			out := c.OrderBookCh
+			c.mu.RUnlock()
+			deliver(c.ClientWs, "OrderBookCh", out, &e)
 			return true
-		default:
-			// special cases
-			// market price candlestick channel
-			chName := fmt.Sprint(ch)
-			// market price channels
-			if strings.Contains(chName, "mark-price-candle") {
-				e := public.MarkPriceCandlesticks{}
-				err := json.Unmarshal(data, &e)
-				if err != nil {
-					return false
-				}
-				if c.MarkPriceCandlesticksCh != nil {
-					c.MarkPriceCandlesticksCh <- &e
-				}
-				return true
-			}
-			// index chandlestick channels
-			if strings.Contains(chName, "index-candle") {
-				e := public.IndexCandlesticks{}
-				err := json.Unmarshal(data, &e)
-				if err != nil {
-					return false
-				}
-				if c.IndexCandlesticksCh != nil {
-					c.IndexCandlesticksCh <- &e
-				}
-				return true
-			}
-			// candlestick channels
-			if strings.Contains(chName, "candle") {
-				e := public.Candlesticks{}
-				err := json.Unmarshal(data, &e)
-				if err != nil {
-					return false
-				}
-				if c.CandlesticksCh != nil {
-					c.CandlesticksCh <- &e
-				}
-				return true
-			}
-			// order book channels
-			if strings.Contains(chName, "books") {
-				e := public.OrderBook{}
-				err := json.Unmarshal(data, &e)
-				if err != nil {
-					return false
-				}
-				if c.OrderBookCh != nil {
-					c.OrderBookCh <- &e
-				}
-				return true
-			}
 		}
 	}
 	return false
@@ -0,0 +1,250 @@
+package ws
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pkg/errors"
+)
+
+// subscription is a subscribe op and the socket it streams on. The op is replayed on a new socket after a send error,
+// a service upgrade notice or Resubscribe, until every arg of it is unsubscribed.
+type subscription struct {
+	url        okex.BaseURL
+	needLogin  bool
+	errChan    chan *connError
+	noticeChan chan *conn
+	resubChan  chan struct{}
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+	mu         sync.Mutex // guards args and cn
+	args       []map[string]string
+	cn         *conn
+}
+
+// argKeys returns the set of args, keyed by their json which has sorted keys
+func argKeys(args []map[string]string) map[string]bool {
+	keys := make(map[string]bool, len(args))
+	for _, arg := range args {
+		keys[argKey(arg)] = true
+	}
+	return keys
+}
+
+func argKey(arg map[string]string) string {
+	j, _ := json.Marshal(arg)
+	return string(j)
+}
+
+func opData(op okex.Operation, args []map[string]string) []byte {
+	j, _ := json.Marshal(map[string]interface{}{
+		"op":   op,
+		"args": args,
+	})
+	return j
+}
+
+// data is the subscribe op of the args left
+func (s *subscription) data() []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return opData(okex.SubscribeOperation, s.args)
+}
+
+// newConn returns a socket for the subscription, it reports its send errors and notices to the monitor
+func (s *subscription) newConn() *conn {
+	cn := newConn(s.needLogin, s.errChan, s.noticeChan)
+	cn.subscribed = true
+	return cn
+}
+
+func (s *subscription) conn() *conn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cn
+}
+
+// setConn makes cn the socket of the subscription, it's closed right away if the subscription was unsubscribed meanwhile
+func (s *subscription) setConn(cn *conn) {
+	s.mu.Lock()
+	s.cn = cn
+	stopped := s.stopped()
+	s.mu.Unlock()
+	if stopped {
+		cn.close()
+	}
+}
+
+// has reports whether every arg of keys is subscribed
+func (s *subscription) has(keys map[string]bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	subscribed := argKeys(s.args)
+	for key := range keys {
+		if !subscribed[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// remove drops the args of keys, it returns the dropped ones, the current socket and whether no arg is left.
+// The subscription is stopped once no arg is left.
+func (s *subscription) remove(keys map[string]bool) ([]map[string]string, *conn, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var removed, left []map[string]string
+	for _, arg := range s.args {
+		if keys[argKey(arg)] {
+			removed = append(removed, arg)
+		} else {
+			left = append(left, arg)
+		}
+	}
+	if len(removed) == 0 {
+		return nil, s.cn, false
+	}
+	s.args = left
+	if len(left) == 0 {
+		s.stopOnce.Do(func() { close(s.stopChan) })
+	}
+	return removed, s.cn, len(left) == 0
+}
+
+func (s *subscription) stopped() bool {
+	select {
+	case <-s.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *ClientWs) subscribe(url okex.BaseURL, needLogin bool, args []map[string]string) error {
+	s := &subscription{
+		url:        url,
+		needLogin:  needLogin,
+		errChan:    make(chan *connError, 2),
+		noticeChan: make(chan *conn, 1),
+		resubChan:  make(chan struct{}, 1),
+		stopChan:   make(chan struct{}),
+		args:       append([]map[string]string(nil), args...),
+	}
+	cn := s.newConn()
+	if err := c.connect(url, cn, s.data()); err != nil {
+		return err
+	}
+	s.cn = cn
+	c.mu.Lock()
+	c.subscriptions = append(c.subscriptions, s)
+	c.mu.Unlock()
+
+	go c.monitor(s)
+	return nil
+}
+
+// unsubscribe sends the unsubscribe on the sockets of the subscriptions holding args, the ones left without args are
+// dropped and their sockets closed once the unsubscribe is answered
+func (c *ClientWs) unsubscribe(url okex.BaseURL, needLogin bool, args []map[string]string) error {
+	keys := argKeys(args)
+	c.mu.RLock()
+	subs := make([]*subscription, 0, len(c.subscriptions))
+	for _, s := range c.subscriptions {
+		if s.url == url && s.needLogin == needLogin {
+			subs = append(subs, s)
+		}
+	}
+	c.mu.RUnlock()
+
+	found := false
+	for _, s := range subs {
+		removed, cn, empty := s.remove(keys)
+		if len(removed) == 0 {
+			continue
+		}
+		found = true
+		if empty {
+			c.drop(s)
+			c.closeAfterReply(cn)
+		}
+		cn.send(c.ctx, opData(okex.UnsubscribeOperation, removed))
+	}
+	if !found {
+		return errors.Errorf("<%s> Subscription not found.", opData(okex.UnsubscribeOperation, args))
+	}
+	return nil
+}
+
+func (c *ClientWs) drop(s *subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, sub := range c.subscriptions {
+		if sub == s {
+			c.subscriptions = append(c.subscriptions[:i:i], c.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// monitor keeps the subscription on a live socket until it's unsubscribed or the client is cancelled
+func (c *ClientWs) monitor(s *subscription) {
+	for {
+		select {
+		case ce := <-s.errChan:
+			ce.cn.close()
+			if ce.cn != s.conn() {
+				// the socket was already replaced
+				continue
+			}
+			c.logger.ErrorF("<%s> Send error <%+v>, reconnect...\n", string(s.data()), ce.err)
+			c.reconnect(s)
+		case noticed := <-s.noticeChan:
+			if noticed != s.conn() {
+				continue
+			}
+			// the old socket keeps streaming until the replacement is subscribed, so nothing is missed,
+			// it's closed by the subscribe reply of the replacement
+			c.logger.InfoF("<%s> Service upgrade notice, switching connection...\n", string(s.data()))
+			cn := s.newConn()
+			cn.replaces = noticed
+			if err := c.connect(s.url, cn, s.data()); err != nil {
+				c.logger.ErrorF("<%s> Switch connection failed <%+v>, keep the old one.\n", string(s.data()), err)
+				continue
+			}
+			s.setConn(cn)
+			c.logger.InfoF("<%s> Switch connection success.", string(s.data()))
+		case <-s.resubChan:
+			c.logger.InfoF("<%s> Resubscribe...\n", string(s.data()))
+			s.conn().close()
+			c.reconnect(s)
+		case <-s.stopChan:
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+// reconnect replaces the socket of the subscription, it retries until it succeeds, the subscription is stopped
+// or the client is cancelled
+func (c *ClientWs) reconnect(s *subscription) {
+	for !s.stopped() {
+		cn := s.newConn()
+		err := c.connect(s.url, cn, s.data())
+		if err == nil {
+			s.setConn(cn)
+			c.logger.InfoF("<%s> Connect success.", string(s.data()))
+			return
+		}
+		c.logger.ErrorF("<%s> Connect failed <%+v>, retry...\n", string(s.data()), err)
+		select {
+		case <-time.After(redialTick):
+		case <-s.stopChan:
+			return
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package ws
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/pefish/go-okx"
 	requests "github.com/pefish/go-okx/requests/ws/trade"
+	"github.com/pkg/errors"
 )
 
 // Trade
@@ -25,16 +29,24 @@ func NewTrade(c *ClientWs) *Trade {
 // Place orders in a batch. Maximum 20 orders can be placed at a time
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-place-multiple-orders
+//
+// The ID of the first order is the id of the message, the earliest ExpTime is its expTime.
 func (c *Trade) PlaceOrder(req ...requests.PlaceOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
+	if len(req) == 0 {
+		return errors.New("no order in the request")
+	}
 	op := okex.OrderOperation
 	if len(req) > 1 {
 		op = okex.BatchOrderOperation
 	}
+	expTimes := make([]time.Time, len(req))
 	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+		if err := order.Validate(); err != nil {
+			return err
+		}
+		expTimes[i] = order.ExpTime
 	}
-	return c.Send(true, op, tmpArgs)
+	return c.send(true, tradeMessage(req[0].ID, op, req, expTimes...))
 }
 
 // CancelOrder
@@ -46,15 +58,19 @@ func (c *Trade) PlaceOrder(req ...requests.PlaceOrder) error {
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-cancel-multiple-orders
 func (c *Trade) CancelOrder(req ...requests.CancelOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
+	if len(req) == 0 {
+		return errors.New("no order in the request")
+	}
 	op := okex.CancelOrderOperation
 	if len(req) > 1 {
 		op = okex.BatchCancelOrderOperation
 	}
-	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+	for _, order := range req {
+		if err := order.Validate(); err != nil {
+			return err
+		}
 	}
-	return c.Send(true, op, tmpArgs)
+	return c.send(true, tradeMessage(req[0].ID, op, req))
 }
 
 // AmendOrder
@@ -65,14 +81,48 @@ func (c *Trade) CancelOrder(req ...requests.CancelOrder) error {
 // Amend incomplete orders in batches. Maximum 20 orders can be amended at a time.
 //
 // https://www.okex.com/docs-v5/en/#websocket-api-trade-amend-multiple-orders
+//
+// The ID of the first amendment is the id of the message, the earliest ExpTime is its expTime.
 func (c *Trade) AmendOrder(req ...requests.AmendOrder) error {
-	tmpArgs := make([]map[string]string, len(req))
+	if len(req) == 0 {
+		return errors.New("no order in the request")
+	}
 	op := okex.AmendOrderOperation
 	if len(req) > 1 {
 		op = okex.BatchAmendOrderOperation
 	}
+	expTimes := make([]time.Time, len(req))
 	for i, order := range req {
-		tmpArgs[i] = okex.S2M(order)
+		if err := order.Validate(); err != nil {
+			return err
+		}
+		expTimes[i] = order.ExpTime
+	}
+	return c.send(true, tradeMessage(req[0].ID, op, req, expTimes...))
+}
+
+// tradeMessage returns the message of a trade operation, args are marshalled as is so booleans and attached orders are kept
+func tradeMessage(id string, op okex.Operation, args interface{}, expTimes ...time.Time) map[string]interface{} {
+	msg := map[string]interface{}{
+		"op":   op,
+		"args": args,
+	}
+	if id != "" {
+		msg["id"] = id
+	}
+	if exp := earliest(expTimes); !exp.IsZero() {
+		msg["expTime"] = strconv.FormatInt(exp.UnixMilli(), 10)
+	}
+	return msg
+}
+
+// earliest returns the earliest non zero time of times
+func earliest(times []time.Time) time.Time {
+	var res time.Time
+	for _, t := range times {
+		if !t.IsZero() && (res.IsZero() || t.Before(res)) {
+			res = t
+		}
 	}
-	return c.Send(true, op, tmpArgs)
+	return res
 }
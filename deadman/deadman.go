@@ -0,0 +1,223 @@
+// Package deadman keeps the cancel-all-after countdown armed while the process is alive.
+// When the process dies, hangs or loses its private websocket, the heartbeats stop and the server cancels every pending order once the timeout elapsed.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-trade-post-cancel-all-after
+package deadman
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pefish/go-okx/api/rest"
+	"github.com/pefish/go-okx/api/ws"
+	requests "github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+const (
+	MinTimeout = 10 * time.Second
+	MaxTimeout = 120 * time.Second
+)
+
+var (
+	ErrUnhealthy = errors.New("connection unhealthy, heartbeat skipped")
+	ErrStarted   = errors.New("switch already started")
+)
+
+// Miss is a heartbeat that didn't restart the countdown
+type Miss struct {
+	Err      error
+	LastBeat time.Time // last successful heartbeat, zero when none succeeded
+	Expired  bool      // the timeout elapsed since LastBeat, the server cancelled the orders
+}
+
+// Switch sends a cancel-all-after heartbeat every interval, it's safe for concurrent use
+type Switch struct {
+	trade       *rest.Trade
+	timeout     time.Duration
+	interval    time.Duration
+	tag         string
+	mu          sync.Mutex
+	healthy     func() bool
+	onMiss      func(*Miss)
+	lastBeat    time.Time
+	triggerTime time.Time
+	stop        chan struct{}
+	done        chan struct{}
+}
+
+// NewSwitch returns a pointer to a fresh Switch, timeout is whole seconds between MinTimeout and MaxTimeout.
+// The heartbeat interval defaults to a third of timeout, two heartbeats can be missed before the orders are cancelled.
+func NewSwitch(t *rest.Trade, timeout time.Duration) (*Switch, error) {
+	if timeout < MinTimeout || timeout > MaxTimeout || timeout%time.Second != 0 {
+		return nil, errors.Errorf("timeout %s is not whole seconds within %s-%s", timeout, MinTimeout, MaxTimeout)
+	}
+	return &Switch{trade: t, timeout: timeout, interval: timeout / 3}, nil
+}
+
+// SetInterval sets the time between heartbeats, it must be shorter than the timeout
+func (s *Switch) SetInterval(d time.Duration) error {
+	if d <= 0 || d >= s.timeout {
+		return errors.Errorf("interval %s is not within 0-%s", d, s.timeout)
+	}
+	s.mu.Lock()
+	s.interval = d
+	s.mu.Unlock()
+	return nil
+}
+
+// SetTag sets the tag sent with the heartbeats, the countdown of a tag only cancels the orders placed with it
+func (s *Switch) SetTag(tag string) {
+	s.mu.Lock()
+	s.tag = tag
+	s.mu.Unlock()
+}
+
+// OnMiss registers the callback called from the heartbeat goroutine for every failed or skipped heartbeat
+func (s *Switch) OnMiss(fn func(*Miss)) {
+	s.mu.Lock()
+	s.onMiss = fn
+	s.mu.Unlock()
+}
+
+// SetHealth registers a check called before every heartbeat, the heartbeat is skipped when it returns false
+func (s *Switch) SetHealth(fn func() bool) {
+	s.mu.Lock()
+	s.healthy = fn
+	s.mu.Unlock()
+}
+
+// WatchWs skips the heartbeats while no private socket of c was read from within maxAge,
+// losing the private websocket for longer than the timeout lets the server cancel the orders.
+//
+// Subscribe to a private channel before Start, the first heartbeat fails until a private socket is read from.
+func (s *Switch) WatchWs(c *ws.ClientWs, maxAge time.Duration) {
+	s.SetHealth(func() bool { return c.PrivateAlive(maxAge) })
+}
+
+// Start sends the first heartbeat and returns its error, then keeps sending them until ctx is done or Stop is called.
+// The countdown stays armed when ctx is done, the orders are cancelled once it elapses.
+func (s *Switch) Start(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stop != nil {
+		s.mu.Unlock()
+		return ErrStarted
+	}
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	stop, done, interval := s.stop, s.done, s.interval
+	s.mu.Unlock()
+
+	if err := s.beat(); err != nil {
+		s.mu.Lock()
+		if s.done == done {
+			s.stop, s.done = nil, nil
+		}
+		s.mu.Unlock()
+		close(done)
+		return err
+	}
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.beat(); err != nil {
+					s.miss(err)
+				}
+			case <-stop:
+				return
+			case <-ctx.Done():
+				// the switch can be started again
+				s.mu.Lock()
+				if s.done == done {
+					s.stop, s.done = nil, nil
+				}
+				s.mu.Unlock()
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops the heartbeats and waits for the one in flight. With disarm, the countdown is cancelled and the orders stay on the book,
+// without it they are cancelled once the countdown elapses.
+func (s *Switch) Stop(disarm bool) error {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	tag := s.tag
+	s.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	if !disarm {
+		return nil
+	}
+	res, err := s.trade.CancelAllAfter(requests.CancelAllAfter{TimeOut: 0, Tag: tag})
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.Errorf("CancelAllAfter failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	s.mu.Lock()
+	s.triggerTime = time.Time{}
+	s.mu.Unlock()
+	return nil
+}
+
+// LastBeat returns the time of the last successful heartbeat
+func (s *Switch) LastBeat() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastBeat
+}
+
+// TriggerTime returns when the orders will be cancelled without another heartbeat, it's zero when disarmed
+func (s *Switch) TriggerTime() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.triggerTime
+}
+
+func (s *Switch) beat() error {
+	s.mu.Lock()
+	healthy, tag := s.healthy, s.tag
+	s.mu.Unlock()
+	if healthy != nil && !healthy() {
+		return ErrUnhealthy
+	}
+	res, err := s.trade.CancelAllAfter(requests.CancelAllAfter{TimeOut: int64(s.timeout / time.Second), Tag: tag})
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.Errorf("CancelAllAfter failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	s.mu.Lock()
+	s.lastBeat = time.Now()
+	if len(res.CancelAllAfters) > 0 {
+		s.triggerTime = time.Time(res.CancelAllAfters[0].TriggerTime)
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *Switch) miss(err error) {
+	s.mu.Lock()
+	fn, last := s.onMiss, s.lastBeat
+	s.mu.Unlock()
+	if fn == nil {
+		return
+	}
+	fn(&Miss{
+		Err:      err,
+		LastBeat: last,
+		Expired:  !last.IsZero() && time.Since(last) >= s.timeout,
+	})
+}
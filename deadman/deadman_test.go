@@ -0,0 +1,67 @@
+package deadman
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+)
+
+func newTestSwitch(t *testing.T, beats *atomic.Int64) *Switch {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		beats.Add(1)
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[{"triggerTime":"1587971460000","ts":"1587971400000"}]}`))
+	}))
+	t.Cleanup(srv.Close)
+	c := rest.NewClient(&i_logger.DefaultLogger, "", "", "", okex.BaseURL(srv.URL), okex.NormalServer)
+	s, err := NewSwitch(c.Trade, MinTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetInterval(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSwitchRestartAfterCancel(t *testing.T) {
+	var beats atomic.Int64
+	s := newTestSwitch(t, &beats)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := s.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Start(context.Background()); err != ErrStarted {
+		t.Fatalf("second Start = %v, want ErrStarted", err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		err := s.Start(context.Background())
+		if err == nil {
+			break
+		}
+		if err != ErrStarted || time.Now().After(deadline) {
+			t.Fatalf("Start after the context was cancelled = %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	n := beats.Load()
+	time.Sleep(20 * time.Millisecond)
+	if beats.Load() == n {
+		t.Fatal("no heartbeat after the restart")
+	}
+	if err := s.Stop(false); err != nil {
+		t.Fatal(err)
+	}
+	if s.TriggerTime().IsZero() {
+		t.Fatal("trigger time not set")
+	}
+}
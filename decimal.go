@@ -0,0 +1,334 @@
+package okex
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// Decimal is an exact decimal number kept as the string the server sent, so it's marshalled back losslessly.
+	//
+	// The zero value is the empty string, it's treated as zero and dropped by omitempty.
+	// Arithmetic on a Decimal that was not built by NewDecimal or unmarshalled panics if it's not a valid number.
+	Decimal string
+
+	RoundingMode uint8
+)
+
+const (
+	RoundDown     RoundingMode = iota // towards zero
+	RoundUp                           // away from zero
+	RoundFloor                        // towards negative infinity
+	RoundCeil                         // towards positive infinity
+	RoundHalfUp                       // to nearest, ties away from zero
+	RoundHalfEven                     // to nearest, ties to even
+)
+
+// maxExponent bounds the exponent form, the plain form of larger exponents is too long to be a price or a size
+const maxExponent = 100
+
+var bigTen = big.NewInt(10)
+
+// NewDecimal validates s and returns it as a Decimal. The exponent form is accepted up to an exponent of ±100
+// and converted to the plain form the server expects, "1e-5" becomes "0.00001".
+func NewDecimal(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if !validDecimal(s) {
+		return "", errors.Errorf("invalid decimal %q", s)
+	}
+	return Decimal(s).plain(), nil
+}
+
+// MustDecimal is like NewDecimal but panics on an invalid number
+func MustDecimal(s string) Decimal {
+	d, err := NewDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromInt returns i as a Decimal
+func DecimalFromInt(i int64) Decimal {
+	return Decimal(strconv.FormatInt(i, 10))
+}
+
+// DecimalFromFloat returns the shortest decimal that converts back to f
+func DecimalFromFloat(f float64) Decimal {
+	return Decimal(strconv.FormatFloat(f, 'f', -1, 64))
+}
+
+func validDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+	i := 0
+	if s[i] == '-' || s[i] == '+' {
+		i++
+	}
+	digits, dot := 0, false
+	for ; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.' && !dot:
+			dot = true
+		case (c == 'e' || c == 'E') && digits > 0:
+			e, err := strconv.ParseInt(s[i+1:], 10, 32)
+			return err == nil && e >= -maxExponent && e <= maxExponent
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// parse returns coef and exp of d = coef * 10^exp
+func (d Decimal) parse() (*big.Int, int32) {
+	s := string(d)
+	if s == "" {
+		return new(big.Int), 0
+	}
+	if !validDecimal(s) {
+		panic(errors.Errorf("invalid decimal %q", s))
+	}
+	var exp int64
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, _ = strconv.ParseInt(s[i+1:], 10, 32)
+		s = s[:i]
+	}
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		exp -= int64(len(s) - i - 1)
+		s = s[:i] + s[i+1:]
+	}
+	coef, _ := new(big.Int).SetString(s, 10)
+	return coef, int32(exp)
+}
+
+func format(coef *big.Int, exp int32) Decimal {
+	if exp >= 0 {
+		return Decimal(new(big.Int).Mul(coef, pow10(exp)).String())
+	}
+	digits := new(big.Int).Abs(coef).String()
+	scale := int(-exp)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	if coef.Sign() < 0 {
+		s = "-" + s
+	}
+	return Decimal(s)
+}
+
+// plain returns d without exponent nor plus sign, d is returned as is when it has neither
+func (d Decimal) plain() Decimal {
+	if !strings.ContainsAny(string(d), "eE+") {
+		return d
+	}
+	return format(d.parse())
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// align returns the coefs of d and y scaled to their smallest exponent
+func (d Decimal) align(y Decimal) (*big.Int, *big.Int, int32) {
+	dc, de := d.parse()
+	yc, ye := y.parse()
+	switch {
+	case de > ye:
+		dc.Mul(dc, pow10(de-ye))
+		de = ye
+	case ye > de:
+		yc.Mul(yc, pow10(ye-de))
+	}
+	return dc, yc, de
+}
+
+// quo returns num/den as an integer rounded with mode
+func quo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+	sign := int64(num.Sign() * den.Sign())
+	up := false
+	switch mode {
+	case RoundUp:
+		up = true
+	case RoundFloor:
+		up = sign < 0
+	case RoundCeil:
+		up = sign > 0
+	case RoundHalfUp, RoundHalfEven:
+		half := new(big.Int).Abs(r)
+		half.Lsh(half, 1)
+		c := half.Cmp(new(big.Int).Abs(den))
+		up = c > 0 || (c == 0 && (mode == RoundHalfUp || q.Bit(0) == 1))
+	}
+	if up {
+		q.Add(q, big.NewInt(sign))
+	}
+	return q
+}
+
+// String returns the decimal as it was given, the zero value is "0"
+func (d Decimal) String() string {
+	if d == "" {
+		return "0"
+	}
+	return string(d)
+}
+
+// IsZero reports whether d == 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Sign returns -1, 0 or +1
+func (d Decimal) Sign() int {
+	coef, _ := d.parse()
+	return coef.Sign()
+}
+
+// Cmp returns -1, 0 or +1 when d is less than, equal to or greater than y
+func (d Decimal) Cmp(y Decimal) int {
+	dc, yc, _ := d.align(y)
+	return dc.Cmp(yc)
+}
+
+// Equal reports whether d and y are the same number, "1.50" equals "1.5"
+func (d Decimal) Equal(y Decimal) bool {
+	return d.Cmp(y) == 0
+}
+
+func (d Decimal) LessThan(y Decimal) bool {
+	return d.Cmp(y) < 0
+}
+
+func (d Decimal) GreaterThan(y Decimal) bool {
+	return d.Cmp(y) > 0
+}
+
+func (d Decimal) Add(y Decimal) Decimal {
+	dc, yc, exp := d.align(y)
+	return format(dc.Add(dc, yc), exp)
+}
+
+func (d Decimal) Sub(y Decimal) Decimal {
+	dc, yc, exp := d.align(y)
+	return format(dc.Sub(dc, yc), exp)
+}
+
+func (d Decimal) Mul(y Decimal) Decimal {
+	dc, de := d.parse()
+	yc, ye := y.parse()
+	return format(dc.Mul(dc, yc), de+ye)
+}
+
+// Div returns d/y rounded to places decimal places with mode, it panics when y is zero
+func (d Decimal) Div(y Decimal, places int32, mode RoundingMode) Decimal {
+	dc, de := d.parse()
+	yc, ye := y.parse()
+	if yc.Sign() == 0 {
+		panic(errors.New("decimal division by zero"))
+	}
+	// d/y = dc/yc * 10^(de-ye), scale dc so the quotient has places decimals
+	shift := de - ye + places
+	if shift >= 0 {
+		dc.Mul(dc, pow10(shift))
+	} else {
+		yc.Mul(yc, pow10(-shift))
+	}
+	return format(quo(dc, yc, mode), -places)
+}
+
+func (d Decimal) Neg() Decimal {
+	coef, exp := d.parse()
+	return format(coef.Neg(coef), exp)
+}
+
+func (d Decimal) Abs() Decimal {
+	coef, exp := d.parse()
+	return format(coef.Abs(coef), exp)
+}
+
+// Round rounds d to places decimal places with mode, negative places round to tens, hundreds and so on
+func (d Decimal) Round(places int32, mode RoundingMode) Decimal {
+	coef, exp := d.parse()
+	if exp >= -places {
+		return format(coef, exp)
+	}
+	return format(quo(coef, pow10(-places-exp), mode), -places)
+}
+
+// RoundStep rounds d to a multiple of step with mode, like a price to the tick size or a size to the lot size.
+// The result has the scale of step. A zero step returns d as is.
+func (d Decimal) RoundStep(step Decimal, mode RoundingMode) Decimal {
+	sc, se := step.parse()
+	if sc.Sign() == 0 {
+		return d
+	}
+	dc, stc, _ := d.align(step)
+	n := quo(dc, stc, mode)
+	return format(n.Mul(n, sc), se)
+}
+
+// IsMultipleOf reports whether d is a whole multiple of step
+func (d Decimal) IsMultipleOf(step Decimal) bool {
+	dc, sc, _ := d.align(step)
+	if sc.Sign() == 0 {
+		return dc.Sign() == 0
+	}
+	return new(big.Int).Rem(dc, sc).Sign() == 0
+}
+
+// Trim removes the trailing zeros of the fractional part, "1.2300" becomes "1.23" and "2.0" becomes "2"
+func (d Decimal) Trim() Decimal {
+	coef, exp := d.parse()
+	if coef.Sign() == 0 {
+		return "0"
+	}
+	r := new(big.Int)
+	for exp < 0 {
+		q, m := new(big.Int).QuoRem(coef, bigTen, r)
+		if m.Sign() != 0 {
+			break
+		}
+		coef, exp = q, exp+1
+	}
+	return format(coef, exp)
+}
+
+// Float64 returns the nearest float64 of d
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// MarshalJSON sends the plain form, a Decimal converted from a string in exponent form is converted back
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	if !validDecimal(string(d)) {
+		return []byte(strconv.Quote(d.String())), nil
+	}
+	return []byte(strconv.Quote(d.plain().String())), nil
+}
+
+func (d *Decimal) UnmarshalJSON(s []byte) error {
+	r := string(unquote(s))
+	if r == "" || r == "null" {
+		*d = ""
+		return nil
+	}
+	v, err := NewDecimal(r)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
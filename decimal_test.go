@@ -0,0 +1,185 @@
+package okex
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewDecimal(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Decimal
+		ok   bool
+	}{
+		{"1.5", "1.5", true},
+		{" -0.001 ", "-0.001", true},
+		{"+2", "2", true},
+		{".5", ".5", true},
+		{"1e-5", "0.00001", true},
+		{"1.5E3", "1500", true},
+		{"-2.50e-2", "-0.0250", true},
+		{"1e100", Decimal("1" + strings.Repeat("0", 100)), true},
+		{"1e101", "", false},
+		{"1e2000000000", "", false},
+		{"", "", false},
+		{"1.2.3", "", false},
+		{"e5", "", false},
+		{"abc", "", false},
+	}
+	for _, tt := range tests {
+		got, err := NewDecimal(tt.in)
+		if (err == nil) != tt.ok {
+			t.Errorf("NewDecimal(%q) error = %v, want ok %v", tt.in, err, tt.ok)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NewDecimal(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalMarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   Decimal
+		want string
+	}{
+		{"", `"0"`},
+		{"0.1", `"0.1"`},
+		{"1e-5", `"0.00001"`},
+		{"+3", `"3"`},
+	}
+	for _, tt := range tests {
+		j, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(j) != tt.want {
+			t.Errorf("Marshal(%q) = %s, want %s", tt.in, j, tt.want)
+		}
+	}
+	var d Decimal
+	if err := json.Unmarshal([]byte(`"2E-3"`), &d); err != nil || d != "0.002" {
+		t.Errorf("Unmarshal = %q, %v, want 0.002", d, err)
+	}
+}
+
+func TestDecimalRound(t *testing.T) {
+	modes := []RoundingMode{RoundDown, RoundUp, RoundFloor, RoundCeil, RoundHalfUp, RoundHalfEven}
+	// the results of each mode in the order of modes
+	tests := []struct {
+		in   Decimal
+		want [6]Decimal
+	}{
+		{"1.25", [6]Decimal{"1.2", "1.3", "1.2", "1.3", "1.3", "1.2"}},
+		{"1.35", [6]Decimal{"1.3", "1.4", "1.3", "1.4", "1.4", "1.4"}},
+		{"-1.25", [6]Decimal{"-1.2", "-1.3", "-1.3", "-1.2", "-1.3", "-1.2"}},
+		{"1.21", [6]Decimal{"1.2", "1.3", "1.2", "1.3", "1.2", "1.2"}},
+		{"-1.29", [6]Decimal{"-1.2", "-1.3", "-1.3", "-1.2", "-1.3", "-1.3"}},
+		{"1.2", [6]Decimal{"1.2", "1.2", "1.2", "1.2", "1.2", "1.2"}},
+	}
+	for _, tt := range tests {
+		for i, mode := range modes {
+			if got := tt.in.Round(1, mode); got != tt.want[i] {
+				t.Errorf("%s.Round(1, %d) = %s, want %s", tt.in, mode, got, tt.want[i])
+			}
+		}
+	}
+	if got := Decimal("1234.5").Round(-2, RoundHalfUp); !got.Equal("1200") {
+		t.Errorf("Round(-2) = %s, want 1200", got)
+	}
+}
+
+func TestDecimalRoundStep(t *testing.T) {
+	tests := []struct {
+		in, step Decimal
+		mode     RoundingMode
+		want     Decimal
+	}{
+		{"42219.93", "0.1", RoundDown, "42219.9"},
+		{"42219.95", "0.1", RoundHalfUp, "42220.0"},
+		{"42219.95", "0.1", RoundHalfEven, "42220.0"},
+		{"42219.85", "0.1", RoundHalfEven, "42219.8"},
+		{"0.123456", "0.0001", RoundUp, "0.1235"},
+		{"7", "5", RoundDown, "5"},
+		{"7", "5", RoundHalfUp, "5"},
+		{"7.5", "5", RoundHalfUp, "10"},
+		{"-0.37", "0.25", RoundFloor, "-0.50"},
+		{"-0.37", "0.25", RoundCeil, "-0.25"},
+		{"1.5", "", RoundDown, "1.5"},
+		{"1e-5", "0.00001", RoundDown, "0.00001"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.RoundStep(tt.step, tt.mode); got != tt.want {
+			t.Errorf("%s.RoundStep(%s, %d) = %s, want %s", tt.in, tt.step, tt.mode, got, tt.want)
+		}
+	}
+	if !Decimal("0.3").IsMultipleOf("0.1") || Decimal("0.35").IsMultipleOf("0.1") {
+		t.Error("IsMultipleOf")
+	}
+}
+
+func TestDecimalDiv(t *testing.T) {
+	tests := []struct {
+		x, y   Decimal
+		places int32
+		mode   RoundingMode
+		want   Decimal
+	}{
+		{"1", "3", 4, RoundHalfEven, "0.3333"},
+		{"2", "3", 4, RoundHalfEven, "0.6667"},
+		{"2", "3", 4, RoundDown, "0.6666"},
+		{"-2", "3", 2, RoundFloor, "-0.67"},
+		{"-2", "3", 2, RoundCeil, "-0.66"},
+		{"10", "4", 0, RoundHalfEven, "2"},
+		{"10", "4", 0, RoundHalfUp, "3"},
+		{"1.5", "0.005", 1, RoundDown, "300.0"},
+		{"100", "0.25", 0, RoundDown, "400"},
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+	}
+	for _, tt := range tests {
+		if got := tt.x.Div(tt.y, tt.places, tt.mode); got != tt.want {
+			t.Errorf("%s.Div(%s, %d, %d) = %s, want %s", tt.x, tt.y, tt.places, tt.mode, got, tt.want)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("division by zero didn't panic")
+		}
+	}()
+	Decimal("1").Div("0", 2, RoundDown)
+}
+
+func TestDecimalTrim(t *testing.T) {
+	tests := []struct {
+		in, want Decimal
+	}{
+		{"1.2300", "1.23"},
+		{"2.0", "2"},
+		{"-0.500", "-0.5"},
+		{"0.000", "0"},
+		{"", "0"},
+		{"100", "100"},
+		{"1.5e2", "150"},
+	}
+	for _, tt := range tests {
+		if got := tt.in.Trim(); got != tt.want {
+			t.Errorf("%q.Trim() = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	if got := Decimal("0.1").Add("0.2"); got != "0.3" {
+		t.Errorf("Add = %s", got)
+	}
+	if got := Decimal("1").Sub("1.25"); got != "-0.25" {
+		t.Errorf("Sub = %s", got)
+	}
+	if got := Decimal("1.5").Mul("-0.2"); got != "-0.30" {
+		t.Errorf("Mul = %s", got)
+	}
+	if !Decimal("1.50").Equal("1.5") || !Decimal("-1").LessThan("") || !Decimal("1e-5").GreaterThan("0") {
+		t.Error("Cmp")
+	}
+}
@@ -39,10 +39,43 @@ type (
 	InstrumentState      string
 	DeliveryExerciseType string
 	CandleStickWsBarSize string
+	ConvertType          string
+	ConvertUnit          string
+	ConvertOpType        string
+	SelfTradeMode        string
+	QuickMarginType      string
+	TriggerPxType        string
+	TpOrdKind            string
+	ChaseType            string
+	AccountLevel         string
+	IsolatedMarginMode   string
+	IsolatedMarginType   string
+	RiskOffsetType       string
+	BorrowRepaySide      string
+	BorrowRepayType      string
+	LoanType             string
+	VIPLoanState         string
+	DebtType             string
+	OneClickRepayStatus  string
+	ConvertState         string
+	EasyConvertSource    string
+	EasyConvertStatus    string
+	GridAlgoOrderType    string
+	GridRunType          string
+	GridDirection        string
+	GridAlgoState        string
+	GridStopType         string
+	GridSubOrderType     string
+	GridTriggerAction    string
+	GridTriggerStrategy  string
+	SubPositionType      string
+	RfqState             string
+	QuoteState           string
 
 	Destination           int
 	BillType              uint8
-	BillSubType           uint8
+	BillSubType           uint16
+	PositionCloseType     uint8
 	FeeCategory           uint8
 	TransferType          uint8
 	AccountType           uint8
@@ -52,6 +85,7 @@ type (
 
 	JSONFloat64 float64
 	JSONInt64   int64
+	JSONBool    bool
 	JSONTime    time.Time
 
 	ClientError error
@@ -67,21 +101,25 @@ const (
 	PublicWsURL  = BaseURL("wss://ws.okx.com:8443/ws/v5/public")
 	PrivateWsURL = BaseURL("wss://ws.okx.com:8443/ws/v5/private")
 
-	AwsRestURL      = BaseURL("https://aws.okx.com")
-	AwsPublicWsURL  = BaseURL("wss://wsaws.okx.com:8443/ws/v5/public")
-	AwsPrivateWsURL = BaseURL("wss://wsaws.okx.com:8443/ws/v5/private")
+	AwsRestURL       = BaseURL("https://aws.okx.com")
+	AwsPublicWsURL   = BaseURL("wss://wsaws.okx.com:8443/ws/v5/public")
+	AwsPrivateWsURL  = BaseURL("wss://wsaws.okx.com:8443/ws/v5/private")
+	AwsBusinessWsURL = BaseURL("wss://wsaws.okx.com:8443/ws/v5/business")
 
-	DemoRestURL      = BaseURL("https://www.okx.com")
-	DemoPublicWsURL  = BaseURL("wss://wspap.okx.com:8443/ws/v5/public?brokerId=9999")
-	DemoPrivateWsURL = BaseURL("wss://wspap.okx.com:8443/ws/v5/private?brokerId=9999")
+	DemoRestURL       = BaseURL("https://www.okx.com")
+	DemoPublicWsURL   = BaseURL("wss://wspap.okx.com:8443/ws/v5/public?brokerId=9999")
+	DemoPrivateWsURL  = BaseURL("wss://wspap.okx.com:8443/ws/v5/private?brokerId=9999")
+	DemoBusinessWsURL = BaseURL("wss://wspap.okx.com:8443/ws/v5/business?brokerId=9999")
 
-	HandleWsURL = BaseURL("wss://ws.okx.com:8443/ws/v5/business")
+	HandleWsURL   = BaseURL("wss://ws.okx.com:8443/ws/v5/business")
+	BusinessWsURL = HandleWsURL
 
 	SpotInstrument    = InstrumentType("SPOT")
 	MarginInstrument  = InstrumentType("MARGIN")
 	SwapInstrument    = InstrumentType("SWAP")
 	FuturesInstrument = InstrumentType("FUTURES")
 	OptionsInstrument = InstrumentType("OPTION")
+	AnyInstrument     = InstrumentType("ANY") // the orders, positions and algo orders channels of every type
 
 	MarginCrossMode    = MarginMode("cross")
 	MarginIsolatedMode = MarginMode("isolated")
@@ -102,6 +140,13 @@ const (
 	BillSystemTokenConversionType = BillType(11)
 	BillStrategyTransferType      = BillType(12)
 
+	PositionClosePartially          = PositionCloseType(1)
+	PositionCloseAll                = PositionCloseType(2)
+	PositionCloseLiquidation        = PositionCloseType(3)
+	PositionClosePartialLiquidation = PositionCloseType(4)
+	PositionCloseADLPartially       = PositionCloseType(5) // the position was not fully closed by ADL
+	PositionCloseADL                = PositionCloseType(6)
+
 	BillBuySubType                              = BillSubType(1)
 	BillSellSubType                             = BillSubType(2)
 	BillOpenLongSubType                         = BillSubType(3)
@@ -168,6 +213,100 @@ const (
 	GreekInCoin    = GreekType("PA")
 	GreekInDollars = GreekType("PB")
 
+	AccountLevelSpot                 = AccountLevel("1")
+	AccountLevelSingleCurrencyMargin = AccountLevel("2") // spot and futures mode
+	AccountLevelMultiCurrencyMargin  = AccountLevel("3")
+	AccountLevelPortfolioMargin      = AccountLevel("4")
+	IsolatedMarginAutoTransfersCcy   = IsolatedMarginMode("auto_transfers_ccy") // new auto transfers, margin in the position currency and the quote currency
+	IsolatedMarginAutomatic          = IsolatedMarginMode("automatic")          // auto transfers
+	IsolatedMarginTypeMargin         = IsolatedMarginType("MARGIN")
+	IsolatedMarginTypeContracts      = IsolatedMarginType("CONTRACTS")
+	RiskOffsetSpotDerivativesUSDT    = RiskOffsetType("1")
+	RiskOffsetSpotDerivativesCrypto  = RiskOffsetType("2")
+	RiskOffsetDerivativesOnly        = RiskOffsetType("3")
+	RiskOffsetSpotDerivativesUSDC    = RiskOffsetType("4")
+
+	BorrowSide   = BorrowRepaySide("borrow")
+	RepaySide    = BorrowRepaySide("repay")
+	AutoBorrow   = BorrowRepayType("auto_borrow")
+	AutoRepay    = BorrowRepayType("auto_repay")
+	ManualBorrow = BorrowRepayType("manual_borrow")
+	ManualRepay  = BorrowRepayType("manual_repay")
+
+	VIPLoan    = LoanType("1")
+	MarketLoan = LoanType("2")
+
+	VIPLoanBorrowing    = VIPLoanState("1")
+	VIPLoanBorrowed     = VIPLoanState("2")
+	VIPLoanRepaying     = VIPLoanState("3")
+	VIPLoanRepaid       = VIPLoanState("4")
+	VIPLoanBorrowFailed = VIPLoanState("5")
+
+	DebtCross    = DebtType("cross")
+	DebtIsolated = DebtType("isolated")
+
+	OneClickRepayRunning = OneClickRepayStatus("running")
+	OneClickRepayFilled  = OneClickRepayStatus("filled")
+	OneClickRepayFailed  = OneClickRepayStatus("failed")
+
+	ConvertFullyFilled = ConvertState("fullyFilled")
+	ConvertRejected    = ConvertState("rejected")
+
+	EasyConvertFromTrading = EasyConvertSource("1")
+	EasyConvertFromFunding = EasyConvertSource("2")
+
+	EasyConvertRunning = EasyConvertStatus("running")
+	EasyConvertFilled  = EasyConvertStatus("filled")
+	EasyConvertFailed  = EasyConvertStatus("failed")
+
+	GridSpot     = GridAlgoOrderType("grid")
+	GridContract = GridAlgoOrderType("contract_grid")
+
+	GridArithmetic = GridRunType("1")
+	GridGeometric  = GridRunType("2")
+
+	GridLong    = GridDirection("long")
+	GridShort   = GridDirection("short")
+	GridNeutral = GridDirection("neutral")
+
+	GridStarting        = GridAlgoState("starting")
+	GridRunning         = GridAlgoState("running")
+	GridStopping        = GridAlgoState("stopping")
+	GridPendingSignal   = GridAlgoState("pending_signal")
+	GridNoClosePosition = GridAlgoState("no_close_position")
+	GridStopped         = GridAlgoState("stopped")
+
+	GridStopSellOrClose = GridStopType("1") // sell the base currency of spot grids, close the positions of contract grids
+	GridStopKeepAssets  = GridStopType("2") // keep the base currency or the positions
+
+	GridSubOrderLive   = GridSubOrderType("live")
+	GridSubOrderFilled = GridSubOrderType("filled")
+
+	GridTriggerStart = GridTriggerAction("start")
+	GridTriggerStop  = GridTriggerAction("stop")
+
+	GridTriggerInstant = GridTriggerStrategy("instant")
+	GridTriggerPrice   = GridTriggerStrategy("price")
+	GridTriggerRSI     = GridTriggerStrategy("rsi")
+
+	SubPositionLead = SubPositionType("lead")
+	SubPositionCopy = SubPositionType("copy")
+
+	RfqActive      = RfqState("active")
+	RfqCanceled    = RfqState("canceled")
+	RfqPendingFill = RfqState("pending_fill")
+	RfqFilled      = RfqState("filled")
+	RfqExpired     = RfqState("expired")
+	RfqTradedAway  = RfqState("traded_away")
+	RfqFailed      = RfqState("failed")
+
+	QuoteActive      = QuoteState("active")
+	QuoteCanceled    = QuoteState("canceled")
+	QuotePendingFill = QuoteState("pending_fill")
+	QuoteFilled      = QuoteState("filled")
+	QuoteExpired     = QuoteState("expired")
+	QuoteFailed      = QuoteState("failed")
+
 	Bar1m  = BarSize("1m")
 	Bar3m  = BarSize("3m")
 	Bar5m  = BarSize("5m")
@@ -205,16 +344,40 @@ const (
 	OrderFOK             = OrderType("fok")
 	OrderIOC             = OrderType("ioc")
 	OrderOptimalLimitIoc = OrderType("optimal_limit_ioc")
+	OrderMMP             = OrderType("mmp")
+	OrderMMPAndPostOnly  = OrderType("mmp_and_post_only")
+	OrderOpFOK           = OrderType("op_fok")
+	OrderELP             = OrderType("elp")
 
 	AlgoOrderConditional = AlgoOrderType("conditional")
 	AlgoOrderOCO         = AlgoOrderType("oco")
 	AlgoOrderTrigger     = AlgoOrderType("trigger")
 	AlgoOrderIceberg     = AlgoOrderType("iceberg")
 	AlgoOrderTwap        = AlgoOrderType("twap")
+	AlgoOrderMoveStop    = AlgoOrderType("move_order_stop")
+	AlgoOrderChase       = AlgoOrderType("chase")
+
+	ChaseDistance = ChaseType("distance")
+	ChaseRatio    = ChaseType("ratio")
 
 	QuantityBaseCcy  = QuantityType("base_ccy")
 	QuantityQuoteCcy = QuantityType("quote_ccy")
 
+	SelfTradeCancelMaker = SelfTradeMode("cancel_maker")
+	SelfTradeCancelTaker = SelfTradeMode("cancel_taker")
+	SelfTradeCancelBoth  = SelfTradeMode("cancel_both")
+
+	QuickMarginManual     = QuickMarginType("manual")
+	QuickMarginAutoBorrow = QuickMarginType("auto_borrow")
+	QuickMarginAutoRepay  = QuickMarginType("auto_repay")
+
+	TriggerPxLast  = TriggerPxType("last")
+	TriggerPxIndex = TriggerPxType("index")
+	TriggerPxMark  = TriggerPxType("mark")
+
+	TpOrdCondition = TpOrdKind("condition")
+	TpOrdLimit     = TpOrdKind("limit")
+
 	OrderTakerFlow = OrderFlowType("T")
 	OrderMakerFlow = OrderFlowType("M")
 
@@ -265,6 +428,7 @@ const (
 
 	APIKeyReadOnly = APIKeyAccess("read_only")
 	APIKeyTrade    = APIKeyAccess("trade")
+	APIKeyWithdraw = APIKeyAccess("withdraw")
 
 	OptionCall = OptionType("C")
 	OptionPut  = OptionType("P")
@@ -282,6 +446,15 @@ const (
 	Exercise   = DeliveryExerciseType("exercised")
 	ExpiredOtm = DeliveryExerciseType("expired_otm")
 
+	ConvertCoinToContract = ConvertType("1")
+	ConvertContractToCoin = ConvertType("2")
+
+	ConvertUnitCoin = ConvertUnit("coin")
+	ConvertUnitUsds = ConvertUnit("usds")
+
+	ConvertOpen  = ConvertOpType("open")
+	ConvertClose = ConvertOpType("close")
+
 	CandleStick1Y  = CandleStickWsBarSize("candle1Y")
 	CandleStick6M  = CandleStickWsBarSize("candle6M")
 	CandleStick3M  = CandleStickWsBarSize("candle3M")
@@ -305,7 +478,7 @@ const (
 func (t *JSONTime) String() string { return (time.Time)(*t).String() }
 
 func (t *JSONTime) UnmarshalJSON(s []byte) (err error) {
-	r := strings.Replace(string(s), `"`, ``, -1)
+	r := string(unquote(s))
 	if r == "" {
 		return
 	}
@@ -318,7 +491,7 @@ func (t *JSONTime) UnmarshalJSON(s []byte) (err error) {
 	return
 }
 func (t *JSONFloat64) UnmarshalJSON(s []byte) (err error) {
-	r := strings.Replace(string(s), `"`, ``, -1)
+	r := string(unquote(s))
 	if r == "" {
 		return
 	}
@@ -331,7 +504,7 @@ func (t *JSONFloat64) UnmarshalJSON(s []byte) (err error) {
 	return
 }
 func (t *JSONInt64) UnmarshalJSON(s []byte) (err error) {
-	r := strings.Replace(string(s), `"`, ``, -1)
+	r := string(unquote(s))
 	if r == "" {
 		return
 	}
@@ -343,6 +516,19 @@ func (t *JSONInt64) UnmarshalJSON(s []byte) (err error) {
 	*(*int64)(t) = q
 	return
 }
+func (t *JSONBool) UnmarshalJSON(s []byte) (err error) {
+	r := string(unquote(s))
+	if r == "" {
+		return
+	}
+
+	q, err := strconv.ParseBool(r)
+	if err != nil {
+		return err
+	}
+	*(*bool)(t) = q
+	return
+}
 func (t *WithdrawalState) UnmarshalJSON(s []byte) (err error) {
 	r := strings.Replace(string(s), `"`, ``, -1)
 	if r == "" {
@@ -375,6 +561,19 @@ func (t *BillSubType) UnmarshalJSON(s []byte) (err error) {
 		return
 	}
 
+	q, err := strconv.ParseUint(r, 10, 16)
+	if err != nil {
+		return err
+	}
+	*(*uint16)(t) = uint16(q)
+	return
+}
+func (t *PositionCloseType) UnmarshalJSON(s []byte) (err error) {
+	r := strings.Replace(string(s), `"`, ``, -1)
+	if r == "" {
+		return
+	}
+
 	q, err := strconv.ParseUint(r, 10, 8)
 	if err != nil {
 		return err
@@ -461,6 +660,14 @@ func (t BarSize) Duration() time.Duration {
 	return time.Minute
 }
 
+// unquote strips the quotes around a json string without copying, it's on the path of every pushed number
+func unquote(s []byte) []byte {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
 func S2M(i interface{}) map[string]string {
 	m := make(map[string]string)
 	j, _ := json.Marshal(i)
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"bytes"
 	"encoding/json"
 	"github.com/pefish/go-okx"
 )
@@ -42,6 +43,12 @@ type (
 		Code  string `json:"code"`
 		Msg   string `json:"msg"`
 	}
+	Notice struct {
+		Event  string `json:"event"`
+		Code   string `json:"code"`
+		Msg    string `json:"msg"`
+		ConnID string `json:"connId"`
+	}
 	Subscribe struct {
 		Event string    `json:"event"`
 		Arg   *Argument `json:"arg"`
@@ -65,3 +72,32 @@ func (a *Argument) UnmarshalJSON(buf []byte) error {
 
 	return nil
 }
+
+var (
+	pushPrefix = []byte(`{"arg":{`)
+	channelKey = []byte(`"channel":"`)
+)
+
+// PeekChannel returns the channel of a data push by scanning its leading arg object, without decoding the message.
+// ok is false for anything that is not shaped like a push, such as events.
+func PeekChannel(data []byte) (string, bool) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	if !bytes.HasPrefix(data, pushPrefix) {
+		return "", false
+	}
+	end := bytes.IndexByte(data, '}')
+	if end < 0 {
+		return "", false
+	}
+	arg := data[len(pushPrefix):end]
+	i := bytes.Index(arg, channelKey)
+	if i < 0 {
+		return "", false
+	}
+	v := arg[i+len(channelKey):]
+	j := bytes.IndexByte(v, '"')
+	if j < 0 {
+		return "", false
+	}
+	return string(v[:j]), true
+}
@@ -3,7 +3,10 @@ package private
 import (
 	"github.com/pefish/go-okx/events"
 	"github.com/pefish/go-okx/models/account"
+	"github.com/pefish/go-okx/models/copytrading"
+	"github.com/pefish/go-okx/models/rfq"
 	"github.com/pefish/go-okx/models/trade"
+	"github.com/pefish/go-okx/models/tradingbot"
 )
 
 type (
@@ -23,4 +26,41 @@ type (
 		Arg    *events.Argument `json:"arg"`
 		Orders []*trade.Order   `json:"data"`
 	}
+	AlgoOrder struct {
+		Arg        *events.Argument   `json:"arg"`
+		AlgoOrders []*trade.AlgoOrder `json:"data"`
+	}
+	AdvanceAlgoOrder struct {
+		Arg        *events.Argument   `json:"arg"`
+		AlgoOrders []*trade.AlgoOrder `json:"data"`
+	}
+	Fill struct {
+		Arg   *events.Argument `json:"arg"`
+		Fills []*trade.Fill    `json:"data"`
+	}
+	// GridOrder is an event of grid-orders-spot or grid-orders-contract, Arg tells which
+	GridOrder struct {
+		Arg            *events.Argument            `json:"arg"`
+		GridAlgoOrders []*tradingbot.GridAlgoOrder `json:"data"`
+	}
+	GridPosition struct {
+		Arg           *events.Argument           `json:"arg"`
+		GridPositions []*tradingbot.GridPosition `json:"data"`
+	}
+	LeadNotification struct {
+		Arg               *events.Argument                `json:"arg"`
+		LeadNotifications []*copytrading.LeadNotification `json:"data"`
+	}
+	Rfq struct {
+		Arg  *events.Argument `json:"arg"`
+		Rfqs []*rfq.Rfq       `json:"data"`
+	}
+	Quote struct {
+		Arg    *events.Argument `json:"arg"`
+		Quotes []*rfq.Quote     `json:"data"`
+	}
+	StrucBlockTrade struct {
+		Arg         *events.Argument  `json:"arg"`
+		BlockTrades []*rfq.BlockTrade `json:"data"`
+	}
 )
@@ -4,6 +4,7 @@ import (
 	"github.com/pefish/go-okx/events"
 	"github.com/pefish/go-okx/models/market"
 	"github.com/pefish/go-okx/models/publicdata"
+	"github.com/pefish/go-okx/models/rfq"
 )
 
 type (
@@ -69,4 +70,8 @@ type (
 		Arg               *events.Argument               `json:"arg"`
 		LiquidationOrders []*publicdata.LiquidationOrder `json:"data"`
 	}
+	PublicBlockTrades struct {
+		Arg    *events.Argument            `json:"arg"`
+		Trades []*rfq.InstrumentBlockTrade `json:"data"`
+	}
 )
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/setup"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	dryRun := flag.Bool("dry-run", true, "print the changes without applying them")
+	flag.Parse()
+
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	r := setup.NewReconciler(client.Rest)
+	r.DryRun = *dryRun
+	plan, err := r.Apply(setup.Desired{
+		AcctLv:  okex.AccountLevelSingleCurrencyMargin,
+		PosMode: okex.PositionLongShortMode,
+		Leverages: []setup.Leverage{
+			{InstID: "BTC-USDT-SWAP", MgnMode: okex.MarginCrossMode, Lever: 5},
+			{InstID: "ETH-USDT-SWAP", MgnMode: okex.MarginIsolatedMode, Lever: 3},
+		},
+	})
+	if plan != nil {
+		if plan.Empty() {
+			fmt.Println("up to date")
+		}
+		fmt.Println(plan)
+	}
+	return err
+}
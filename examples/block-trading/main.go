@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/private"
+	models "github.com/pefish/go-okx/models/rfq"
+	"github.com/pefish/go-okx/requests/rest/rfq"
+	ws_private "github.com/pefish/go-okx/requests/ws/private"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	cpRes, err := client.Rest.RFQ.GetCounterparties()
+	if err != nil {
+		return err
+	}
+	if cpRes.Code != 0 {
+		return errors.Errorf("GetCounterparties failed. err: %s, code: %d", cpRes.Msg, cpRes.Code)
+	}
+	if len(cpRes.Counterparties) == 0 {
+		return errors.New("no counterparty")
+	}
+	counterparties := make([]string, len(cpRes.Counterparties))
+	for i, cp := range cpRes.Counterparties {
+		counterparties[i] = cp.TraderCode
+	}
+
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	// execute the first quote of the RFQ, the block trade closes the example
+	quotes := make(chan *models.Quote, 16)
+	client.Ws.Private.OnQuote(func(e *private.Quote) {
+		for _, q := range e.Quotes {
+			if q.State == okex.QuoteActive {
+				select {
+				case quotes <- q:
+				default:
+				}
+			}
+		}
+	})
+	client.Ws.Private.OnStrucBlockTrade(func(e *private.StrucBlockTrade) {
+		for _, t := range e.BlockTrades {
+			for _, l := range t.Legs {
+				log.Printf("block trade %s: %s %s %s at %s", t.BlockTdID, l.Side, l.Sz, l.InstID, l.Px)
+			}
+		}
+		cancel()
+	})
+	err = client.Ws.Private.Quote([]ws_private.Quote{{}})
+	if err != nil {
+		return err
+	}
+	err = client.Ws.Private.StrucBlockTrade([]ws_private.StrucBlockTrade{{}})
+	if err != nil {
+		return err
+	}
+
+	// a BTC call spread
+	createRes, err := client.Rest.RFQ.CreateRfq(rfq.CreateRfq{
+		Counterparties: counterparties,
+		Anonymous:      true,
+		Legs: []*rfq.Leg{
+			{InstID: "BTC-USD-241227-60000-C", Sz: okex.MustDecimal("25"), Side: okex.OrderBuy},
+			{InstID: "BTC-USD-241227-70000-C", Sz: okex.MustDecimal("25"), Side: okex.OrderSell},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if createRes.Code != 0 || len(createRes.Rfqs) == 0 {
+		return errors.Errorf("CreateRfq failed. err: %s, code: %d", createRes.Msg, createRes.Code)
+	}
+	rfqID := createRes.Rfqs[0].RfqID
+
+	for {
+		select {
+		case q := <-quotes:
+			if q.RfqID != rfqID {
+				continue
+			}
+			res, err := client.Rest.RFQ.ExecuteQuote(rfq.ExecuteQuote{RfqID: rfqID, QuoteID: q.QuoteID})
+			if err != nil {
+				return err
+			}
+			if res.Code != 0 {
+				return errors.Errorf("ExecuteQuote failed. err: %s, code: %d", res.Msg, res.Code)
+			}
+			<-ctx.Done()
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
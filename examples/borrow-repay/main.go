@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/requests/rest/account"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	limitsRes, err := client.Rest.Account.GetInterestLimits(account.GetInterestLimits{
+		Type: okex.MarketLoan,
+		Ccy:  "USDT",
+	})
+	if err != nil {
+		return err
+	}
+	if limitsRes.Code != 0 || len(limitsRes.InterestLimits) == 0 {
+		return errors.Errorf("GetInterestLimits failed. err: %s, code: %d", limitsRes.Msg, limitsRes.Code)
+	}
+	for _, r := range limitsRes.InterestLimits[0].Records {
+		fmt.Printf("ccy: %s, daily rate: %s, available: %s\n", r.Ccy, r.Rate, r.AvailLoan)
+	}
+
+	amt := okex.MustDecimal("100")
+	for _, side := range []okex.BorrowRepaySide{okex.BorrowSide, okex.RepaySide} {
+		res, err := client.Rest.Account.SpotBorrowRepay(account.SpotBorrowRepay{
+			Ccy:  "USDT",
+			Side: side,
+			Amt:  amt,
+		})
+		if err != nil {
+			return err
+		}
+		if res.Code != 0 {
+			return errors.Errorf("SpotBorrowRepay failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+	}
+
+	historyRes, err := client.Rest.Account.GetSpotBorrowRepayHistory(account.GetSpotBorrowRepayHistory{Ccy: "USDT"})
+	if err != nil {
+		return err
+	}
+	if historyRes.Code != 0 {
+		return errors.Errorf("GetSpotBorrowRepayHistory failed. err: %s, code: %d", historyRes.Msg, historyRes.Code)
+	}
+	for _, h := range historyRes.SpotBorrowRepayHistories {
+		fmt.Printf("type: %s, amt: %s, borrowed: %s, at: %s\n", h.Type, h.Amt, h.AccBorrowed, h.TS.String())
+	}
+
+	return nil
+}
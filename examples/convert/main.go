@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/requests/rest/convert"
+	"github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+// easyConvertBatch is the maximum number of currencies of an easy convert
+const easyConvertBatch = 5
+
+type credentials struct {
+	apiKey, secretKey, passphrase string
+}
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	// the keys of the sub-accounts, each one is swept with its own client
+	accounts := []credentials{
+		{"SUB1-API-KEY", "SUB1-SECRET-KEY", "SUB1-PASS-PHRASE"},
+		{"SUB2-API-KEY", "SUB2-SECRET-KEY", "SUB2-PASS-PHRASE"},
+	}
+	for _, a := range accounts {
+		client, err := api.NewClient(context.Background(), &i_logger.DefaultLogger, a.apiKey, a.secretKey, a.passphrase, okex.DemoServer)
+		if err != nil {
+			return err
+		}
+		if err := sweep(client, "USDT"); err != nil {
+			log.Printf("%s: %+v", a.apiKey, err)
+		}
+	}
+
+	// convert 100 USDT into BTC at a quoted price
+	client, err := api.NewClient(context.Background(), &i_logger.DefaultLogger, "YOUR-API-KEY", "YOUR-SECRET-KEY", "YOUR-PASS-PHRASE", okex.DemoServer)
+	if err != nil {
+		return err
+	}
+	quoteRes, err := client.Rest.Convert.EstimateQuote(convert.EstimateQuote{
+		BaseCcy:  "BTC",
+		QuoteCcy: "USDT",
+		Side:     okex.OrderBuy,
+		RfqSz:    okex.MustDecimal("100"),
+		RfqSzCcy: "USDT",
+	})
+	if err != nil {
+		return err
+	}
+	if quoteRes.Code != 0 || len(quoteRes.Quotes) == 0 {
+		return errors.Errorf("EstimateQuote failed. err: %s, code: %d", quoteRes.Msg, quoteRes.Code)
+	}
+	q := quoteRes.Quotes[0]
+	log.Printf("quote %s: %s BTC for %s USDT, expires at %s", q.QuoteID, q.BaseSz, q.QuoteSz, q.ExpiresAt().Format(time.RFC3339Nano))
+	if q.Expired(500 * time.Millisecond) {
+		return errors.New("quote expired")
+	}
+	tradeRes, err := client.Rest.Convert.Trade(convert.Trade{
+		QuoteID:  q.QuoteID,
+		BaseCcy:  q.BaseCcy,
+		QuoteCcy: q.QuoteCcy,
+		Side:     q.Side,
+		Sz:       q.RfqSz,
+		SzCcy:    q.RfqSzCcy,
+	})
+	if err != nil {
+		return err
+	}
+	if tradeRes.Code != 0 || len(tradeRes.Trades) == 0 {
+		return errors.Errorf("Trade failed. err: %s, code: %d", tradeRes.Msg, tradeRes.Code)
+	}
+	log.Printf("trade %s: %s at %s", tradeRes.Trades[0].TradeID, tradeRes.Trades[0].State, tradeRes.Trades[0].FillPx)
+	return nil
+}
+
+// sweep converts every small balance of the trading account into toCcy
+func sweep(client *api.Client, toCcy string) error {
+	listRes, err := client.Rest.Trade.GetEasyConvertCurrencies(trade.GetEasyConvertCurrencies{Source: okex.EasyConvertFromTrading})
+	if err != nil {
+		return err
+	}
+	if listRes.Code != 0 {
+		return errors.Errorf("GetEasyConvertCurrencies failed. err: %s, code: %d", listRes.Msg, listRes.Code)
+	}
+	from := make([]string, 0)
+	for _, l := range listRes.EasyConvertCurrencies {
+		for _, b := range l.FromData {
+			if b.FromCcy != toCcy {
+				from = append(from, b.FromCcy)
+			}
+		}
+	}
+	for i := 0; i < len(from); i += easyConvertBatch {
+		res, err := client.Rest.Trade.EasyConvert(trade.EasyConvert{
+			FromCcy: from[i:min(i+easyConvertBatch, len(from))],
+			ToCcy:   toCcy,
+			Source:  okex.EasyConvertFromTrading,
+		})
+		if err != nil {
+			return err
+		}
+		if res.Code != 0 {
+			return errors.Errorf("EasyConvert failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+		for _, c := range res.EasyConverts {
+			log.Printf("%s -> %s: %s", c.FromCcy, c.ToCcy, c.Status)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/private"
+	"github.com/pefish/go-okx/requests/rest/copytrading"
+	ws_private "github.com/pefish/go-okx/requests/ws/private"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	client.Ws.Private.OnLeadNotification(func(e *private.LeadNotification) {
+		for _, n := range e.LeadNotifications {
+			log.Printf("%s not lead traded: %s", n.InstID, n.InfoType)
+		}
+	})
+	err = client.Ws.Private.LeadNotification([]ws_private.LeadNotification{{InstType: okex.SwapInstrument}})
+	if err != nil {
+		return err
+	}
+
+	// lead BTC and ETH perpetuals only
+	setRes, err := client.Rest.CopyTrading.SetInstruments(copytrading.SetInstruments{
+		InstType: okex.SwapInstrument,
+		InstID:   []string{"BTC-USDT-SWAP", "ETH-USDT-SWAP"},
+	})
+	if err != nil {
+		return err
+	}
+	if setRes.Code != 0 {
+		return errors.Errorf("SetInstruments failed. err: %s, code: %d", setRes.Msg, setRes.Code)
+	}
+
+	// a stop loss 5% away from the entry of every lead position without one
+	posRes, err := client.Rest.CopyTrading.GetSubPositions(copytrading.GetSubPositions{InstType: okex.SwapInstrument}, false)
+	if err != nil {
+		return err
+	}
+	if posRes.Code != 0 {
+		return errors.Errorf("GetSubPositions failed. err: %s, code: %d", posRes.Msg, posRes.Code)
+	}
+	for _, p := range posRes.SubPositions {
+		if !p.SlTriggerPx.IsZero() {
+			continue
+		}
+		ratio := okex.MustDecimal("0.95")
+		if p.PosSide == okex.PositionShortSide {
+			ratio = okex.MustDecimal("1.05")
+		}
+		res, err := client.Rest.CopyTrading.PlaceSubPositionTpSl(copytrading.PlaceSubPositionTpSl{
+			InstType:    okex.SwapInstrument,
+			SubPosID:    p.SubPosID,
+			SlTriggerPx: p.OpenAvgPx.Mul(ratio).Round(1, okex.RoundHalfEven),
+			SlOrdPx:     okex.MustDecimal("-1"),
+		})
+		if err != nil {
+			return err
+		}
+		if res.Code != 0 {
+			return errors.Errorf("PlaceSubPositionTpSl failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+	}
+
+	sharingRes, err := client.Rest.CopyTrading.GetTotalProfitSharing(copytrading.GetTotalProfitSharing{InstType: okex.SwapInstrument})
+	if err != nil {
+		return err
+	}
+	if sharingRes.Code != 0 {
+		return errors.Errorf("GetTotalProfitSharing failed. err: %s, code: %d", sharingRes.Msg, sharingRes.Code)
+	}
+	for _, s := range sharingRes.TotalProfitSharings {
+		log.Printf("profit shared: %s %s", s.TotalProfitSharingAmt, s.Ccy)
+	}
+
+	<-ctx.Done()
+	return nil
+}
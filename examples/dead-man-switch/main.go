@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/deadman"
+	"github.com/pefish/go-okx/events/private"
+	requests "github.com/pefish/go-okx/requests/ws/private"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.Ws.Private.OnOrder(func(o *private.Order) {
+		for _, order := range o.Orders {
+			log.Printf("order: %s, state: %s", order.OrdID, order.State)
+		}
+	})
+	err = client.Ws.Private.Order([]requests.Order{{InstType: okex.AnyInstrument}})
+	if err != nil {
+		return err
+	}
+	if err := client.Ws.WaitForAuthorization(10 * time.Second); err != nil {
+		return err
+	}
+
+	// every pending order is cancelled 30 seconds after the process or its private socket died
+	s, err := deadman.NewSwitch(client.Rest.Trade, 30*time.Second)
+	if err != nil {
+		return err
+	}
+	s.WatchWs(client.Ws, 5*time.Second)
+	s.OnMiss(func(m *deadman.Miss) {
+		log.Printf("heartbeat missed: %v, last: %s, expired: %t", m.Err, m.LastBeat, m.Expired)
+	})
+	// the private socket may not have been read from yet
+	for {
+		err = s.Start(ctx)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("armed, orders cancelled at %s without a heartbeat", s.TriggerTime())
+
+	<-ctx.Done()
+	// a clean shutdown keeps the orders on the book
+	return s.Stop(true)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"log"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/private"
+	models "github.com/pefish/go-okx/models/trade"
+	"github.com/pefish/go-okx/requests/rest/trade"
+	ws_private "github.com/pefish/go-okx/requests/ws/private"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+// handle is fed by the rest history and the fills channel alike
+func handle(f *models.Fill) {
+	log.Printf("%s %s %s %s@%s maker: %t, fee: %s %s, rebate: %s, pnl: %s", f.Time().Format(time.RFC3339), f.InstID, f.Side, f.FillSz, f.FillPx, f.IsMaker(), f.FeePaid(), f.FeeCcy, f.Rebate(), f.FillPnl)
+}
+
+func do() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// the swap fills of the last week, page by page
+	req := trade.FillsHistory{
+		InstType: okex.SwapInstrument,
+		Begin:    time.Now().Add(-7 * 24 * time.Hour).UnixMilli(),
+		Limit:    100,
+	}
+	for {
+		res, err := client.Rest.Trade.GetFillsHistory(req)
+		if err != nil {
+			return err
+		}
+		if res.Code != 0 {
+			return errors.Errorf("GetFillsHistory failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+		for _, f := range res.Fills {
+			handle(f)
+		}
+		if len(res.Fills) < int(req.Limit) {
+			break
+		}
+		req.After = res.Fills[len(res.Fills)-1].BillID
+	}
+
+	// then the new ones as they happen, VIP5 and above
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	client.Ws.Private.OnFill(func(e *private.Fill) {
+		for _, f := range e.Fills {
+			handle(f)
+		}
+	})
+	err = client.Ws.Private.Fill([]ws_private.Fill{{}})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/flatten"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	f := flatten.NewFlattener(client.Rest)
+	f.OnProgress(func(r *flatten.Result) {
+		if r.Err != nil {
+			log.Printf("[Failed]\t%s %s %s: %v", r.Action, r.InstID, r.ID, r.Err)
+			return
+		}
+		log.Printf("[Done]\t%s %s %s", r.Action, r.InstID, r.ID)
+	})
+
+	// every swap, use flatten.Scope{} for the whole account or flatten.Scope{InstID: "BTC-USDT-SWAP"} for a single instrument
+	report := f.Flatten(flatten.Scope{InstType: okex.SwapInstrument})
+	return report.Err()
+}
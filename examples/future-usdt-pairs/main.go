@@ -54,11 +54,11 @@ func do() error {
 产品id: %s, 
 上线时间: %s, 
 最大杠杆倍数: %f, 
-下单价格精度: %f, 
-下单数量精度: %f, 
-最小下单数量: %f, 
-市价单最大委托张数: %f
-每张面额：%f
+下单价格精度: %s, 
+下单数量精度: %s, 
+最小下单数量: %s, 
+市价单最大委托张数: %s
+每张面额：%s
 Uly: %s
 InstFamily: %s
 `,
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/private"
+	"github.com/pefish/go-okx/requests/rest/tradingbot"
+	ws_private "github.com/pefish/go-okx/requests/ws/private"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	symbol := "BTC-USDT-SWAP"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	client.Ws.Private.OnGridOrderContract(func(e *private.GridOrder) {
+		for _, g := range e.GridAlgoOrders {
+			log.Printf("grid %s: %s, pnl: %s, grid profit: %s, liqPx: %s", g.AlgoID, g.State, g.TotalPnl, g.GridProfit, g.LiqPx)
+		}
+	})
+	err = client.Ws.Private.GridOrderContract([]ws_private.GridOrder{{InstType: okex.SwapInstrument}})
+	if err != nil {
+		return err
+	}
+
+	// start from the parameters suggested by the back testing of the last 7 days
+	aiRes, err := client.Rest.TradingBot.GetGridAIParam(tradingbot.GridAIParam{
+		AlgoOrdType: okex.GridContract,
+		InstID:      symbol,
+		Direction:   okex.GridNeutral,
+		Duration:    "7D",
+	})
+	if err != nil {
+		return err
+	}
+	if aiRes.Code != 0 || len(aiRes.GridAIParams) == 0 {
+		return errors.Errorf("GetGridAIParam failed. err: %s, code: %d", aiRes.Msg, aiRes.Code)
+	}
+	ai := aiRes.GridAIParams[0]
+	grid := tradingbot.PlaceGridOrder{
+		InstID:      symbol,
+		AlgoOrdType: okex.GridContract,
+		MaxPx:       ai.MaxPx,
+		MinPx:       ai.MinPx,
+		GridNum:     int64(ai.GridNum),
+		RunType:     ai.RunType,
+		Direction:   okex.GridNeutral,
+		Lever:       okex.MustDecimal("3"),
+	}
+
+	minRes, err := client.Rest.TradingBot.ComputeGridMinInvestment(tradingbot.GridMinInvestment{
+		InstID:      grid.InstID,
+		AlgoOrdType: grid.AlgoOrdType,
+		MaxPx:       grid.MaxPx,
+		MinPx:       grid.MinPx,
+		GridNum:     grid.GridNum,
+		RunType:     grid.RunType,
+		Direction:   grid.Direction,
+		Lever:       grid.Lever,
+	})
+	if err != nil {
+		return err
+	}
+	if minRes.Code != 0 || len(minRes.GridMinInvestments) == 0 || len(minRes.GridMinInvestments[0].MinInvestmentData) == 0 {
+		return errors.Errorf("ComputeGridMinInvestment failed. err: %s, code: %d", minRes.Msg, minRes.Code)
+	}
+	grid.Sz = minRes.GridMinInvestments[0].MinInvestmentData[0].Amt
+
+	placeRes, err := client.Rest.TradingBot.PlaceGridOrder(grid)
+	if err != nil {
+		return err
+	}
+	if placeRes.Code != 0 || len(placeRes.PlaceGridOrders) == 0 {
+		return errors.Errorf("PlaceGridOrder failed. err: %s, code: %d", placeRes.Msg, placeRes.Code)
+	}
+	algoID := placeRes.PlaceGridOrders[0].AlgoID
+	log.Printf("grid %s started with %s USDT", algoID, grid.Sz)
+
+	subRes, err := client.Rest.TradingBot.GetGridSubOrders(tradingbot.GridSubOrders{
+		AlgoOrdType: okex.GridContract,
+		AlgoID:      algoID,
+		Type:        okex.GridSubOrderLive,
+	})
+	if err != nil {
+		return err
+	}
+	if subRes.Code != 0 {
+		return errors.Errorf("GetGridSubOrders failed. err: %s, code: %d", subRes.Msg, subRes.Code)
+	}
+	for _, o := range subRes.GridSubOrders {
+		log.Printf("%s %s@%s", o.Side, o.Sz, o.Px)
+	}
+
+	<-ctx.Done()
+
+	// stop the grid and close its position
+	stopRes, err := client.Rest.TradingBot.StopGridOrder([]tradingbot.StopGridOrder{{
+		AlgoID:      algoID,
+		InstID:      symbol,
+		AlgoOrdType: okex.GridContract,
+		StopType:    okex.GridStopSellOrClose,
+	}})
+	if err != nil {
+		return err
+	}
+	if stopRes.Code != 0 {
+		return errors.Errorf("StopGridOrder failed. err: %s, code: %d", stopRes.Msg, stopRes.Code)
+	}
+	return nil
+}
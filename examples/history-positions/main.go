@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	requests "github.com/pefish/go-okx/requests/rest/account"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.NormalServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// realized pnl of the swaps closed during the last 30 days, by instrument
+	end := time.Now()
+	it := client.Rest.Account.HistoryPositions(requests.GetHistoryPositions{
+		InstType: okex.SwapInstrument,
+	}, end.AddDate(0, 0, -30), end)
+	pnl := make(map[string]okex.Decimal)
+	for it.Next() {
+		p := it.Position()
+		pnl[p.InstID] = pnl[p.InstID].Add(p.RealizedPnl)
+		if p.Type == okex.PositionCloseLiquidation || p.Type == okex.PositionClosePartialLiquidation {
+			fmt.Printf("liquidated: %s, at: %s, penalty: %s\n", p.InstID, p.UTime.String(), p.LiqPenalty)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	for instID, v := range pnl {
+		fmt.Printf("symbol: %s, realized pnl: %s\n", instID, v)
+	}
+
+	return nil
+}
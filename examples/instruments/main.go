@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/instruments"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.NormalServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	registry := instruments.NewRegistry(client.Rest, client.Ws)
+	registry.OnEvent(func(e *instruments.Event) {
+		switch e.Type {
+		case instruments.Listed, instruments.Delisted:
+			log.Printf("[%s]\t%s", e.Type, e.Instrument.InstID)
+		case instruments.StateChanged:
+			log.Printf("[%s]\t%s: %s -> %s", e.Type, e.Instrument.InstID, e.Previous.State, e.Instrument.State)
+		case instruments.TickSizeChanged:
+			log.Printf("[%s]\t%s: %s -> %s", e.Type, e.Instrument.InstID, e.Previous.TickSz, e.Instrument.TickSz)
+		case instruments.LotSizeChanged:
+			log.Printf("[%s]\t%s: %s -> %s", e.Type, e.Instrument.InstID, e.Previous.LotSz, e.Instrument.LotSz)
+		}
+	})
+	err = registry.Load(okex.SwapInstrument, okex.FuturesInstrument)
+	if err != nil {
+		return err
+	}
+
+	// live USDT margined swaps
+	for _, i := range registry.BySettleCcy("USDT") {
+		if i.InstType != okex.SwapInstrument || i.State != okex.InstrumentLive {
+			continue
+		}
+		log.Printf("%s tickSz: %s, lotSz: %s, minSz: %s, ctVal: %s", i.InstID, i.TickSz, i.LotSz, i.MinSz, i.CtVal)
+	}
+
+	err = registry.Watch()
+	if err != nil {
+		return err
+	}
+
+	select {}
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/instruments"
+	"github.com/pefish/go-okx/requests/rest/account"
+	"github.com/pefish/go-okx/requests/rest/public"
+	"github.com/pefish/go-okx/risk"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	registry := instruments.NewRegistry(client.Rest, nil)
+	if err := registry.Load(okex.SwapInstrument); err != nil {
+		return err
+	}
+	tiers, err := client.Rest.PublicData.GetPositionTiers(public.GetPositionTiers{
+		InstType:   okex.SwapInstrument,
+		TdMode:     okex.TradeIsolatedMode,
+		InstFamily: "BTC-USDT",
+	})
+	if err != nil {
+		return err
+	}
+	if tiers.Code != 0 {
+		return errors.Errorf("GetPositionTiers failed. err: %s, code: %d", tiers.Msg, tiers.Code)
+	}
+	fees, err := client.Rest.Account.GetFeeRates(account.GetFeeRates{InstType: okex.SwapInstrument, InstFamily: "BTC-USDT"})
+	if err != nil {
+		return err
+	}
+	if fees.Code != 0 {
+		return errors.Errorf("GetFeeRates failed. err: %s, code: %d", fees.Msg, fees.Code)
+	}
+	c := risk.NewCalculator()
+	c.AddTiers(tiers.PositionTiers)
+	c.SetFees(fees.Fees)
+
+	// the real positions, compared with what OKX computes
+	positions, err := client.Rest.Account.GetPositions(account.GetPositions{InstType: okex.SwapInstrument})
+	if err != nil {
+		return err
+	}
+	if positions.Code != 0 {
+		return errors.Errorf("GetPositions failed. err: %s, code: %d", positions.Msg, positions.Code)
+	}
+	for _, p := range positions.Positions {
+		inst, ok := registry.Get(p.InstID)
+		if !ok || p.MgnMode != okex.MarginIsolatedMode {
+			continue
+		}
+		m, err := c.Isolated(risk.FromPosition(p, inst))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s liqPx %s (okx %f), mgnRatio %s (okx %f)\n", p.InstID, m.LiqPx, p.LiqPx, m.MgnRatio, p.MgnRatio)
+	}
+
+	// a long that doesn't exist yet
+	inst, ok := registry.Get("BTC-USDT-SWAP")
+	if !ok {
+		return errors.New("BTC-USDT-SWAP not found")
+	}
+	m, err := c.Isolated(&risk.Position{
+		Instrument: inst,
+		Pos:        okex.MustDecimal("100"),
+		PosSide:    okex.PositionLongSide,
+		AvgPx:      okex.MustDecimal("60000"),
+		MarkPx:     okex.MustDecimal("60000"),
+		Lever:      okex.MustDecimal("20"),
+		MgnMode:    okex.MarginIsolatedMode,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("hypothetical imr %s mmr %s liqPx %s\n", m.Imr, m.Mmr, m.LiqPx)
+	return nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/normalizer"
+	"github.com/pefish/go-okx/requests/rest/public"
+	"github.com/pefish/go-okx/requests/ws/trade"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.NormalServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Rest.PublicData.GetInstruments(public.GetInstruments{
+		InstType: okex.SwapInstrument,
+	})
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.Errorf("GetInstruments failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	n := normalizer.NewNormalizer(normalizer.NewInstruments(res.Instruments))
+	n.PxMode = okex.RoundDown // never buy above the price asked
+
+	order := trade.PlaceOrder{
+		InstID:  "BTC-USDT-SWAP",
+		TdMode:  okex.TradeCrossMode,
+		Side:    okex.OrderBuy,
+		OrdType: okex.OrderLimit,
+		Px:      okex.MustDecimal("30000.123"),
+		Sz:      okex.MustDecimal("1.2345"),
+	}
+	err = n.WsPlaceOrder(&order)
+	if err != nil {
+		return err
+	}
+	log.Printf("px: %s, sz: %s", order.Px, order.Sz)
+
+	return client.Ws.Trade.PlaceOrder(order)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/orderbook"
+	requests "github.com/pefish/go-okx/requests/ws/public"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.NormalServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	books := orderbook.NewBooks(client.Ws)
+	books.OnResync(func(instID string, err error) {
+		log.Printf("[Resync]\t%s: %v", instID, err)
+	})
+	book, err := books.Subscribe(requests.OrderBook{
+		InstID:  "BTC-USDT-SWAP",
+		Channel: "books",
+	})
+	if err != nil {
+		return err
+	}
+	book.OnChange(func(b *orderbook.Book) {
+		bid, _ := b.BestBid()
+		ask, _ := b.BestAsk()
+		if bid == nil || ask == nil {
+			return
+		}
+		log.Printf("%s bid: %f x %f, ask: %f x %f", b.InstID, bid.DepthPrice, bid.Size, ask.DepthPrice, ask.Size)
+	})
+
+	select {}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	symbol := "BTC-USDT-SWAP"
+
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// a post only buy with a take profit and a stop loss attached, dropped if not accepted within 5 seconds
+	order := trade.PlaceOrder{
+		InstID:   symbol,
+		TdMode:   okex.TradeCrossMode,
+		Side:     okex.OrderBuy,
+		OrdType:  okex.OrderPostOnly,
+		ClOrdID:  "entry1",
+		Px:       okex.MustDecimal("60000"),
+		Sz:       okex.MustDecimal("1"),
+		StpMode:  okex.SelfTradeCancelMaker,
+		BanAmend: true,
+		AttachAlgoOrds: []*trade.AttachAlgoOrder{
+			{
+				AttachAlgoClOrdID: "entry1tpsl",
+				TpTriggerPx:       okex.MustDecimal("66000"),
+				TpOrdPx:           okex.MustDecimal("-1"),
+				TpTriggerPxType:   okex.TriggerPxLast,
+				SlTriggerPx:       okex.MustDecimal("57000"),
+				SlOrdPx:           okex.MustDecimal("-1"),
+				SlTriggerPxType:   okex.TriggerPxMark,
+			},
+		},
+		ExpTime: time.Now().Add(5 * time.Second),
+	}
+	// Validate is also called by PlaceOrder, it's called here to show the error before anything is sent
+	if err := order.Validate(); err != nil {
+		return err
+	}
+	placeOrderRes, err := client.Rest.Trade.PlaceOrder([]trade.PlaceOrder{order})
+	if err != nil {
+		return err
+	}
+	if placeOrderRes.Code != 0 {
+		return errors.Errorf("PlaceOrder failed. err: %s, code: %d", placeOrderRes.Msg, placeOrderRes.Code)
+	}
+	fmt.Println(placeOrderRes.PlaceOrders[0].OrdID)
+
+	// move the stop loss up and the entry price down
+	amendRes, err := client.Rest.Trade.AmendOrder([]trade.AmendOrder{
+		{
+			InstID:  symbol,
+			ClOrdID: "entry1",
+			NewPx:   okex.MustDecimal("59900"),
+			AttachAlgoOrds: []*trade.AmendAttachAlgoOrder{
+				{
+					AttachAlgoClOrdID: "entry1tpsl",
+					NewSlTriggerPx:    okex.MustDecimal("58000"),
+					NewSlOrdPx:        okex.MustDecimal("-1"),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if amendRes.Code != 0 {
+		return errors.Errorf("AmendOrder failed. err: %s, code: %d", amendRes.Msg, amendRes.Code)
+	}
+
+	return nil
+}
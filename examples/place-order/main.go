@@ -40,7 +40,7 @@ func do() error {
 			TdMode:  okex.TradeCrossMode,
 			Side:    okex.OrderBuy,
 			OrdType: okex.OrderMarket,
-			Sz:      1,
+			Sz:      okex.DecimalFromInt(1),
 		},
 	})
 	if err != nil {
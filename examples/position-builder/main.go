@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pefish/go-okx/risk"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	client, err := api.NewClient(
+		context.Background(),
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	// a short perpetual hedging the account, filled at 60000
+	hedge := trade.PlaceOrder{
+		InstID:  "BTC-USDT-SWAP",
+		TdMode:  okex.TradeCrossMode,
+		Side:    okex.OrderSell,
+		OrdType: okex.OrderLimit,
+		Px:      okex.MustDecimal("60000"),
+		Sz:      okex.MustDecimal("10"),
+	}
+	c, err := risk.WhatIf(client.Rest.Account, []trade.PlaceOrder{hedge}, "")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("mmr %s -> %s (%s)\n", c.Before.TotalMmr, c.After.TotalMmr, c.MmrChange())
+	fmt.Printf("margin ratio %s -> %s\n", c.Before.MarginRatio, c.After.MarginRatio)
+
+	// the same hedge with the index prices 10% lower
+	s := risk.NewScenario().SetIdxVol(okex.MustDecimal("-0.1"))
+	if err := s.AddOrder(hedge, ""); err != nil {
+		return err
+	}
+	res, err := risk.Simulate(client.Rest.Account, s)
+	if err != nil {
+		return err
+	}
+	for _, u := range res.RiskUnitData {
+		fmt.Println(u.RiskUnit, u.Mmr, u.Delta)
+	}
+	return nil
+}
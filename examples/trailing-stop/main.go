@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/private"
+	"github.com/pefish/go-okx/requests/rest/trade"
+	ws_private "github.com/pefish/go-okx/requests/ws/private"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatalf("%+v", err)
+	}
+}
+
+func do() error {
+	symbol := "BTC-USDT-SWAP"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.DemoServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	client.Ws.Private.OnAlgoOrder(func(e *private.AlgoOrder) {
+		for _, o := range e.AlgoOrders {
+			log.Printf("algo: %s, type: %s, state: %s, moveTriggerPx: %f", o.AlgoClOrdID, o.OrdType, o.State, o.MoveTriggerPx)
+		}
+	})
+	err = client.Ws.Private.AlgoOrder([]ws_private.AlgoOrder{
+		{InstType: okex.SwapInstrument, InstID: symbol},
+	})
+	if err != nil {
+		return err
+	}
+
+	// sell 1 contract once the price fell 1% from its highest after reaching 70000
+	placeRes, err := client.Rest.Trade.PlaceAlgoOrder(trade.PlaceAlgoOrder{
+		InstID:      symbol,
+		TdMode:      okex.TradeCrossMode,
+		Side:        okex.OrderSell,
+		OrdType:     okex.AlgoOrderMoveStop,
+		Sz:          okex.MustDecimal("1"),
+		AlgoClOrdID: "trail1",
+		ReduceOnly:  true,
+		MoveStopOrder: trade.MoveStopOrder{
+			CallbackRatio: okex.MustDecimal("0.01"),
+			ActivePx:      okex.MustDecimal("70000"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if placeRes.Code != 0 {
+		return errors.Errorf("PlaceAlgoOrder failed. err: %s, code: %d", placeRes.Msg, placeRes.Code)
+	}
+
+	detailRes, err := client.Rest.Trade.GetAlgoOrderDetail(trade.AlgoOrderDetails{AlgoClOrdID: "trail1"})
+	if err != nil {
+		return err
+	}
+	if detailRes.Code != 0 || len(detailRes.AlgoOrders) == 0 {
+		return errors.Errorf("GetAlgoOrderDetail failed. err: %s, code: %d", detailRes.Msg, detailRes.Code)
+	}
+	log.Printf("algoId: %s, callbackRatio: %f", detailRes.AlgoOrders[0].AlgoID, detailRes.AlgoOrders[0].CallbackRatio)
+
+	// a stop loss, its trigger price is moved up later
+	placeRes, err = client.Rest.Trade.PlaceAlgoOrder(trade.PlaceAlgoOrder{
+		InstID:      symbol,
+		TdMode:      okex.TradeCrossMode,
+		Side:        okex.OrderSell,
+		OrdType:     okex.AlgoOrderConditional,
+		Sz:          okex.MustDecimal("1"),
+		AlgoClOrdID: "sl1",
+		ReduceOnly:  true,
+		StopOrder: trade.StopOrder{
+			SlTriggerPx: okex.MustDecimal("57000"),
+			SlOrdPx:     okex.MustDecimal("-1"),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if placeRes.Code != 0 {
+		return errors.Errorf("PlaceAlgoOrder failed. err: %s, code: %d", placeRes.Msg, placeRes.Code)
+	}
+	amendRes, err := client.Rest.Trade.AmendAlgoOrder(trade.AmendAlgoOrder{
+		InstID:         symbol,
+		AlgoClOrdID:    "sl1",
+		NewSlTriggerPx: okex.MustDecimal("58000"),
+		NewSlOrdPx:     okex.MustDecimal("-1"),
+	})
+	if err != nil {
+		return err
+	}
+	if amendRes.Code != 0 {
+		return errors.Errorf("AmendAlgoOrder failed. err: %s, code: %d", amendRes.Msg, amendRes.Code)
+	}
+
+	<-ctx.Done()
+	return nil
+}
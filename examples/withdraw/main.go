@@ -17,7 +17,7 @@ import (
 )
 
 var toAddress = "5BnsHy3CV2SjefwMPQ4pwQPVmigxA8R7gUZypRNsZqxp"
-var amount = 0.1
+var amount = okex.MustDecimal("0.1")
 
 func main() {
 	envMap, _ := godotenv.Read("./.env")
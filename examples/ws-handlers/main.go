@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api"
+	"github.com/pefish/go-okx/events"
+	"github.com/pefish/go-okx/events/public"
+	requests "github.com/pefish/go-okx/requests/ws/public"
+)
+
+func main() {
+	err := do()
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func do() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := api.NewClient(
+		ctx,
+		&i_logger.DefaultLogger,
+		"YOUR-API-KEY",
+		"YOUR-SECRET-KEY",
+		"YOUR-PASS-PHRASE",
+		okex.NormalServer,
+	)
+	if err != nil {
+		return err
+	}
+
+	client.Ws.OnSubscribe(func(sub *events.Subscribe) {
+		channel, _ := sub.Arg.Get("channel")
+		log.Printf("[Subscribed]\t%s", channel)
+	})
+	client.Ws.OnError(func(err *events.Error) {
+		log.Printf("[Error]\t%+v", err)
+		cancel()
+	})
+	client.Ws.Public.OnTicker(func(t *public.Tickers) {
+		for _, p := range t.Tickers {
+			log.Printf("symbol: %s, last: %f, time: %s", p.InstID, p.Last, p.TS.String())
+		}
+	})
+	client.Ws.Public.OnMarkPrice(func(m *public.MarkPrice) {
+		for _, p := range m.Prices {
+			log.Printf("symbol: %s, mark price: %f", p.InstID, p.MarkPx)
+		}
+	})
+
+	err = client.Ws.Public.Tickers([]requests.Tickers{
+		{InstID: "BTC-USDT"},
+	})
+	if err != nil {
+		return err
+	}
+	err = client.Ws.Public.MarkPrice([]requests.MarkPrice{
+		{InstID: "BTC-USDT-SWAP"},
+	})
+	if err != nil {
+		return err
+	}
+
+	<-ctx.Done()
+	return nil
+}
@@ -138,6 +138,4 @@ time: %s
 			}
 		}
 	}
-
-	return nil
 }
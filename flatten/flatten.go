@@ -0,0 +1,339 @@
+// Package flatten is the panic button: it cancels the pending orders and algo orders and closes the positions
+// of an instrument, an instrument type or the whole account, reporting the outcome of every item.
+package flatten
+
+import (
+	"fmt"
+	"sync"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+	"github.com/pefish/go-okx/models/trade"
+	accountRequests "github.com/pefish/go-okx/requests/rest/account"
+	requests "github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+const (
+	pageLimit        = 100
+	cancelBatch      = 20
+	cancelAlgoBatch  = 10
+	listOrdersID     = "orders"
+	listPositionsID  = "positions"
+	listAlgoOrdersID = "algo orders"
+)
+
+type Action string
+
+const (
+	CancelOrder     = Action("cancel_order")
+	MassCancel      = Action("mass_cancel")
+	CancelAlgoOrder = Action("cancel_algo_order")
+	ClosePosition   = Action("close_position")
+	List            = Action("list") // listing what has to be cancelled or closed failed, ID is what was listed
+)
+
+// algoOrderTypes are listed one request each, conditional and oco are the only ones that can be listed together
+var algoOrderTypes = []okex.AlgoOrderType{
+	okex.AlgoOrderConditional + "," + okex.AlgoOrderOCO,
+	okex.AlgoOrderTrigger,
+	okex.AlgoOrderMoveStop,
+	okex.AlgoOrderChase,
+	okex.AlgoOrderIceberg,
+	okex.AlgoOrderTwap,
+}
+
+// Scope selects what is flattened, the zero Scope is the whole account
+type Scope struct {
+	InstID   string              // a single instrument, it takes precedence over InstType
+	InstType okex.InstrumentType // every instrument of a type
+}
+
+// Result is the outcome of a single item
+type Result struct {
+	Action Action
+	InstID string
+	ID     string // ordId, algoId, posSide of the closed position or instFamily of a mass cancel
+	Err    error
+}
+
+// Report is the outcome of every item in the order they were done
+type Report struct {
+	Results []*Result
+}
+
+// Failed returns the results having an error
+func (r *Report) Failed() []*Result {
+	res := make([]*Result, 0)
+	for _, item := range r.Results {
+		if item.Err != nil {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// Err returns nil when every item succeeded, the first error and the number of failures otherwise
+func (r *Report) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return errors.Wrapf(failed[0].Err, "%d of %d items failed, first %s %s %s", len(failed), len(r.Results), failed[0].Action, failed[0].InstID, failed[0].ID)
+}
+
+// Flattener cancels and closes through the rest api, it keeps going after failures
+type Flattener struct {
+	rest       *rest.ClientRest
+	mu         sync.RWMutex
+	onProgress func(*Result)
+}
+
+// NewFlattener returns a pointer to a fresh Flattener
+func NewFlattener(r *rest.ClientRest) *Flattener {
+	return &Flattener{rest: r}
+}
+
+// OnProgress registers the callback called with every result as soon as it's known
+func (f *Flattener) OnProgress(fn func(*Result)) {
+	f.mu.Lock()
+	f.onProgress = fn
+	f.mu.Unlock()
+}
+
+// Flatten cancels the algo orders first so none triggers meanwhile, then the pending orders, then closes the positions at market
+func (f *Flattener) Flatten(s Scope) *Report {
+	r := &Report{}
+	f.cancelAlgoOrders(s, r)
+	f.cancelOrders(s, r)
+	f.closePositions(s, r)
+	return r
+}
+
+// CancelOrders cancels the pending orders, the MMP orders of options are cancelled by instrument family through mass cancel
+func (f *Flattener) CancelOrders(s Scope) *Report {
+	r := &Report{}
+	f.cancelOrders(s, r)
+	return r
+}
+
+// CancelAlgoOrders cancels the pending algo orders of every type
+func (f *Flattener) CancelAlgoOrders(s Scope) *Report {
+	r := &Report{}
+	f.cancelAlgoOrders(s, r)
+	return r
+}
+
+// ClosePositions closes the open positions at market, the pending orders blocking a close are cancelled with it
+func (f *Flattener) ClosePositions(s Scope) *Report {
+	r := &Report{}
+	f.closePositions(s, r)
+	return r
+}
+
+func (f *Flattener) report(r *Report, res *Result) {
+	r.Results = append(r.Results, res)
+	f.mu.RLock()
+	fn := f.onProgress
+	f.mu.RUnlock()
+	if fn != nil {
+		fn(res)
+	}
+}
+
+func (f *Flattener) cancelOrders(s Scope, r *Report) {
+	orders, err := f.pendingOrders(s)
+	if err != nil {
+		f.report(r, &Result{Action: List, InstID: s.InstID, ID: listOrdersID, Err: err})
+		return
+	}
+	mmp := make(map[string][]*trade.Order)
+	others := make([]*trade.Order, 0, len(orders))
+	for _, o := range orders {
+		if o.InstType == okex.OptionsInstrument && (o.OrdType == okex.OrderMMP || o.OrdType == okex.OrderMMPAndPostOnly) {
+			if id, err := okex.ParseInstID(o.InstID); err == nil {
+				mmp[id.InstFamily()] = append(mmp[id.InstFamily()], o)
+				continue
+			}
+		}
+		others = append(others, o)
+	}
+	for family, list := range mmp {
+		err := f.massCancel(family)
+		f.report(r, &Result{Action: MassCancel, ID: family, Err: err})
+		if err != nil {
+			// the orders are still pending, cancel them one by one
+			others = append(others, list...)
+		}
+	}
+	for i := 0; i < len(others); i += cancelBatch {
+		f.cancelBatch(others[i:min(i+cancelBatch, len(others))], r)
+	}
+}
+
+func (f *Flattener) pendingOrders(s Scope) ([]*trade.Order, error) {
+	req := requests.OrderList{InstID: s.InstID, Limit: pageLimit}
+	if s.InstID == "" {
+		req.InstType = s.InstType
+	}
+	orders := make([]*trade.Order, 0)
+	for {
+		res, err := f.rest.Trade.GetOrderList(req)
+		if err != nil {
+			return nil, err
+		}
+		if res.Code != 0 {
+			return nil, errors.Errorf("GetOrderList failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+		orders = append(orders, res.Orders...)
+		if len(res.Orders) < pageLimit {
+			return orders, nil
+		}
+		req.After = res.Orders[len(res.Orders)-1].OrdID
+	}
+}
+
+func (f *Flattener) massCancel(family string) error {
+	res, err := f.rest.Trade.MassCancel(requests.MassCancel{InstType: okex.OptionsInstrument, InstFamily: family})
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.Errorf("MassCancel failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	if len(res.MassCancels) == 0 || !res.MassCancels[0].Result {
+		return errors.New("MassCancel failed")
+	}
+	return nil
+}
+
+func (f *Flattener) cancelBatch(orders []*trade.Order, r *Report) {
+	req := make([]requests.CancelOrder, len(orders))
+	for i, o := range orders {
+		req[i] = requests.CancelOrder{InstID: o.InstID, OrdID: o.OrdID}
+	}
+	res, err := f.rest.Trade.CandleOrder(req)
+	if err == nil && res.Code != 0 && len(res.PlaceOrders) == 0 {
+		err = errors.Errorf("CancelOrder failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	byID := make(map[string]*trade.PlaceOrder, len(res.PlaceOrders))
+	for _, item := range res.PlaceOrders {
+		byID[item.OrdID] = item
+	}
+	for _, o := range orders {
+		itemErr := err
+		if item := byID[o.OrdID]; itemErr == nil && item == nil {
+			itemErr = errors.New("missing from the response")
+		} else if itemErr == nil && item.SCode != 0 {
+			itemErr = errors.Errorf("err: %s, code: %d", item.SMsg, item.SCode)
+		}
+		f.report(r, &Result{Action: CancelOrder, InstID: o.InstID, ID: o.OrdID, Err: itemErr})
+	}
+}
+
+func (f *Flattener) cancelAlgoOrders(s Scope, r *Report) {
+	for _, t := range algoOrderTypes {
+		algos, err := f.pendingAlgoOrders(s, t)
+		if err != nil {
+			f.report(r, &Result{Action: List, InstID: s.InstID, ID: fmt.Sprintf("%s %s", t, listAlgoOrdersID), Err: err})
+			continue
+		}
+		advance := t == okex.AlgoOrderIceberg || t == okex.AlgoOrderTwap
+		for i := 0; i < len(algos); i += cancelAlgoBatch {
+			f.cancelAlgoBatch(algos[i:min(i+cancelAlgoBatch, len(algos))], advance, r)
+		}
+	}
+}
+
+func (f *Flattener) pendingAlgoOrders(s Scope, t okex.AlgoOrderType) ([]*trade.AlgoOrder, error) {
+	req := requests.AlgoOrderList{InstID: s.InstID, OrdType: t, Limit: pageLimit}
+	if s.InstID == "" {
+		req.InstType = s.InstType
+	}
+	algos := make([]*trade.AlgoOrder, 0)
+	for {
+		res, err := f.rest.Trade.GetAlgoOrderList(req, false)
+		if err != nil {
+			return nil, err
+		}
+		if res.Code != 0 {
+			return nil, errors.Errorf("GetAlgoOrderList failed. err: %s, code: %d", res.Msg, res.Code)
+		}
+		algos = append(algos, res.AlgoOrders...)
+		if len(res.AlgoOrders) < pageLimit {
+			return algos, nil
+		}
+		req.After = res.AlgoOrders[len(res.AlgoOrders)-1].AlgoID
+	}
+}
+
+func (f *Flattener) cancelAlgoBatch(algos []*trade.AlgoOrder, advance bool, r *Report) {
+	req := make([]requests.CancelAlgoOrder, len(algos))
+	for i, a := range algos {
+		req[i] = requests.CancelAlgoOrder{InstID: a.InstID, AlgoID: a.AlgoID}
+	}
+	cancel := f.rest.Trade.CancelAlgoOrder
+	if advance {
+		cancel = f.rest.Trade.CancelAdvanceAlgoOrder
+	}
+	res, err := cancel(req)
+	if err == nil && res.Code != 0 && len(res.CancelAlgoOrders) == 0 {
+		err = errors.Errorf("CancelAlgoOrder failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	byID := make(map[string]*trade.CancelAlgoOrder, len(res.CancelAlgoOrders))
+	for _, item := range res.CancelAlgoOrders {
+		byID[item.AlgoID] = item
+	}
+	for _, a := range algos {
+		itemErr := err
+		if item := byID[a.AlgoID]; itemErr == nil && item == nil {
+			itemErr = errors.New("missing from the response")
+		} else if itemErr == nil && item.SCode != 0 {
+			itemErr = errors.Errorf("err: %s, code: %d", item.SMsg, item.SCode)
+		}
+		f.report(r, &Result{Action: CancelAlgoOrder, InstID: a.InstID, ID: a.AlgoID, Err: itemErr})
+	}
+}
+
+func (f *Flattener) closePositions(s Scope, r *Report) {
+	req := accountRequests.GetPositions{}
+	if s.InstID != "" {
+		req.InstID = []string{s.InstID}
+	} else {
+		req.InstType = s.InstType
+	}
+	res, err := f.rest.Account.GetPositions(req)
+	if err == nil && res.Code != 0 {
+		err = errors.Errorf("GetPositions failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	if err != nil {
+		f.report(r, &Result{Action: List, InstID: s.InstID, ID: listPositionsID, Err: err})
+		return
+	}
+	for _, p := range res.Positions {
+		if p.Pos == 0 {
+			continue
+		}
+		c := requests.ClosePosition{
+			InstID:  p.InstID,
+			PosSide: p.PosSide,
+			MgnMode: p.MgnMode,
+			AutoCxl: true,
+		}
+		if p.InstType == okex.MarginInstrument && p.MgnMode == okex.MarginCrossMode {
+			c.Ccy = p.Ccy
+		}
+		f.report(r, &Result{Action: ClosePosition, InstID: p.InstID, ID: string(p.PosSide), Err: f.closePosition(c)})
+	}
+}
+
+func (f *Flattener) closePosition(req requests.ClosePosition) error {
+	res, err := f.rest.Trade.ClosePosition(req)
+	if err != nil {
+		return err
+	}
+	if res.Code != 0 {
+		return errors.Errorf("ClosePosition failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	return nil
+}
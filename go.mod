@@ -7,12 +7,10 @@ toolchain go1.21.0
 require (
 	github.com/google/uuid v1.6.0
 	github.com/gorilla/websocket v1.5.1
+	github.com/joho/godotenv v1.5.1
 	github.com/pefish/go-interface v0.1.2
+	github.com/pefish/go-random v0.2.9
 	github.com/pkg/errors v0.9.1
 )
 
-require (
-	github.com/joho/godotenv v1.5.1 // indirect
-	github.com/pefish/go-random v0.2.9 // indirect
-	golang.org/x/net v0.17.0 // indirect
-)
+require golang.org/x/net v0.17.0 // indirect
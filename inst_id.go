@@ -0,0 +1,195 @@
+package okex
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// expiryLayout is the date part of futures and options instrument ids, like 250328
+const expiryLayout = "060102"
+
+// deliveryHour is the UTC hour futures and options are delivered or exercised at on their expiry date
+const deliveryHour = 8
+
+// InstID is a parsed instrument id:
+//
+//	SPOT and MARGIN: BTC-USDT
+//	SWAP:            BTC-USDT-SWAP
+//	FUTURES:         BTC-USD-250328
+//	OPTION:          BTC-USD-250328-90000-C
+//
+// MARGIN instruments share the ids of SPOT ones, they are parsed as SpotInstrument.
+type InstID struct {
+	Base    string
+	Quote   string
+	Type    InstrumentType
+	Expiry  time.Time // delivery time of FUTURES and OPTION, 08:00 UTC of the expiry date
+	Strike  Decimal
+	OptType OptionType
+}
+
+// ParseInstID parses and validates an instrument id
+func ParseInstID(s string) (InstID, error) {
+	parts := strings.Split(s, "-")
+	id := InstID{}
+	if len(parts) < 2 {
+		return id, errors.Errorf("invalid instrument id %q", s)
+	}
+	id.Base, id.Quote = parts[0], parts[1]
+	switch {
+	case len(parts) == 2:
+		id.Type = SpotInstrument
+	case len(parts) == 3 && parts[2] == string(SwapInstrument):
+		id.Type = SwapInstrument
+	case len(parts) == 3:
+		id.Type = FuturesInstrument
+	case len(parts) == 5:
+		id.Type = OptionsInstrument
+		id.Strike = Decimal(parts[3])
+		id.OptType = OptionType(parts[4])
+	default:
+		return id, errors.Errorf("invalid instrument id %q", s)
+	}
+	if id.Type == FuturesInstrument || id.Type == OptionsInstrument {
+		t, err := time.Parse(expiryLayout, parts[2])
+		if err != nil {
+			return id, errors.Errorf("invalid expiry in instrument id %q", s)
+		}
+		id.Expiry = t.Add(deliveryHour * time.Hour)
+	}
+	if err := id.Validate(); err != nil {
+		return id, errors.Wrapf(err, "invalid instrument id %q", s)
+	}
+	return id, nil
+}
+
+// MustParseInstID is like ParseInstID but panics on an invalid id
+func MustParseInstID(s string) InstID {
+	id, err := ParseInstID(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// NewSpotInstID returns the id of a spot or margin pair
+func NewSpotInstID(base, quote string) InstID {
+	return InstID{Base: base, Quote: quote, Type: SpotInstrument}
+}
+
+// NewSwapInstID returns the id of a perpetual swap
+func NewSwapInstID(base, quote string) InstID {
+	return InstID{Base: base, Quote: quote, Type: SwapInstrument}
+}
+
+// NewFuturesInstID returns the id of a futures expiring on the date of expiry, in UTC
+func NewFuturesInstID(base, quote string, expiry time.Time) InstID {
+	return InstID{Base: base, Quote: quote, Type: FuturesInstrument, Expiry: deliveryTime(expiry)}
+}
+
+// NewOptionInstID returns the id of an option expiring on the date of expiry, in UTC
+func NewOptionInstID(base, quote string, expiry time.Time, strike Decimal, optType OptionType) InstID {
+	return InstID{
+		Base:    base,
+		Quote:   quote,
+		Type:    OptionsInstrument,
+		Expiry:  deliveryTime(expiry),
+		Strike:  strike,
+		OptType: optType,
+	}
+}
+
+func deliveryTime(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, deliveryHour, 0, 0, 0, time.UTC)
+}
+
+// Validate checks the fields needed by the type of the id are set and valid
+func (id InstID) Validate() error {
+	if !validCcy(id.Base) || !validCcy(id.Quote) {
+		return errors.Errorf("invalid currencies %q and %q", id.Base, id.Quote)
+	}
+	switch id.Type {
+	case SpotInstrument, MarginInstrument, SwapInstrument:
+		return nil
+	case FuturesInstrument, OptionsInstrument:
+		if id.Expiry.IsZero() {
+			return errors.New("expiry is required")
+		}
+	default:
+		return errors.Errorf("invalid instrument type %q", id.Type)
+	}
+	if id.Type == FuturesInstrument {
+		return nil
+	}
+	if !validDecimal(string(id.Strike)) || id.Strike.Sign() <= 0 {
+		return errors.Errorf("invalid strike %q", id.Strike)
+	}
+	if id.OptType != OptionCall && id.OptType != OptionPut {
+		return errors.Errorf("invalid option type %q", id.OptType)
+	}
+	return nil
+}
+
+func validCcy(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		if !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the id the way the server expects it
+func (id InstID) String() string {
+	s := id.Base + "-" + id.Quote
+	switch id.Type {
+	case SwapInstrument:
+		s += "-SWAP"
+	case FuturesInstrument:
+		s += "-" + id.Expiry.UTC().Format(expiryLayout)
+	case OptionsInstrument:
+		s += "-" + id.Expiry.UTC().Format(expiryLayout) + "-" + string(id.Strike) + "-" + string(id.OptType)
+	}
+	return s
+}
+
+// InstFamily returns the instrument family of derivatives, like BTC-USD or BTC-USDT, it's the pair itself for spot.
+// It's the value of both the instFamily and the older uly parameters of the api for the ids this type parses.
+func (id InstID) InstFamily() string {
+	return id.Base + "-" + id.Quote
+}
+
+// IsDerivative reports whether the id is a swap, a futures or an option
+func (id InstID) IsDerivative() bool {
+	return id.Type == SwapInstrument || id.Type == FuturesInstrument || id.Type == OptionsInstrument
+}
+
+// Expired reports whether a futures or an option was delivered or exercised at t
+func (id InstID) Expired(t time.Time) bool {
+	return !id.Expiry.IsZero() && !t.Before(id.Expiry)
+}
+
+// WithExpiry returns a copy of the id expiring on the date of expiry, it's used to roll futures and options
+func (id InstID) WithExpiry(expiry time.Time) InstID {
+	id.Expiry = deliveryTime(expiry)
+	return id
+}
+
+func (id InstID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *InstID) UnmarshalText(b []byte) error {
+	v, err := ParseInstID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = v
+	return nil
+}
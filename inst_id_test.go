@@ -0,0 +1,134 @@
+package okex
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseInstID(t *testing.T) {
+	expiry := time.Date(2025, 3, 28, deliveryHour, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want InstID
+	}{
+		{"BTC-USDT", InstID{Base: "BTC", Quote: "USDT", Type: SpotInstrument}},
+		{"1INCH-USDT", InstID{Base: "1INCH", Quote: "USDT", Type: SpotInstrument}},
+		{"BTC-USDT-SWAP", InstID{Base: "BTC", Quote: "USDT", Type: SwapInstrument}},
+		{"BTC-USD-SWAP", InstID{Base: "BTC", Quote: "USD", Type: SwapInstrument}},
+		{"BTC-USD-250328", InstID{Base: "BTC", Quote: "USD", Type: FuturesInstrument, Expiry: expiry}},
+		{"BTC-USD-250328-90000-C", InstID{Base: "BTC", Quote: "USD", Type: OptionsInstrument, Expiry: expiry, Strike: "90000", OptType: OptionCall}},
+		{"ETH-USD-250328-1500.5-P", InstID{Base: "ETH", Quote: "USD", Type: OptionsInstrument, Expiry: expiry, Strike: "1500.5", OptType: OptionPut}},
+	}
+	for _, tt := range tests {
+		got, err := ParseInstID(tt.in)
+		if err != nil {
+			t.Errorf("ParseInstID(%q) error = %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseInstID(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+		if s := got.String(); s != tt.in {
+			t.Errorf("ParseInstID(%q).String() = %q", tt.in, s)
+		}
+	}
+}
+
+func TestParseInstIDMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"BTC",
+		"BTC-",
+		"-USDT",
+		"btc-usdt",
+		"BTC_USDT",
+		"BTC-USDT-SWAP-1",
+		"BTC-USD-250230",
+		"BTC-USD-2503",
+		"BTC-USD-250328-90000",
+		"BTC-USD-250328-90000-X",
+		"BTC-USD-250328-0-C",
+		"BTC-USD-250328--1-P",
+		"BTC-USD-250328-abc-C",
+		"BTC-USD-250328-90000-C-1",
+	}
+	for _, in := range tests {
+		if id, err := ParseInstID(in); err == nil {
+			t.Errorf("ParseInstID(%q) = %+v, want an error", in, id)
+		}
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("MustParseInstID of a malformed id didn't panic")
+		}
+	}()
+	MustParseInstID("BTC")
+}
+
+func TestInstIDConstructors(t *testing.T) {
+	// a local time late on the 28th is already the 29th in UTC
+	late := time.Date(2025, 3, 28, 23, 0, 0, 0, time.FixedZone("UTC-3", -3*3600))
+	tests := []struct {
+		id         InstID
+		want       string
+		family     string
+		derivative bool
+	}{
+		{NewSpotInstID("BTC", "USDT"), "BTC-USDT", "BTC-USDT", false},
+		{NewSwapInstID("BTC", "USDT"), "BTC-USDT-SWAP", "BTC-USDT", true},
+		{NewFuturesInstID("BTC", "USD", late), "BTC-USD-250329", "BTC-USD", true},
+		{NewOptionInstID("BTC", "USD", late, "90000", OptionCall), "BTC-USD-250329-90000-C", "BTC-USD", true},
+	}
+	for _, tt := range tests {
+		if err := tt.id.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v", tt.want, err)
+		}
+		if s := tt.id.String(); s != tt.want {
+			t.Errorf("String() = %q, want %q", s, tt.want)
+		}
+		if f := tt.id.InstFamily(); f != tt.family {
+			t.Errorf("%s: InstFamily() = %q, want %q", tt.want, f, tt.family)
+		}
+		if d := tt.id.IsDerivative(); d != tt.derivative {
+			t.Errorf("%s: IsDerivative() = %v, want %v", tt.want, d, tt.derivative)
+		}
+	}
+}
+
+func TestInstIDExpiry(t *testing.T) {
+	id := MustParseInstID("BTC-USD-250328")
+	delivery := time.Date(2025, 3, 28, deliveryHour, 0, 0, 0, time.UTC)
+	if id.Expired(delivery.Add(-time.Second)) || !id.Expired(delivery) {
+		t.Errorf("Expired around the delivery time %s", delivery)
+	}
+	if MustParseInstID("BTC-USDT-SWAP").Expired(delivery) {
+		t.Error("a swap expired")
+	}
+	next := id.WithExpiry(time.Date(2025, 6, 27, 0, 0, 0, 0, time.UTC))
+	if s := next.String(); s != "BTC-USD-250627" {
+		t.Errorf("WithExpiry().String() = %q, want BTC-USD-250627", s)
+	}
+	if id.String() != "BTC-USD-250328" {
+		t.Error("WithExpiry modified the original id")
+	}
+}
+
+func TestInstIDText(t *testing.T) {
+	var v struct {
+		InstID InstID `json:"instId"`
+	}
+	if err := json.Unmarshal([]byte(`{"instId":"BTC-USD-250328-90000-C"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	j, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(j) != `{"instId":"BTC-USD-250328-90000-C"}` {
+		t.Errorf("Marshal = %s", j)
+	}
+	if err := json.Unmarshal([]byte(`{"instId":"BTC"}`), &v); err == nil {
+		t.Error("Unmarshal of a malformed id succeeded")
+	}
+}
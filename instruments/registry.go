@@ -0,0 +1,320 @@
+// Package instruments keeps the metadata of every instrument loaded from the rest api and up to date through the websocket instruments channel.
+//
+// https://www.okx.com/docs-v5/en/#public-data-websocket-instruments-channel
+package instruments
+
+import (
+	"sort"
+	"sync"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+	"github.com/pefish/go-okx/api/ws"
+	"github.com/pefish/go-okx/events/public"
+	"github.com/pefish/go-okx/models/publicdata"
+	restRequests "github.com/pefish/go-okx/requests/rest/public"
+	wsRequests "github.com/pefish/go-okx/requests/ws/public"
+	"github.com/pkg/errors"
+)
+
+type EventType string
+
+// key is an instrument of the registry, SPOT and MARGIN share their instIds
+type key struct {
+	instType okex.InstrumentType
+	instID   string
+}
+
+const (
+	Listed          = EventType("listed")
+	Delisted        = EventType("delisted")
+	StateChanged    = EventType("state_changed")
+	TickSizeChanged = EventType("tick_size_changed")
+	LotSizeChanged  = EventType("lot_size_changed")
+)
+
+// AllTypes are the instrument types loaded when none is given to Load
+var AllTypes = []okex.InstrumentType{
+	okex.SpotInstrument,
+	okex.MarginInstrument,
+	okex.SwapInstrument,
+	okex.FuturesInstrument,
+	okex.OptionsInstrument,
+}
+
+// Event is a change of an instrument. Previous is nil for Listed, Instrument is the last known version for Delisted.
+//
+// The instruments channel only pushes the instruments that changed, Delisted comes from the refreshes of Load alone:
+// expired futures and options stay in the registry until the next Load of their type.
+type Event struct {
+	Type       EventType
+	Instrument *publicdata.Instrument
+	Previous   *publicdata.Instrument
+}
+
+// Registry is the set of known instruments, it's safe for concurrent use.
+//
+// Instruments are replaced and never modified in place, the pointers it returns must be treated as read only.
+// SPOT and MARGIN instruments share their instId, each type keeps its own version.
+type Registry struct {
+	rest    *rest.ClientRest
+	ws      *ws.ClientWs
+	mu      sync.RWMutex
+	byID    map[key]*publicdata.Instrument
+	loaded  map[okex.InstrumentType]bool
+	onEvent func(*Event)
+
+	watchMu  sync.Mutex // guards watched and unlisten
+	watched  []wsRequests.Instruments
+	unlisten func()
+}
+
+// NewRegistry returns a pointer to a fresh Registry, ws can be nil when live updates are not needed
+func NewRegistry(r *rest.ClientRest, c *ws.ClientWs) *Registry {
+	return &Registry{
+		rest:   r,
+		ws:     c,
+		byID:   make(map[key]*publicdata.Instrument),
+		loaded: make(map[okex.InstrumentType]bool),
+	}
+}
+
+// OnEvent registers the callback called for listings, delistings, state changes and tick/lot size changes
+func (r *Registry) OnEvent(fn func(*Event)) {
+	r.mu.Lock()
+	r.onEvent = fn
+	r.mu.Unlock()
+}
+
+// Load fetches the instruments of types, all of them when types is empty.
+//
+// The first load of a type emits no event, a later one is a full refresh: instruments missing from the response are removed as Delisted.
+func (r *Registry) Load(types ...okex.InstrumentType) error {
+	if len(types) == 0 {
+		types = AllTypes
+	}
+	for _, t := range types {
+		list, err := r.fetch(t)
+		if err != nil {
+			return err
+		}
+		r.replace(t, list)
+	}
+	return nil
+}
+
+// Watch subscribes to the instruments channel of every loaded type, pushes then update the registry.
+// It's a no-op while the registry is watched, types loaded after Watch are only refreshed by Load.
+//
+// It listens to the instruments of the websocket client, the OnInstrument handler is left to the user.
+func (r *Registry) Watch() error {
+	if r.ws == nil {
+		return errors.New("registry has no websocket client")
+	}
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+	if r.unlisten != nil {
+		return nil
+	}
+	r.mu.RLock()
+	req := make([]wsRequests.Instruments, 0, len(r.loaded))
+	for t := range r.loaded {
+		req = append(req, wsRequests.Instruments{InstType: t})
+	}
+	r.mu.RUnlock()
+	if len(req) == 0 {
+		return errors.New("no instrument type loaded")
+	}
+	unlisten := r.ws.Public.ListenInstrument(r.process)
+	if err := r.ws.Public.Instruments(req); err != nil {
+		unlisten()
+		return err
+	}
+	r.watched, r.unlisten = req, unlisten
+	return nil
+}
+
+// Unwatch unsubscribes the instruments channel subscribed by Watch and stops listening to it, the registry keeps its
+// instruments and is only refreshed by Load afterwards. It's a no-op when the registry isn't watched.
+func (r *Registry) Unwatch() error {
+	r.watchMu.Lock()
+	defer r.watchMu.Unlock()
+	if r.unlisten == nil {
+		return nil
+	}
+	r.unlisten()
+	req := r.watched
+	r.watched, r.unlisten = nil, nil
+	return r.ws.Public.UInstruments(req)
+}
+
+// Get returns the instrument of instID, the SPOT version of the pairs that are both SPOT and MARGIN
+func (r *Registry) Get(instID string) (*publicdata.Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range AllTypes {
+		if i, ok := r.byID[key{t, instID}]; ok {
+			return i, true
+		}
+	}
+	return nil, false
+}
+
+// GetType returns the instrument of instID of type t, like the MARGIN version of a pair
+func (r *Registry) GetType(t okex.InstrumentType, instID string) (*publicdata.Instrument, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.byID[key{t, instID}]
+	return i, ok
+}
+
+// ByType returns the instruments of an instrument type
+func (r *Registry) ByType(t okex.InstrumentType) []*publicdata.Instrument {
+	return r.filter(func(i *publicdata.Instrument) bool { return i.InstType == t })
+}
+
+// ByUnderlying returns the derivatives of an underlying, like BTC-USD
+func (r *Registry) ByUnderlying(uly string) []*publicdata.Instrument {
+	return r.filter(func(i *publicdata.Instrument) bool { return i.Uly == uly })
+}
+
+// ByInstFamily returns the derivatives of an instrument family, like BTC-USD
+func (r *Registry) ByInstFamily(instFamily string) []*publicdata.Instrument {
+	return r.filter(func(i *publicdata.Instrument) bool { return i.InstFamily == instFamily })
+}
+
+// BySettleCcy returns the derivatives settled in ccy
+func (r *Registry) BySettleCcy(ccy string) []*publicdata.Instrument {
+	return r.filter(func(i *publicdata.Instrument) bool { return i.SettleCcy == ccy })
+}
+
+// ByState returns the instruments in state
+func (r *Registry) ByState(state okex.InstrumentState) []*publicdata.Instrument {
+	return r.filter(func(i *publicdata.Instrument) bool { return i.State == state })
+}
+
+// Filter returns the instruments fn reports true for, sorted by instId then instType
+func (r *Registry) Filter(fn func(*publicdata.Instrument) bool) []*publicdata.Instrument {
+	return r.filter(fn)
+}
+
+func (r *Registry) filter(fn func(*publicdata.Instrument) bool) []*publicdata.Instrument {
+	r.mu.RLock()
+	res := make([]*publicdata.Instrument, 0)
+	for _, i := range r.byID {
+		if fn(i) {
+			res = append(res, i)
+		}
+	}
+	r.mu.RUnlock()
+	sort.Slice(res, func(a, b int) bool {
+		if res[a].InstID == res[b].InstID {
+			return res[a].InstType < res[b].InstType
+		}
+		return res[a].InstID < res[b].InstID
+	})
+	return res
+}
+
+// fetch returns every instrument of t, options are only listed per underlying
+func (r *Registry) fetch(t okex.InstrumentType) ([]*publicdata.Instrument, error) {
+	if t != okex.OptionsInstrument {
+		return r.fetchInstruments(restRequests.GetInstruments{InstType: t})
+	}
+	res, err := r.rest.PublicData.GetUnderlying(restRequests.GetUnderlying{InstType: t})
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, errors.Errorf("GetUnderlying failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	list := make([]*publicdata.Instrument, 0)
+	for _, ulys := range res.Underlings {
+		for _, uly := range ulys {
+			l, err := r.fetchInstruments(restRequests.GetInstruments{InstType: t, Uly: uly})
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, l...)
+		}
+	}
+	return list, nil
+}
+
+func (r *Registry) fetchInstruments(req restRequests.GetInstruments) ([]*publicdata.Instrument, error) {
+	res, err := r.rest.PublicData.GetInstruments(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, errors.Errorf("GetInstruments of %s failed. err: %s, code: %d", req.InstType, res.Msg, res.Code)
+	}
+	return res.Instruments, nil
+}
+
+// replace sets the instruments of t to list
+func (r *Registry) replace(t okex.InstrumentType, list []*publicdata.Instrument) {
+	r.mu.Lock()
+	first := !r.loaded[t]
+	r.loaded[t] = true
+	seen := make(map[key]bool, len(list))
+	events := make([]*Event, 0)
+	for _, i := range list {
+		seen[key{i.InstType, i.InstID}] = true
+		events = append(events, r.upsert(i)...)
+	}
+	for k, i := range r.byID {
+		if k.instType == t && !seen[k] {
+			delete(r.byID, k)
+			events = append(events, &Event{Type: Delisted, Instrument: i})
+		}
+	}
+	onEvent := r.onEvent
+	r.mu.Unlock()
+
+	if !first {
+		emit(onEvent, events)
+	}
+}
+
+func (r *Registry) process(e *public.Instruments) {
+	r.mu.Lock()
+	events := make([]*Event, 0)
+	for _, i := range e.Instruments {
+		events = append(events, r.upsert(i)...)
+	}
+	onEvent := r.onEvent
+	r.mu.Unlock()
+
+	emit(onEvent, events)
+}
+
+// upsert stores i and returns the events of the change, r.mu must be held
+func (r *Registry) upsert(i *publicdata.Instrument) []*Event {
+	k := key{i.InstType, i.InstID}
+	prev, ok := r.byID[k]
+	r.byID[k] = i
+	if !ok {
+		return []*Event{{Type: Listed, Instrument: i}}
+	}
+	events := make([]*Event, 0)
+	if prev.State != i.State {
+		events = append(events, &Event{Type: StateChanged, Instrument: i, Previous: prev})
+	}
+	if !prev.TickSz.Equal(i.TickSz) {
+		events = append(events, &Event{Type: TickSizeChanged, Instrument: i, Previous: prev})
+	}
+	if !prev.LotSz.Equal(i.LotSz) {
+		events = append(events, &Event{Type: LotSizeChanged, Instrument: i, Previous: prev})
+	}
+	return events
+}
+
+func emit(onEvent func(*Event), events []*Event) {
+	if onEvent == nil {
+		return
+	}
+	for _, e := range events {
+		onEvent(e)
+	}
+}
@@ -0,0 +1,239 @@
+package instruments
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+	"github.com/pefish/go-okx/api/ws"
+	"github.com/pefish/go-okx/models/publicdata"
+)
+
+// fakeRest serves the instruments of each type, options are listed per underlying like the real api
+type fakeRest struct {
+	mu          sync.Mutex
+	instruments map[string]string // the data of the instruments response by instType, or instType/uly for options
+}
+
+func (f *fakeRest) set(k, data string) {
+	f.mu.Lock()
+	f.instruments[k] = data
+	f.mu.Unlock()
+}
+
+func (f *fakeRest) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.URL.Path {
+	case "/api/v5/public/underlying":
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[["BTC-USD"]]}`))
+	case "/api/v5/public/instruments":
+		k := q.Get("instType")
+		if uly := q.Get("uly"); uly != "" {
+			k += "/" + uly
+		}
+		_, _ = w.Write([]byte(`{"code":"0","msg":"","data":[` + f.instruments[k] + `]}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestRegistry(t *testing.T, c *ws.ClientWs) (*Registry, *fakeRest) {
+	f := &fakeRest{instruments: map[string]string{
+		"SPOT": `{"instType":"SPOT","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","tickSz":"0.1","lotSz":"0.00000001","state":"live"},` +
+			`{"instType":"SPOT","instId":"ETH-USDT","baseCcy":"ETH","quoteCcy":"USDT","tickSz":"0.01","lotSz":"0.000001","state":"live"}`,
+		"MARGIN":         `{"instType":"MARGIN","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","tickSz":"0.1","lotSz":"0.0001","state":"live"}`,
+		"OPTION/BTC-USD": `{"instType":"OPTION","instId":"BTC-USD-240329-70000-C","uly":"BTC-USD","instFamily":"BTC-USD","tickSz":"0.0005","lotSz":"1","state":"live"}`,
+	}}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+	r := rest.NewClient(&i_logger.DefaultLogger, "", "", "", okex.BaseURL(srv.URL), okex.NormalServer)
+	return NewRegistry(r, c), f
+}
+
+func TestRegistryLoad(t *testing.T) {
+	r, f := newTestRegistry(t, nil)
+	var events []*Event
+	r.OnEvent(func(e *Event) { events = append(events, e) })
+	if err := r.Load(okex.SpotInstrument, okex.MarginInstrument, okex.OptionsInstrument); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("first load emitted %d events", len(events))
+	}
+
+	spot, ok := r.Get("BTC-USDT")
+	if !ok || spot.InstType != okex.SpotInstrument {
+		t.Fatalf("Get(BTC-USDT) = %+v, %v, want the SPOT version", spot, ok)
+	}
+	margin, ok := r.GetType(okex.MarginInstrument, "BTC-USDT")
+	if !ok || margin.LotSz != "0.0001" {
+		t.Fatalf("GetType(MARGIN, BTC-USDT) = %+v, %v, want lotSz 0.0001", margin, ok)
+	}
+	if got := r.ByType(okex.SpotInstrument); len(got) != 2 || got[0].InstID != "BTC-USDT" || got[1].InstID != "ETH-USDT" {
+		t.Errorf("ByType(SPOT) = %d instruments", len(got))
+	}
+	if got := r.ByUnderlying("BTC-USD"); len(got) != 1 || got[0].InstType != okex.OptionsInstrument {
+		t.Errorf("ByUnderlying(BTC-USD) = %d instruments", len(got))
+	}
+	if got := r.Filter(func(i *publicdata.Instrument) bool { return i.InstID == "BTC-USDT" }); len(got) != 2 ||
+		got[0].InstType != okex.MarginInstrument || got[1].InstType != okex.SpotInstrument {
+		t.Errorf("Filter(BTC-USDT) doesn't hold both versions sorted by type")
+	}
+
+	// a refresh of SPOT changes the tick size of BTC-USDT and delists ETH-USDT, MARGIN is left alone
+	f.set("SPOT", `{"instType":"SPOT","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","tickSz":"0.01","lotSz":"0.00000001","state":"live"}`)
+	if err := r.Load(okex.SpotInstrument); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		typ    EventType
+		instID string
+		prev   bool
+	}{
+		{TickSizeChanged, "BTC-USDT", true},
+		{Delisted, "ETH-USDT", false},
+	}
+	if len(events) != len(tests) {
+		t.Fatalf("refresh emitted %d events, want %d", len(events), len(tests))
+	}
+	for i, tt := range tests {
+		e := events[i]
+		if e.Type != tt.typ || e.Instrument.InstID != tt.instID || (e.Previous != nil) != tt.prev {
+			t.Errorf("event %d = %s %s, want %s %s", i, e.Type, e.Instrument.InstID, tt.typ, tt.instID)
+		}
+	}
+	if _, ok := r.GetType(okex.SpotInstrument, "ETH-USDT"); ok {
+		t.Error("delisted ETH-USDT still there")
+	}
+	if _, ok := r.GetType(okex.MarginInstrument, "BTC-USDT"); !ok {
+		t.Error("refresh of SPOT removed the MARGIN version")
+	}
+}
+
+// fakeWs acks the subscriptions and pushes BTC-USDT after each subscribe, suspended after the odd ones and live after
+// the even ones
+type fakeWs struct {
+	subscribes, unsubscribes atomic.Int64
+}
+
+func (f *fakeWs) serve(t *testing.T) okex.BaseURL {
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer c.Close()
+		for {
+			_, data, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "ping" {
+				_ = c.WriteMessage(websocket.TextMessage, []byte("pong"))
+				continue
+			}
+			msg := struct {
+				Op   string            `json:"op"`
+				Args []json.RawMessage `json:"args"`
+			}{}
+			if json.Unmarshal(data, &msg) != nil {
+				continue
+			}
+			for _, arg := range msg.Args {
+				_ = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"`+msg.Op+`","arg":`+string(arg)+`}`))
+			}
+			switch msg.Op {
+			case "subscribe":
+				state := okex.InstrumentLive
+				if f.subscribes.Add(1)%2 == 1 {
+					state = okex.InstrumentSuspend
+				}
+				_ = c.WriteMessage(websocket.TextMessage, []byte(`{"arg":{"channel":"instruments","instType":"SPOT"},"data":[`+
+					`{"instType":"SPOT","instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","tickSz":"0.1","lotSz":"0.00000001","state":"`+
+					string(state)+`"}]}`))
+			case "unsubscribe":
+				f.unsubscribes.Add(1)
+			}
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return okex.BaseURL("ws" + strings.TrimPrefix(srv.URL, "http"))
+}
+
+func TestRegistryWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := &fakeWs{}
+	c := ws.NewClient(ctx, &i_logger.DefaultLogger, "", "", "", map[bool]okex.BaseURL{false: f.serve(t)})
+	r, _ := newTestRegistry(t, c)
+	if err := r.Watch(); err == nil {
+		t.Fatal("Watch without a loaded type succeeded")
+	}
+	if err := r.Load(okex.SpotInstrument); err != nil {
+		t.Fatal(err)
+	}
+	events := make(chan *Event, 10)
+	r.OnEvent(func(e *Event) { events <- e })
+
+	if err := r.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		if e.Type != StateChanged || e.Instrument.State != okex.InstrumentSuspend {
+			t.Fatalf("got %s %s, want the state change to suspend", e.Type, e.Instrument.State)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("push not applied")
+	}
+
+	// a second Watch doesn't subscribe again
+	if err := r.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if n := f.subscribes.Load(); n != 1 {
+		t.Fatalf("%d subscribes, want 1", n)
+	}
+
+	if err := r.Unwatch(); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for f.unsubscribes.Load() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("instruments channel not unsubscribed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := r.Unwatch(); err != nil {
+		t.Fatalf("second Unwatch = %v", err)
+	}
+
+	// watched again, the registry listens to the pushes again
+	if err := r.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case e := <-events:
+		if e.Type != StateChanged || e.Instrument.State != okex.InstrumentLive {
+			t.Fatalf("got %s %s, want the state change to live", e.Type, e.Instrument.State)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("push not applied after watching again")
+	}
+}
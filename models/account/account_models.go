@@ -6,41 +6,41 @@ import (
 
 type (
 	Balance struct {
-		TotalEq     okex.JSONFloat64  `json:"totalEq"`
-		IsoEq       okex.JSONFloat64  `json:"isoEq"`
-		AdjEq       okex.JSONFloat64  `json:"adjEq,omitempty"`
-		OrdFroz     okex.JSONFloat64  `json:"ordFroz,omitempty"`
-		Imr         okex.JSONFloat64  `json:"imr,omitempty"`
-		Mmr         okex.JSONFloat64  `json:"mmr,omitempty"`
-		MgnRatio    okex.JSONFloat64  `json:"mgnRatio,omitempty"`
-		NotionalUsd okex.JSONFloat64  `json:"notionalUsd,omitempty"`
+		TotalEq     okex.Decimal      `json:"totalEq"`
+		IsoEq       okex.Decimal      `json:"isoEq"`
+		AdjEq       okex.Decimal      `json:"adjEq,omitempty"`
+		OrdFroz     okex.Decimal      `json:"ordFroz,omitempty"`
+		Imr         okex.Decimal      `json:"imr,omitempty"`
+		Mmr         okex.Decimal      `json:"mmr,omitempty"`
+		MgnRatio    okex.Decimal      `json:"mgnRatio,omitempty"`
+		NotionalUsd okex.Decimal      `json:"notionalUsd,omitempty"`
 		Details     []*BalanceDetails `json:"details,omitempty"`
 		UTime       okex.JSONTime     `json:"uTime"`
 	}
 	BalanceDetails struct {
-		Ccy           string           `json:"ccy"`
-		Eq            okex.JSONFloat64 `json:"eq"`
-		CashBal       okex.JSONFloat64 `json:"cashBal"`
-		IsoEq         okex.JSONFloat64 `json:"isoEq,omitempty"`
-		AvailEq       okex.JSONFloat64 `json:"availEq,omitempty"`
-		DisEq         okex.JSONFloat64 `json:"disEq"`
-		AvailBal      okex.JSONFloat64 `json:"availBal"`
-		FrozenBal     okex.JSONFloat64 `json:"frozenBal"`
-		OrdFrozen     okex.JSONFloat64 `json:"ordFrozen"`
-		Liab          okex.JSONFloat64 `json:"liab,omitempty"`
-		Upl           okex.JSONFloat64 `json:"upl,omitempty"`
-		UplLib        okex.JSONFloat64 `json:"uplLib,omitempty"`
-		CrossLiab     okex.JSONFloat64 `json:"crossLiab,omitempty"`
-		IsoLiab       okex.JSONFloat64 `json:"isoLiab,omitempty"`
-		MgnRatio      okex.JSONFloat64 `json:"mgnRatio,omitempty"`
-		Interest      okex.JSONFloat64 `json:"interest,omitempty"`
-		Twap          okex.JSONFloat64 `json:"twap,omitempty"`
-		MaxLoan       okex.JSONFloat64 `json:"maxLoan,omitempty"`
-		EqUsd         okex.JSONFloat64 `json:"eqUsd"`
-		NotionalLever okex.JSONFloat64 `json:"notionalLever,omitempty"`
-		StgyEq        okex.JSONFloat64 `json:"stgyEq"`
-		IsoUpl        okex.JSONFloat64 `json:"isoUpl,omitempty"`
-		UTime         okex.JSONTime    `json:"uTime"`
+		Ccy           string        `json:"ccy"`
+		Eq            okex.Decimal  `json:"eq"`
+		CashBal       okex.Decimal  `json:"cashBal"`
+		IsoEq         okex.Decimal  `json:"isoEq,omitempty"`
+		AvailEq       okex.Decimal  `json:"availEq,omitempty"`
+		DisEq         okex.Decimal  `json:"disEq"`
+		AvailBal      okex.Decimal  `json:"availBal"`
+		FrozenBal     okex.Decimal  `json:"frozenBal"`
+		OrdFrozen     okex.Decimal  `json:"ordFrozen"`
+		Liab          okex.Decimal  `json:"liab,omitempty"`
+		Upl           okex.Decimal  `json:"upl,omitempty"`
+		UplLib        okex.Decimal  `json:"uplLib,omitempty"`
+		CrossLiab     okex.Decimal  `json:"crossLiab,omitempty"`
+		IsoLiab       okex.Decimal  `json:"isoLiab,omitempty"`
+		MgnRatio      okex.Decimal  `json:"mgnRatio,omitempty"`
+		Interest      okex.Decimal  `json:"interest,omitempty"`
+		Twap          okex.Decimal  `json:"twap,omitempty"`
+		MaxLoan       okex.Decimal  `json:"maxLoan,omitempty"`
+		EqUsd         okex.Decimal  `json:"eqUsd"`
+		NotionalLever okex.Decimal  `json:"notionalLever,omitempty"`
+		StgyEq        okex.Decimal  `json:"stgyEq"`
+		IsoUpl        okex.Decimal  `json:"isoUpl,omitempty"`
+		UTime         okex.JSONTime `json:"uTime"`
 	}
 	Position struct {
 		InstID      string              `json:"instId"`
@@ -53,6 +53,7 @@ type (
 		Pos         okex.JSONFloat64    `json:"pos"`
 		AvailPos    okex.JSONFloat64    `json:"availPos,omitempty"`
 		AvgPx       okex.JSONFloat64    `json:"avgPx"`
+		MarkPx      okex.JSONFloat64    `json:"markPx"`
 		Upl         okex.JSONFloat64    `json:"upl"`
 		UplRatio    okex.JSONFloat64    `json:"uplRatio"`
 		Lever       okex.JSONFloat64    `json:"lever"`
@@ -94,21 +95,31 @@ type (
 		TS      okex.JSONTime                        `json:"ts"`
 	}
 	HistoryPosition struct {
-		InstID           string              `json:"instId"`
-		InstType         okex.InstrumentType `json:"instType"`
-		Ccy              string              `json:"ccy"`
-		Lever            okex.JSONFloat64    `json:"lever"`
-		OpenAvgPx        okex.JSONFloat64    `json:"openAvgPx"`
-		CloseAvgPx       okex.JSONFloat64    `json:"closeAvgPx"`
-		CloseTotalAmount okex.JSONFloat64    `json:"closeTotalAmount"`
-		Pnl              okex.JSONFloat64    `json:"closeUpl"`
-		PnlRatio         okex.JSONFloat64    `json:"pnlRatio"`
-		Fee              okex.JSONFloat64    `json:"fee"`
-		FundingFee       okex.JSONFloat64    `json:"fundingFee"`
-		RealizedPnl      okex.JSONFloat64    `json:"realizedPnl"` // Pnl + Fee + FundingFee
-		PosSide          okex.PositionSide   `json:"posSide"`
-		MgnMode          okex.MarginMode     `json:"mgnMode"`
-		CloseTime        okex.JSONTime       `json:"cTime"`
+		InstID         string                 `json:"instId"`
+		InstType       okex.InstrumentType    `json:"instType"`
+		Uly            string                 `json:"uly"`
+		Ccy            string                 `json:"ccy"`
+		PosID          string                 `json:"posId"`
+		Type           okex.PositionCloseType `json:"type"`
+		Lever          okex.Decimal           `json:"lever"`
+		OpenAvgPx      okex.Decimal           `json:"openAvgPx"`
+		NonSettleAvgPx okex.Decimal           `json:"nonSettleAvgPx"`
+		CloseAvgPx     okex.Decimal           `json:"closeAvgPx"`
+		OpenMaxPos     okex.Decimal           `json:"openMaxPos"`
+		CloseTotalPos  okex.Decimal           `json:"closeTotalPos"`
+		TriggerPx      okex.Decimal           `json:"triggerPx"` // the liquidation or ADL trigger price
+		Pnl            okex.Decimal           `json:"pnl"`
+		PnlRatio       okex.Decimal           `json:"pnlRatio"`
+		Fee            okex.Decimal           `json:"fee"`
+		FundingFee     okex.Decimal           `json:"fundingFee"`
+		LiqPenalty     okex.Decimal           `json:"liqPenalty"`
+		RealizedPnl    okex.Decimal           `json:"realizedPnl"` // Pnl + Fee + FundingFee + LiqPenalty
+		SettledPnl     okex.Decimal           `json:"settledPnl"`
+		PosSide        okex.PositionSide      `json:"posSide"`
+		Direction      okex.PositionSide      `json:"direction"` // long or short
+		MgnMode        okex.MarginMode        `json:"mgnMode"`
+		CTime          okex.JSONTime          `json:"cTime"`
+		UTime          okex.JSONTime          `json:"uTime"` // the time of the latest close
 	}
 	PositionAndAccountRiskBalanceData struct {
 		Ccy   string           `json:"ccy"`
@@ -150,13 +161,49 @@ type (
 		TS        okex.JSONTime       `json:"ts"`
 	}
 	Config struct {
-		Level      string            `json:"level"`
-		LevelTmp   string            `json:"levelTmp"`
-		AcctLv     string            `json:"acctLv"`
-		AutoLoan   bool              `json:"autoLoan"`
-		UID        string            `json:"uid"`
-		GreeksType okex.GreekType    `json:"greeksType"`
-		PosMode    okex.PositionType `json:"posMode"`
+		UID                 string                  `json:"uid"`
+		MainUID             string                  `json:"mainUid"`
+		Label               string                  `json:"label"` // label of the api key
+		Perm                string                  `json:"perm"`  // permissions of the api key, comma separated
+		IP                  string                  `json:"ip"`    // ips bound to the api key, comma separated
+		Level               string                  `json:"level"`
+		LevelTmp            string                  `json:"levelTmp"`
+		KycLv               string                  `json:"kycLv"`
+		AcctLv              okex.AccountLevel       `json:"acctLv"`
+		AcctStpMode         okex.SelfTradeMode      `json:"acctStpMode"`
+		PosMode             okex.PositionType       `json:"posMode"`
+		GreeksType          okex.GreekType          `json:"greeksType"`
+		CtIsoMode           okex.IsolatedMarginMode `json:"ctIsoMode"`
+		MgnIsoMode          okex.IsolatedMarginMode `json:"mgnIsoMode"`
+		AutoLoan            bool                    `json:"autoLoan"`
+		EnableSpotBorrow    bool                    `json:"enableSpotBorrow"`
+		SpotBorrowAutoRepay bool                    `json:"spotBorrowAutoRepay"`
+		SpotOffsetType      okex.RiskOffsetType     `json:"spotOffsetType"`
+		LiquidationGear     string                  `json:"liquidationGear"`
+		RoleType            string                  `json:"roleType"`
+		SpotRoleType        string                  `json:"spotRoleType"`
+		TraderInsts         []string                `json:"traderInsts"`
+		SpotTraderInsts     []string                `json:"spotTraderInsts"`
+		OpAuth              string                  `json:"opAuth"` // 1 when options trading is activated
+		Type                string                  `json:"type"`   // 0 main account, 1 standard, 2 managed trading, 5 custody trading, 9 managed trading (copy trading) sub-account
+		SettleCcy           string                  `json:"settleCcy"`
+		SettleCcyList       []string                `json:"settleCcyList"`
+	}
+	AccountLevel struct {
+		AcctLv okex.AccountLevel `json:"acctLv"`
+	}
+	IsolatedMode struct {
+		IsoMode okex.IsolatedMarginMode `json:"isoMode"`
+	}
+	AutoLoan struct {
+		AutoLoan bool `json:"autoLoan"`
+	}
+	RiskOffsetType struct {
+		Type okex.RiskOffsetType `json:"type"`
+	}
+	RiskOffsetAmount struct {
+		Ccy            string       `json:"ccy"`
+		ClSpotInUseAmt okex.Decimal `json:"clSpotInUseAmt"`
 	}
 	PositionMode struct {
 		PosMode okex.PositionType `json:"posMode"`
@@ -222,4 +269,148 @@ type (
 		Ccy   string           `json:"ccy"`
 		MaxWd okex.JSONFloat64 `json:"maxWd"`
 	}
+	SpotBorrowRepay struct {
+		Ccy  string               `json:"ccy"`
+		Side okex.BorrowRepaySide `json:"side"`
+		Amt  okex.Decimal         `json:"amt"`
+	}
+	AutoRepay struct {
+		AutoRepay bool `json:"autoRepay"`
+	}
+	SpotBorrowRepayHistory struct {
+		Ccy         string               `json:"ccy"`
+		Type        okex.BorrowRepayType `json:"type"`
+		Amt         okex.Decimal         `json:"amt"`
+		AccBorrowed okex.Decimal         `json:"accBorrowed"`
+		TS          okex.JSONTime        `json:"ts"`
+	}
+	VIPBorrowRepay struct {
+		Ccy   string               `json:"ccy"`
+		Side  okex.BorrowRepaySide `json:"side"`
+		Amt   okex.Decimal         `json:"amt"`
+		OrdID string               `json:"ordId"`
+		State okex.VIPLoanState    `json:"state"`
+	}
+	VIPBorrowRepayHistory struct {
+		Ccy        string        `json:"ccy"`
+		Type       string        `json:"type"`
+		TradedLoan okex.Decimal  `json:"tradedLoan"`
+		UsedLmt    okex.Decimal  `json:"usedLmt"`
+		TS         okex.JSONTime `json:"ts"`
+	}
+	InterestLimits struct {
+		Debt             okex.Decimal           `json:"debt"` // in USDT
+		Interest         okex.Decimal           `json:"interest"`
+		LoanAlloc        okex.Decimal           `json:"loanAlloc"` // percentage of the VIP loan quota allocated to the trading account
+		NextDiscountTime okex.JSONTime          `json:"nextDiscountTime"`
+		NextInterestTime okex.JSONTime          `json:"nextInterestTime"`
+		Records          []*InterestLimitRecord `json:"records"`
+	}
+	InterestLimitRecord struct {
+		Ccy        string       `json:"ccy"`
+		Rate       okex.Decimal `json:"rate"` // daily rate
+		AvgRate    okex.Decimal `json:"avgRate"`
+		LoanQuota  okex.Decimal `json:"loanQuota"`
+		SurplusLmt okex.Decimal `json:"surplusLmt"`
+		UsedLmt    okex.Decimal `json:"usedLmt"`
+		Interest   okex.Decimal `json:"interest"`
+		PosLoan    okex.Decimal `json:"posLoan"` // frozen by pending orders
+		AvailLoan  okex.Decimal `json:"availLoan"`
+		UsedLoan   okex.Decimal `json:"usedLoan"`
+	}
+	VIPInterest struct {
+		OrdID        string        `json:"ordId"`
+		Ccy          string        `json:"ccy"`
+		Interest     okex.Decimal  `json:"interest"`
+		InterestRate okex.Decimal  `json:"interestRate"` // hourly rate
+		Liab         okex.Decimal  `json:"liab"`
+		TS           okex.JSONTime `json:"ts"`
+	}
+	VIPLoanOrder struct {
+		OrdID           string            `json:"ordId"`
+		Ccy             string            `json:"ccy"`
+		State           okex.VIPLoanState `json:"state"`
+		OrigRate        okex.Decimal      `json:"origRate"`
+		CurRate         okex.Decimal      `json:"curRate"`
+		DueAmt          okex.Decimal      `json:"dueAmt"`
+		BorrowAmt       okex.Decimal      `json:"borrowAmt"`
+		RepayAmt        okex.Decimal      `json:"repayAmt"`
+		NextRefreshTime okex.JSONTime     `json:"nextRefreshTime"`
+		TS              okex.JSONTime     `json:"ts"`
+	}
+	VIPLoanOrderDetail struct {
+		Ccy        string        `json:"ccy"`
+		Type       string        `json:"type"` // borrow, repay or interest_rate_change
+		Rate       okex.Decimal  `json:"rate"`
+		Amt        okex.Decimal  `json:"amt"`
+		FailReason string        `json:"failReason"`
+		TS         okex.JSONTime `json:"ts"`
+	}
+	PositionBuilder struct {
+		Eq           okex.Decimal               `json:"eq"` // adjusted equity in USD
+		TotalImr     okex.Decimal               `json:"totalImr"`
+		TotalMmr     okex.Decimal               `json:"totalMmr"`
+		BorrowMmr    okex.Decimal               `json:"borrowMmr"`
+		DerivMmr     okex.Decimal               `json:"derivMmr"`
+		MarginRatio  okex.Decimal               `json:"marginRatio"`
+		Upl          okex.Decimal               `json:"upl"`
+		AcctLever    okex.Decimal               `json:"acctLever"`
+		TS           okex.JSONTime              `json:"ts"`
+		Assets       []*PositionBuilderAsset    `json:"assets"`
+		RiskUnitData []*PositionBuilderRiskUnit `json:"riskUnitData"`
+		Positions    []*PositionBuilderPosition `json:"positions"`
+	}
+	PositionBuilderAsset struct {
+		Ccy       string       `json:"ccy"`
+		AvailEq   okex.Decimal `json:"availEq"`
+		SpotInUse okex.Decimal `json:"spotInUse"`
+		BorrowMmr okex.Decimal `json:"borrowMmr"`
+		BorrowImr okex.Decimal `json:"borrowImr"`
+	}
+	// PositionBuilderRiskUnit is the portfolio margin risk of an underlying, Mr1 to Mr9 are the stress test results
+	PositionBuilderRiskUnit struct {
+		RiskUnit   string                      `json:"riskUnit"`
+		IndexUsd   okex.Decimal                `json:"indexUsd"`
+		Mmr        okex.Decimal                `json:"mmr"`
+		Imr        okex.Decimal                `json:"imr"`
+		Upl        okex.Decimal                `json:"upl"`
+		Mr1        okex.Decimal                `json:"mr1"`
+		Mr2        okex.Decimal                `json:"mr2"`
+		Mr3        okex.Decimal                `json:"mr3"`
+		Mr4        okex.Decimal                `json:"mr4"`
+		Mr5        okex.Decimal                `json:"mr5"`
+		Mr6        okex.Decimal                `json:"mr6"`
+		Mr7        okex.Decimal                `json:"mr7"`
+		Mr8        okex.Decimal                `json:"mr8"`
+		Mr9        okex.Decimal                `json:"mr9"`
+		Delta      okex.Decimal                `json:"delta"`
+		Gamma      okex.Decimal                `json:"gamma"`
+		Theta      okex.Decimal                `json:"theta"`
+		Vega       okex.Decimal                `json:"vega"`
+		Portfolios []*PositionBuilderPortfolio `json:"portfolios"`
+	}
+	PositionBuilderPortfolio struct {
+		InstID      string              `json:"instId"`
+		InstType    okex.InstrumentType `json:"instType"`
+		Amt         okex.Decimal        `json:"amt"`
+		Pos         okex.Decimal        `json:"pos"`
+		AvgPx       okex.Decimal        `json:"avgPx"`
+		NotionalUsd okex.Decimal        `json:"notionalUsd"`
+		IsRealPos   bool                `json:"isRealPos"`
+		Delta       okex.Decimal        `json:"delta"`
+		Gamma       okex.Decimal        `json:"gamma"`
+		Theta       okex.Decimal        `json:"theta"`
+		Vega        okex.Decimal        `json:"vega"`
+	}
+	PositionBuilderPosition struct {
+		InstID      string              `json:"instId"`
+		InstType    okex.InstrumentType `json:"instType"`
+		Pos         okex.Decimal        `json:"pos"`
+		AvgPx       okex.Decimal        `json:"avgPx"`
+		Lever       okex.Decimal        `json:"lever"`
+		NotionalUsd okex.Decimal        `json:"notionalUsd"`
+		Imr         okex.Decimal        `json:"imr"`
+		Mmr         okex.Decimal        `json:"mmr"`
+		IsRealPos   bool                `json:"isRealPos"`
+	}
 )
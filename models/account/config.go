@@ -0,0 +1,41 @@
+package account
+
+import (
+	"strings"
+
+	okex "github.com/pefish/go-okx"
+)
+
+// Permissions returns the permissions of the api key
+func (c *Config) Permissions() []okex.APIKeyAccess {
+	res := make([]okex.APIKeyAccess, 0)
+	for _, p := range split(c.Perm) {
+		res = append(res, okex.APIKeyAccess(p))
+	}
+	return res
+}
+
+// HasPermission reports whether the api key has the permission p
+func (c *Config) HasPermission(p okex.APIKeyAccess) bool {
+	for _, v := range c.Permissions() {
+		if v == p {
+			return true
+		}
+	}
+	return false
+}
+
+// IPs returns the ips the api key is bound to, empty when it's not bound
+func (c *Config) IPs() []string {
+	return split(c.IP)
+}
+
+func split(s string) []string {
+	res := make([]string, 0)
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
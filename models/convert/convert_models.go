@@ -0,0 +1,51 @@
+package convert
+
+import (
+	"github.com/pefish/go-okx"
+)
+
+type (
+	Currency struct {
+		Ccy string       `json:"ccy"`
+		Min okex.Decimal `json:"min"`
+		Max okex.Decimal `json:"max"`
+	}
+	CurrencyPair struct {
+		InstID      string       `json:"instId"`
+		BaseCcy     string       `json:"baseCcy"`
+		BaseCcyMin  okex.Decimal `json:"baseCcyMin"`
+		BaseCcyMax  okex.Decimal `json:"baseCcyMax"`
+		QuoteCcy    string       `json:"quoteCcy"`
+		QuoteCcyMin okex.Decimal `json:"quoteCcyMin"`
+		QuoteCcyMax okex.Decimal `json:"quoteCcyMax"`
+	}
+	Quote struct {
+		QuoteID   string         `json:"quoteId"`
+		ClQReqID  string         `json:"clQReqId"`
+		BaseCcy   string         `json:"baseCcy"`
+		QuoteCcy  string         `json:"quoteCcy"`
+		Side      okex.OrderSide `json:"side"`
+		OrigRfqSz okex.Decimal   `json:"origRfqSz"`
+		RfqSz     okex.Decimal   `json:"rfqSz"`
+		RfqSzCcy  string         `json:"rfqSzCcy"`
+		CnvtPx    okex.Decimal   `json:"cnvtPx"`
+		BaseSz    okex.Decimal   `json:"baseSz"`
+		QuoteSz   okex.Decimal   `json:"quoteSz"`
+		TtlMs     okex.JSONInt64 `json:"ttlMs"`
+		QuoteTime okex.JSONTime  `json:"quoteTime"`
+	}
+	Trade struct {
+		TradeID     string            `json:"tradeId"`
+		QuoteID     string            `json:"quoteId"`
+		ClTReqID    string            `json:"clTReqId"`
+		State       okex.ConvertState `json:"state"`
+		InstID      string            `json:"instId"`
+		BaseCcy     string            `json:"baseCcy"`
+		QuoteCcy    string            `json:"quoteCcy"`
+		Side        okex.OrderSide    `json:"side"`
+		FillPx      okex.Decimal      `json:"fillPx"`
+		FillBaseSz  okex.Decimal      `json:"fillBaseSz"`
+		FillQuoteSz okex.Decimal      `json:"fillQuoteSz"`
+		TS          okex.JSONTime     `json:"ts"`
+	}
+)
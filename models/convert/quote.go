@@ -0,0 +1,13 @@
+package convert
+
+import "time"
+
+// ExpiresAt returns when the quote can no longer be traded
+func (q *Quote) ExpiresAt() time.Time {
+	return time.Time(q.QuoteTime).Add(time.Duration(q.TtlMs) * time.Millisecond)
+}
+
+// Expired reports whether the quote expired, margin is subtracted from its lifetime to allow for the trade request to arrive
+func (q *Quote) Expired(margin time.Duration) bool {
+	return !time.Now().Before(q.ExpiresAt().Add(-margin))
+}
@@ -0,0 +1,76 @@
+package copytrading
+
+import (
+	"github.com/pefish/go-okx"
+)
+
+type (
+	// SubPosition is a lead position, the close fields are only set by the history
+	SubPosition struct {
+		InstType    okex.InstrumentType `json:"instType"`
+		InstID      string              `json:"instId"`
+		SubPosID    string              `json:"subPosId"`
+		PosSide     okex.PositionSide   `json:"posSide"`
+		MgnMode     okex.MarginMode     `json:"mgnMode"`
+		Lever       okex.Decimal        `json:"lever"`
+		OpenOrdID   string              `json:"openOrdId"`
+		OpenAvgPx   okex.Decimal        `json:"openAvgPx"`
+		OpenTime    okex.JSONTime       `json:"openTime"`
+		SubPos      okex.Decimal        `json:"subPos"`
+		TpTriggerPx okex.Decimal        `json:"tpTriggerPx"`
+		SlTriggerPx okex.Decimal        `json:"slTriggerPx"`
+		TpOrdPx     okex.Decimal        `json:"tpOrdPx"`
+		SlOrdPx     okex.Decimal        `json:"slOrdPx"`
+		AlgoID      string              `json:"algoId"`
+		Margin      okex.Decimal        `json:"margin"`
+		Upl         okex.Decimal        `json:"upl"`
+		UplRatio    okex.Decimal        `json:"uplRatio"`
+		MarkPx      okex.Decimal        `json:"markPx"`
+		UniqueCode  string              `json:"uniqueCode"`
+		Ccy         string              `json:"ccy"`
+		CloseOrdID  string              `json:"closeOrdId,omitempty"`
+		CloseAvgPx  okex.Decimal        `json:"closeAvgPx,omitempty"`
+		CloseTime   okex.JSONTime       `json:"closeTime,omitempty"`
+		Pnl         okex.Decimal        `json:"pnl,omitempty"`
+		PnlRatio    okex.Decimal        `json:"pnlRatio,omitempty"`
+		Type        string              `json:"type,omitempty"` // how the position was closed
+	}
+	SubPositionAction struct {
+		SubPosID string `json:"subPosId"`
+		Tag      string `json:"tag"`
+	}
+	Instrument struct {
+		InstID  string `json:"instId"`
+		Enabled bool   `json:"enabled"`
+	}
+	ProfitSharingDetail struct {
+		Ccy              string              `json:"ccy"`
+		ProfitSharingAmt okex.Decimal        `json:"profitSharingAmt"`
+		ProfitSharingID  string              `json:"profitSharingId"`
+		NickName         string              `json:"nickName"`
+		PortLink         string              `json:"portLink"`
+		InstType         okex.InstrumentType `json:"instType"`
+		TS               okex.JSONTime       `json:"ts"`
+	}
+	TotalProfitSharing struct {
+		Ccy                   string              `json:"ccy"`
+		TotalProfitSharingAmt okex.Decimal        `json:"totalProfitSharingAmt"`
+		InstType              okex.InstrumentType `json:"instType"`
+	}
+	UnrealizedProfitSharing struct {
+		Ccy                        string              `json:"ccy"`
+		UnrealizedProfitSharingAmt okex.Decimal        `json:"unrealizedProfitSharingAmt"`
+		NickName                   string              `json:"nickName"`
+		PortLink                   string              `json:"portLink"`
+		InstType                   okex.InstrumentType `json:"instType"`
+		TS                         okex.JSONTime       `json:"ts"`
+	}
+	// LeadNotification tells a lead trader an order was not lead traded, InfoType tells why
+	LeadNotification struct {
+		InfoType   string              `json:"infoType"`
+		InstType   okex.InstrumentType `json:"instType"`
+		InstID     string              `json:"instId"`
+		SubPosID   string              `json:"subPosId"`
+		UniqueCode string              `json:"uniqueCode"`
+	}
+)
@@ -15,10 +15,10 @@ type (
 		CanInternal bool   `json:"canInternal"`
 	}
 	Balance struct {
-		Ccy       string `json:"ccy"`
-		Bal       string `json:"bal"`
-		FrozenBal string `json:"frozenBal"`
-		AvailBal  string `json:"availBal"`
+		Ccy       string       `json:"ccy"`
+		Bal       okex.Decimal `json:"bal"`
+		FrozenBal okex.Decimal `json:"frozenBal"`
+		AvailBal  okex.Decimal `json:"availBal"`
 	}
 	Transfer struct {
 		TransID string           `json:"transId"`
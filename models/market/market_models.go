@@ -1,6 +1,7 @@
 package market
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/pefish/go-okx"
@@ -44,33 +45,37 @@ type (
 		TS   okex.JSONTime      `json:"ts"`
 	}
 	OrderBookWs struct {
-		Asks     []*OrderBookEntity `json:"asks"`
-		Bids     []*OrderBookEntity `json:"bids"`
-		Checksum int                `json:"checksum"`
-		TS       okex.JSONTime      `json:"ts"`
+		Asks      []*OrderBookEntity `json:"asks"`
+		Bids      []*OrderBookEntity `json:"bids"`
+		Checksum  int                `json:"checksum"`
+		SeqID     int64              `json:"seqId"`
+		PrevSeqID int64              `json:"prevSeqId"`
+		TS        okex.JSONTime      `json:"ts"`
 	}
 	OrderBookEntity struct {
 		DepthPrice      float64
 		Size            float64
 		LiquidatedOrder int
 		OrderNumbers    int
+		RawDepthPrice   string // as sent by the server, checksums are computed on it
+		RawSize         string // as sent by the server, checksums are computed on it
 	}
 	Candle struct {
-		O           float64
-		H           float64
-		L           float64
-		C           float64
-		Vol         float64
-		VolCcy      float64
-		VolCcyQuote float64
+		O           okex.Decimal
+		H           okex.Decimal
+		L           okex.Decimal
+		C           okex.Decimal
+		Vol         okex.Decimal
+		VolCcy      okex.Decimal
+		VolCcyQuote okex.Decimal
 		TS          okex.JSONTime
 		Confirm     bool
 	}
 	IndexCandle struct {
-		O  float64
-		H  float64
-		L  float64
-		C  float64
+		O  okex.Decimal
+		H  okex.Decimal
+		L  okex.Decimal
+		C  okex.Decimal
 		TS okex.JSONTime
 	}
 	Trade struct {
@@ -101,33 +106,33 @@ type (
 	}
 )
 
+// UnmarshalJSON parses a ["px","sz","liquidated orders","orders"] level by hand, books channels push hundreds of them per message
 func (o *OrderBookEntity) UnmarshalJSON(buf []byte) error {
 	var (
-		dp, s, lo, on string
-		err           error
+		fields [4][]byte
+		err    error
 	)
-	tmp := []interface{}{&dp, &s, &lo, &on}
-	wantLen := len(tmp)
-	if err := json.Unmarshal(buf, &tmp); err != nil {
+	n, err := scanStrings(buf, fields[:])
+	if err != nil {
 		return err
 	}
-
-	if g, e := len(tmp), wantLen; g != e {
+	if g, e := n, len(fields); g != e {
 		return errors.New(fmt.Sprintf("wrong number of fields in OrderBookEntity: %d != %d", g, e))
 	}
-	o.DepthPrice, err = strconv.ParseFloat(dp, 64)
+	o.RawDepthPrice, o.RawSize = string(fields[0]), string(fields[1])
+	o.DepthPrice, err = strconv.ParseFloat(o.RawDepthPrice, 64)
 	if err != nil {
 		return err
 	}
-	o.Size, err = strconv.ParseFloat(s, 64)
+	o.Size, err = strconv.ParseFloat(o.RawSize, 64)
 	if err != nil {
 		return err
 	}
-	o.LiquidatedOrder, err = strconv.Atoi(lo)
+	o.LiquidatedOrder, err = atoi(fields[2])
 	if err != nil {
 		return err
 	}
-	o.OrderNumbers, err = strconv.Atoi(on)
+	o.OrderNumbers, err = atoi(fields[3])
 	if err != nil {
 		return err
 	}
@@ -135,71 +140,120 @@ func (o *OrderBookEntity) UnmarshalJSON(buf []byte) error {
 	return nil
 }
 
+// UnmarshalJSON parses a ["ts","o","h","l","c","vol","volCcy","volCcyQuote","confirm"] candle by hand
 func (c *Candle) UnmarshalJSON(buf []byte) error {
 	var (
-		o, h, l, cl, vol, volCcy, ts, confirm, volCcyQuote string
-		err                                                error
+		fields [9][]byte
+		err    error
 	)
-	tmp := []interface{}{&ts, &o, &h, &l, &cl, &vol, &volCcy, &volCcyQuote, &confirm}
-	wantLen := len(tmp)
-	if err := json.Unmarshal(buf, &tmp); err != nil {
+	n, err := scanStrings(buf, fields[:])
+	if err != nil {
 		return err
 	}
-
-	if g, e := len(tmp), wantLen; g != e {
+	if g, e := n, len(fields); g != e {
 		return errors.New(fmt.Sprintf("wrong number of fields in Candle: %d != %d", g, e))
 	}
 
-	timestamp, err := strconv.ParseInt(ts, 10, 64)
+	timestamp, err := strconv.ParseInt(string(fields[0]), 10, 64)
 	if err != nil {
 		return err
 	}
 	*(*time.Time)(&c.TS) = time.UnixMilli(timestamp)
 
-	c.O, err = strconv.ParseFloat(o, 64)
-	if err != nil {
-		return err
+	for i, dst := range []*okex.Decimal{&c.O, &c.H, &c.L, &c.C, &c.Vol, &c.VolCcy, &c.VolCcyQuote} {
+		*dst, err = okex.NewDecimal(string(fields[i+1]))
+		if err != nil {
+			return err
+		}
 	}
 
-	c.H, err = strconv.ParseFloat(h, 64)
-	if err != nil {
-		return err
-	}
-
-	c.L, err = strconv.ParseFloat(l, 64)
-	if err != nil {
-		return err
-	}
-
-	c.C, err = strconv.ParseFloat(cl, 64)
-	if err != nil {
-		return err
-	}
+	c.Confirm = string(fields[8]) != "0"
 
-	c.Vol, err = strconv.ParseFloat(vol, 64)
-	if err != nil {
-		return err
-	}
-
-	c.VolCcy, err = strconv.ParseFloat(volCcy, 64)
-	if err != nil {
-		return err
-	}
+	return nil
+}
 
-	c.VolCcyQuote, err = strconv.ParseFloat(volCcyQuote, 64)
-	if err != nil {
-		return err
-	}
+// UnmarshalJSON parses a ticker by hand, the tickers channel pushes one per instrument every 100ms
+func (t *Ticker) UnmarshalJSON(buf []byte) error {
+	err := scanObject(buf, func(key, value []byte) error {
+		switch string(key) {
+		case "instId":
+			t.InstID = string(value)
+		case "instType":
+			t.InstType = okex.InstrumentType(value)
+		case "ts":
+			return t.TS.UnmarshalJSON(value)
+		default:
+			if dst := t.float(key); dst != nil {
+				return dst.UnmarshalJSON(value)
+			}
+		}
+		return nil
+	})
+	if err == errNotFlat {
+		type plain Ticker
+		return json.Unmarshal(buf, (*plain)(t))
+	}
+	return err
+}
 
-	if confirm == "0" {
-		c.Confirm = false
-	} else {
-		c.Confirm = true
+func (t *Ticker) float(key []byte) *okex.JSONFloat64 {
+	switch string(key) {
+	case "last":
+		return &t.Last
+	case "lastSz":
+		return &t.LastSz
+	case "askPx":
+		return &t.AskPx
+	case "askSz":
+		return &t.AskSz
+	case "bidPx":
+		return &t.BidPx
+	case "bidSz":
+		return &t.BidSz
+	case "open24h":
+		return &t.Open24h
+	case "high24h":
+		return &t.High24h
+	case "low24h":
+		return &t.Low24h
+	case "volCcy24h":
+		return &t.VolCcy24h
+	case "vol24h":
+		return &t.Vol24h
+	case "sodUtc0":
+		return &t.SodUtc0
+	case "sodUtc8":
+		return &t.SodUtc8
 	}
-
 	return nil
 }
 
+// UnmarshalJSON parses a trade by hand, the trades channel pushes every fill of the instrument
+func (t *Trade) UnmarshalJSON(buf []byte) error {
+	err := scanObject(buf, func(key, value []byte) error {
+		switch string(key) {
+		case "instId":
+			t.InstID = string(value)
+		case "tradeId":
+			return t.TradeID.UnmarshalJSON(value)
+		case "px":
+			return t.Px.UnmarshalJSON(value)
+		case "sz":
+			return t.Sz.UnmarshalJSON(value)
+		case "side":
+			t.Side = okex.TradeSide(value)
+		case "ts":
+			return t.TS.UnmarshalJSON(value)
+		}
+		return nil
+	})
+	if err == errNotFlat {
+		type plain Trade
+		return json.Unmarshal(buf, (*plain)(t))
+	}
+	return err
+}
+
 func (c *IndexCandle) UnmarshalJSON(buf []byte) error {
 	var (
 		o, h, l, cl, ts string
@@ -221,25 +275,152 @@ func (c *IndexCandle) UnmarshalJSON(buf []byte) error {
 	}
 	*(*time.Time)(&c.TS) = time.UnixMilli(timestamp)
 
-	c.O, err = strconv.ParseFloat(o, 64)
+	c.O, err = okex.NewDecimal(o)
 	if err != nil {
 		return err
 	}
 
-	c.H, err = strconv.ParseFloat(h, 64)
+	c.H, err = okex.NewDecimal(h)
 	if err != nil {
 		return err
 	}
 
-	c.L, err = strconv.ParseFloat(l, 64)
+	c.L, err = okex.NewDecimal(l)
 	if err != nil {
 		return err
 	}
 
-	c.C, err = strconv.ParseFloat(cl, 64)
+	c.C, err = okex.NewDecimal(cl)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// scanStrings splits a json array of plain strings into dst without reflection and returns the number of elements.
+// Escaped strings are not supported, the server never sends them in numeric arrays.
+func scanStrings(buf []byte, dst [][]byte) (int, error) {
+	i := skipSpaces(buf, 0)
+	if i >= len(buf) || buf[i] != '[' {
+		return 0, errors.New("json array expected")
+	}
+	i = skipSpaces(buf, i+1)
+	if i < len(buf) && buf[i] == ']' {
+		return 0, nil
+	}
+	n := 0
+	for {
+		v, next, err := scanString(buf, i)
+		if err != nil {
+			return n, err
+		}
+		if n < len(dst) {
+			dst[n] = v
+		}
+		n++
+		i = skipSpaces(buf, next)
+		if i >= len(buf) {
+			return n, errors.New("unterminated json array")
+		}
+		switch buf[i] {
+		case ',':
+			i = skipSpaces(buf, i+1)
+		case ']':
+			return n, nil
+		default:
+			return n, errors.New(fmt.Sprintf("unexpected %q at offset %d", buf[i], i))
+		}
+	}
+}
+
+// scanObject calls fn with the key and the value of every field of a flat json object of plain strings, without
+// reflection. It returns errNotFlat for other values or escaped strings, the caller decodes those with encoding/json.
+func scanObject(buf []byte, fn func(key, value []byte) error) error {
+	i := skipSpaces(buf, 0)
+	if i >= len(buf) || buf[i] != '{' {
+		return errors.New("json object expected")
+	}
+	i = skipSpaces(buf, i+1)
+	if i < len(buf) && buf[i] == '}' {
+		return nil
+	}
+	for {
+		key, next, err := scanString(buf, i)
+		if err != nil {
+			return err
+		}
+		i = skipSpaces(buf, next)
+		if i >= len(buf) || buf[i] != ':' {
+			return errors.New(fmt.Sprintf("':' expected at offset %d", i))
+		}
+		i = skipSpaces(buf, i+1)
+		if i >= len(buf) || buf[i] != '"' {
+			return errNotFlat
+		}
+		value, next, err := scanString(buf, i)
+		if err == errEscaped {
+			return errNotFlat
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(key, value); err != nil {
+			return err
+		}
+		i = skipSpaces(buf, next)
+		if i >= len(buf) {
+			return errors.New("unterminated json object")
+		}
+		switch buf[i] {
+		case ',':
+			i = skipSpaces(buf, i+1)
+		case '}':
+			return nil
+		default:
+			return errors.New(fmt.Sprintf("unexpected %q at offset %d", buf[i], i))
+		}
+	}
+}
+
+var (
+	errEscaped = errors.New("escaped json string is not supported")
+	errNotFlat = errors.New("json object of plain strings expected")
+)
+
+// scanString returns the content of the json string at buf[i] and the offset after it
+func scanString(buf []byte, i int) ([]byte, int, error) {
+	if i >= len(buf) || buf[i] != '"' {
+		return nil, i, errors.New(fmt.Sprintf("json string expected at offset %d", i))
+	}
+	end := bytes.IndexByte(buf[i+1:], '"')
+	if end < 0 {
+		return nil, i, errors.New("unterminated json string")
+	}
+	v := buf[i+1 : i+1+end]
+	if bytes.IndexByte(v, '\\') >= 0 {
+		return nil, i, errEscaped
+	}
+	return v, i + end + 2, nil
+}
+
+func skipSpaces(buf []byte, i int) int {
+	for i < len(buf) && (buf[i] == ' ' || buf[i] == '\t' || buf[i] == '\n' || buf[i] == '\r') {
+		i++
+	}
+	return i
+}
+
+func atoi(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, errors.New("empty integer")
+	}
+	n := 0
+	for _, d := range b {
+		if d < '0' || d > '9' {
+			return strconv.Atoi(string(b))
+		}
+		n = n*10 + int(d-'0')
+	}
+	return n, nil
+}
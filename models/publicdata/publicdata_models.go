@@ -6,29 +6,34 @@ import (
 
 type (
 	Instrument struct {
-		InstID     string               `json:"instId"`
-		Uly        string               `json:"uly,omitempty"`
-		InstFamily string               `json:"instFamily,omitempty"`
-		BaseCcy    string               `json:"baseCcy,omitempty"`
-		QuoteCcy   string               `json:"quoteCcy,omitempty"`
-		SettleCcy  string               `json:"settleCcy,omitempty"`
-		CtValCcy   string               `json:"ctValCcy,omitempty"`
-		CtVal      okex.JSONFloat64     `json:"ctVal,omitempty"`
-		CtMult     okex.JSONFloat64     `json:"ctMult,omitempty"`
-		Stk        okex.JSONFloat64     `json:"stk,omitempty"`
-		TickSz     okex.JSONFloat64     `json:"tickSz,omitempty"`
-		LotSz      okex.JSONFloat64     `json:"lotSz,omitempty"`
-		MinSz      okex.JSONFloat64     `json:"minSz,omitempty"`
-		Lever      okex.JSONFloat64     `json:"lever"`
-		InstType   okex.InstrumentType  `json:"instType"`
-		Category   okex.FeeCategory     `json:"category,string"`
-		OptType    okex.OptionType      `json:"optType,omitempty"`
-		ListTime   okex.JSONTime        `json:"listTime"`
-		ExpTime    okex.JSONTime        `json:"expTime,omitempty"`
-		CtType     okex.ContractType    `json:"ctType,omitempty"`
-		Alias      okex.AliasType       `json:"alias,omitempty"`
-		State      okex.InstrumentState `json:"state"`
-		MaxMktSz   okex.JSONFloat64     `json:"maxMktSz,omitempty"`
+		InstID       string               `json:"instId"`
+		Uly          string               `json:"uly,omitempty"`
+		InstFamily   string               `json:"instFamily,omitempty"`
+		BaseCcy      string               `json:"baseCcy,omitempty"`
+		QuoteCcy     string               `json:"quoteCcy,omitempty"`
+		SettleCcy    string               `json:"settleCcy,omitempty"`
+		CtValCcy     string               `json:"ctValCcy,omitempty"`
+		CtVal        okex.Decimal         `json:"ctVal,omitempty"`
+		CtMult       okex.Decimal         `json:"ctMult,omitempty"`
+		Stk          okex.Decimal         `json:"stk,omitempty"`
+		TickSz       okex.Decimal         `json:"tickSz,omitempty"`
+		LotSz        okex.Decimal         `json:"lotSz,omitempty"`
+		MinSz        okex.Decimal         `json:"minSz,omitempty"`
+		Lever        okex.JSONFloat64     `json:"lever"`
+		InstType     okex.InstrumentType  `json:"instType"`
+		Category     okex.FeeCategory     `json:"category,string"`
+		OptType      okex.OptionType      `json:"optType,omitempty"`
+		ListTime     okex.JSONTime        `json:"listTime"`
+		ExpTime      okex.JSONTime        `json:"expTime,omitempty"`
+		CtType       okex.ContractType    `json:"ctType,omitempty"`
+		Alias        okex.AliasType       `json:"alias,omitempty"`
+		State        okex.InstrumentState `json:"state"`
+		MaxMktSz     okex.Decimal         `json:"maxMktSz,omitempty"`
+		MaxLmtSz     okex.Decimal         `json:"maxLmtSz,omitempty"`
+		MaxTwapSz    okex.Decimal         `json:"maxTwapSz,omitempty"`
+		MaxIcebergSz okex.Decimal         `json:"maxIcebergSz,omitempty"`
+		MaxTriggerSz okex.Decimal         `json:"maxTriggerSz,omitempty"`
+		MaxStopSz    okex.Decimal         `json:"maxStopSz,omitempty"`
 	}
 	DeliveryExerciseHistory struct {
 		Details []*DeliveryExerciseHistoryDetails `json:"details"`
@@ -125,6 +130,7 @@ type (
 	PositionTier struct {
 		InstID       string              `json:"instId"`
 		Uly          string              `json:"uly,omitempty"`
+		InstFamily   string              `json:"instFamily,omitempty"`
 		InstType     okex.InstrumentType `json:"instType"`
 		Tier         okex.JSONInt64      `json:"tier"`
 		MinSz        okex.JSONFloat64    `json:"minSz"`
@@ -162,4 +168,11 @@ type (
 		Begin       okex.JSONTime `json:"begin"`
 		End         okex.JSONTime `json:"end"`
 	}
+	ContractCoinConversion struct {
+		InstID string           `json:"instId"`
+		Px     okex.Decimal     `json:"px"`
+		Sz     okex.Decimal     `json:"sz"`
+		Type   okex.ConvertType `json:"type"`
+		Unit   okex.ConvertUnit `json:"unit,omitempty"`
+	}
 )
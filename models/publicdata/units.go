@@ -0,0 +1,117 @@
+package publicdata
+
+import (
+	okex "github.com/pefish/go-okx"
+	"github.com/pkg/errors"
+)
+
+// divPlaces is the precision of the conversions dividing by a price or a contract value before rounding to the lot size
+const divPlaces = 18
+
+// ContractValue returns the value of one contract in CtValCcy, ctVal * ctMult. It's 1 for spot and margin, sizes are in base currency.
+func (i *Instrument) ContractValue() okex.Decimal {
+	if i.InstType == okex.SpotInstrument || i.InstType == okex.MarginInstrument {
+		return "1"
+	}
+	if i.CtMult == "" {
+		return i.CtVal
+	}
+	return i.CtVal.Mul(i.CtMult)
+}
+
+// IsInverse reports whether the contracts are valued in the quote currency (USD) and margined in coin, like BTC-USD-SWAP
+func (i *Instrument) IsInverse() bool {
+	return i.CtType == okex.ContractInverseType
+}
+
+// ContractsToCoin returns the base currency amount of contracts at px.
+// px is only used by inverse contracts, the amount of linear, option and spot contracts doesn't depend on the price.
+func (i *Instrument) ContractsToCoin(contracts, px okex.Decimal) (okex.Decimal, error) {
+	cv, err := i.contractValue()
+	if err != nil {
+		return "", err
+	}
+	v := contracts.Mul(cv)
+	if !i.IsInverse() {
+		return v.Trim(), nil
+	}
+	if err := checkPx(px); err != nil {
+		return "", err
+	}
+	return v.Div(px, divPlaces, okex.RoundHalfEven).Trim(), nil
+}
+
+// ContractsToQuote returns the quote currency notional of contracts at px, the underlying price for options
+func (i *Instrument) ContractsToQuote(contracts, px okex.Decimal) (okex.Decimal, error) {
+	cv, err := i.contractValue()
+	if err != nil {
+		return "", err
+	}
+	v := contracts.Mul(cv)
+	if i.IsInverse() {
+		return v.Trim(), nil
+	}
+	if err := checkPx(px); err != nil {
+		return "", err
+	}
+	return v.Mul(px).Trim(), nil
+}
+
+// CoinToContracts returns the number of contracts worth coin base currency at px, rounded to the lot size with mode.
+// px is only used by inverse contracts.
+func (i *Instrument) CoinToContracts(coin, px okex.Decimal, mode okex.RoundingMode) (okex.Decimal, error) {
+	cv, err := i.contractValue()
+	if err != nil {
+		return "", err
+	}
+	if i.IsInverse() {
+		if err := checkPx(px); err != nil {
+			return "", err
+		}
+		coin = coin.Mul(px)
+	}
+	return coin.Div(cv, divPlaces, mode).RoundStep(i.LotSz, mode), nil
+}
+
+// QuoteToContracts returns the number of contracts worth quote notional at px, rounded to the lot size with mode.
+// px is the underlying price for options and is not used by inverse contracts.
+func (i *Instrument) QuoteToContracts(quote, px okex.Decimal, mode okex.RoundingMode) (okex.Decimal, error) {
+	cv, err := i.contractValue()
+	if err != nil {
+		return "", err
+	}
+	if !i.IsInverse() {
+		if err := checkPx(px); err != nil {
+			return "", err
+		}
+		cv = cv.Mul(px)
+	}
+	return quote.Div(cv, divPlaces, mode).RoundStep(i.LotSz, mode), nil
+}
+
+// OrderSizeToCoin returns the base currency amount of an order sz, spot sizes are in quote currency when tgtCcy is quote_ccy.
+// tgtCcy is taken as given, the quote_ccy default of spot market buys is not applied.
+func (i *Instrument) OrderSizeToCoin(sz, px okex.Decimal, tgtCcy okex.QuantityType) (okex.Decimal, error) {
+	if i.InstType == okex.SpotInstrument && tgtCcy == okex.QuantityQuoteCcy {
+		if err := checkPx(px); err != nil {
+			return "", err
+		}
+		return sz.Div(px, divPlaces, okex.RoundHalfEven).Trim(), nil
+	}
+	return i.ContractsToCoin(sz, px)
+}
+
+func (i *Instrument) contractValue() (okex.Decimal, error) {
+	cv := i.ContractValue()
+	if cv.Sign() <= 0 {
+		return "", errors.Errorf("%s has no contract value", i.InstID)
+	}
+	return cv, nil
+}
+
+func checkPx(px okex.Decimal) error {
+	if px.Sign() <= 0 {
+		return errors.Errorf("invalid price %s", px)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package publicdata
+
+import (
+	"testing"
+
+	okex "github.com/pefish/go-okx"
+)
+
+var (
+	spot = &Instrument{InstID: "BTC-USDT", InstType: okex.SpotInstrument, LotSz: "0.00000001"}
+	// 0.01 BTC per contract
+	linear = &Instrument{InstID: "BTC-USDT-SWAP", InstType: okex.SwapInstrument, CtType: okex.ContractLinearType,
+		CtVal: "0.01", CtMult: "1", CtValCcy: "BTC", LotSz: "1"}
+	// 100 USD per contract
+	inverse = &Instrument{InstID: "BTC-USD-SWAP", InstType: okex.SwapInstrument, CtType: okex.ContractInverseType,
+		CtVal: "100", CtMult: "1", CtValCcy: "USD", LotSz: "1"}
+	// 1 * 0.01 BTC per contract, valued at the underlying price
+	option = &Instrument{InstID: "BTC-USD-250328-90000-C", InstType: okex.OptionsInstrument,
+		CtVal: "1", CtMult: "0.01", CtValCcy: "BTC", LotSz: "1"}
+)
+
+func TestContractValue(t *testing.T) {
+	tests := []struct {
+		i    *Instrument
+		want okex.Decimal
+	}{
+		{spot, "1"},
+		{&Instrument{InstType: okex.MarginInstrument, CtVal: "5"}, "1"},
+		{linear, "0.01"},
+		{inverse, "100"},
+		{option, "0.01"},
+		{&Instrument{InstType: okex.FuturesInstrument, CtVal: "10"}, "10"},
+	}
+	for _, tt := range tests {
+		if got := tt.i.ContractValue(); !got.Equal(tt.want) {
+			t.Errorf("%s %s: ContractValue() = %s, want %s", tt.i.InstType, tt.i.InstID, got, tt.want)
+		}
+	}
+}
+
+func TestContractsConversions(t *testing.T) {
+	tests := []struct {
+		i             *Instrument
+		contracts, px okex.Decimal
+		coin, quote   okex.Decimal
+		coinUsesPx    bool
+		quoteUsesPx   bool
+	}{
+		// 10 * 0.01 BTC = 0.1 BTC, worth 5000 USDT at 50000
+		{i: linear, contracts: "10", px: "50000", coin: "0.1", quote: "5000", quoteUsesPx: true},
+		// 10 * 100 USD = 1000 USD, worth 0.02 BTC at 50000
+		{i: inverse, contracts: "10", px: "50000", coin: "0.02", quote: "1000", coinUsesPx: true},
+		// 10 * 0.01 BTC = 0.1 BTC, worth 5000 USD at an underlying price of 50000
+		{i: option, contracts: "10", px: "50000", coin: "0.1", quote: "5000", quoteUsesPx: true},
+		{i: spot, contracts: "0.5", px: "50000", coin: "0.5", quote: "25000", quoteUsesPx: true},
+	}
+	for _, tt := range tests {
+		coin, err := tt.i.ContractsToCoin(tt.contracts, tt.px)
+		if err != nil || !coin.Equal(tt.coin) {
+			t.Errorf("%s: ContractsToCoin(%s, %s) = %s, %v, want %s", tt.i.InstID, tt.contracts, tt.px, coin, err, tt.coin)
+		}
+		quote, err := tt.i.ContractsToQuote(tt.contracts, tt.px)
+		if err != nil || !quote.Equal(tt.quote) {
+			t.Errorf("%s: ContractsToQuote(%s, %s) = %s, %v, want %s", tt.i.InstID, tt.contracts, tt.px, quote, err, tt.quote)
+		}
+		// back to the contracts, exact amounts don't depend on the rounding mode
+		for _, mode := range []okex.RoundingMode{okex.RoundDown, okex.RoundUp} {
+			if got, err := tt.i.CoinToContracts(tt.coin, tt.px, mode); err != nil || !got.Equal(tt.contracts) {
+				t.Errorf("%s: CoinToContracts(%s, %s, %d) = %s, %v, want %s", tt.i.InstID, tt.coin, tt.px, mode, got, err, tt.contracts)
+			}
+			if got, err := tt.i.QuoteToContracts(tt.quote, tt.px, mode); err != nil || !got.Equal(tt.contracts) {
+				t.Errorf("%s: QuoteToContracts(%s, %s, %d) = %s, %v, want %s", tt.i.InstID, tt.quote, tt.px, mode, got, err, tt.contracts)
+			}
+		}
+		// the price is only required by the conversions using it
+		if _, err := tt.i.ContractsToCoin(tt.contracts, ""); (err != nil) != tt.coinUsesPx {
+			t.Errorf("%s: ContractsToCoin without a price error = %v", tt.i.InstID, err)
+		}
+		if _, err := tt.i.ContractsToQuote(tt.contracts, "0"); (err != nil) != tt.quoteUsesPx {
+			t.Errorf("%s: ContractsToQuote at 0 error = %v", tt.i.InstID, err)
+		}
+		if _, err := tt.i.CoinToContracts(tt.coin, "-1", okex.RoundDown); (err != nil) != tt.coinUsesPx {
+			t.Errorf("%s: CoinToContracts at -1 error = %v", tt.i.InstID, err)
+		}
+		if _, err := tt.i.QuoteToContracts(tt.quote, "", okex.RoundDown); (err != nil) != tt.quoteUsesPx {
+			t.Errorf("%s: QuoteToContracts without a price error = %v", tt.i.InstID, err)
+		}
+	}
+}
+
+func TestToContractsRounding(t *testing.T) {
+	tests := []struct {
+		name     string
+		convert  func(okex.RoundingMode) (okex.Decimal, error)
+		down, up okex.Decimal
+	}{
+		// 0.104 BTC is 10.4 contracts of 0.01 BTC
+		{"linear coin", func(m okex.RoundingMode) (okex.Decimal, error) { return linear.CoinToContracts("0.104", "50000", m) }, "10", "11"},
+		// 5200 USDT at 50000 is 0.104 BTC
+		{"linear quote", func(m okex.RoundingMode) (okex.Decimal, error) { return linear.QuoteToContracts("5200", "50000", m) }, "10", "11"},
+		// 0.021 BTC at 50000 is 1050 USD, 10.5 contracts of 100 USD
+		{"inverse coin", func(m okex.RoundingMode) (okex.Decimal, error) { return inverse.CoinToContracts("0.021", "50000", m) }, "10", "11"},
+		{"inverse quote", func(m okex.RoundingMode) (okex.Decimal, error) { return inverse.QuoteToContracts("1050", "", m) }, "10", "11"},
+		// 1000 USDT at 30000 is 0.0333… BTC, rounded to the 0.00000001 lot size
+		{"spot quote", func(m okex.RoundingMode) (okex.Decimal, error) { return spot.QuoteToContracts("1000", "30000", m) }, "0.03333333", "0.03333334"},
+	}
+	for _, tt := range tests {
+		down, err := tt.convert(okex.RoundDown)
+		if err != nil || !down.Equal(tt.down) {
+			t.Errorf("%s: rounded down = %s, %v, want %s", tt.name, down, err, tt.down)
+		}
+		up, err := tt.convert(okex.RoundUp)
+		if err != nil || !up.Equal(tt.up) {
+			t.Errorf("%s: rounded up = %s, %v, want %s", tt.name, up, err, tt.up)
+		}
+	}
+}
+
+func TestOrderSizeToCoin(t *testing.T) {
+	tests := []struct {
+		i      *Instrument
+		sz, px okex.Decimal
+		tgtCcy okex.QuantityType
+		want   okex.Decimal
+	}{
+		{spot, "0.5", "50000", okex.QuantityBaseCcy, "0.5"},
+		{spot, "0.5", "", "", "0.5"},
+		{spot, "100", "50000", okex.QuantityQuoteCcy, "0.002"},
+		// tgtCcy only applies to spot
+		{linear, "10", "50000", okex.QuantityQuoteCcy, "0.1"},
+		{inverse, "10", "50000", "", "0.02"},
+	}
+	for _, tt := range tests {
+		got, err := tt.i.OrderSizeToCoin(tt.sz, tt.px, tt.tgtCcy)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("%s: OrderSizeToCoin(%s, %s, %q) = %s, %v, want %s", tt.i.InstID, tt.sz, tt.px, tt.tgtCcy, got, err, tt.want)
+		}
+	}
+	if _, err := spot.OrderSizeToCoin("100", "", okex.QuantityQuoteCcy); err == nil {
+		t.Error("quote size without a price succeeded")
+	}
+}
+
+func TestNoContractValue(t *testing.T) {
+	i := &Instrument{InstID: "BTC-USDT-SWAP", InstType: okex.SwapInstrument, CtType: okex.ContractLinearType, LotSz: "1"}
+	if _, err := i.ContractsToCoin("1", "50000"); err == nil {
+		t.Error("ContractsToCoin without ctVal succeeded")
+	}
+	if _, err := i.ContractsToQuote("1", "50000"); err == nil {
+		t.Error("ContractsToQuote without ctVal succeeded")
+	}
+	if _, err := i.CoinToContracts("1", "50000", okex.RoundDown); err == nil {
+		t.Error("CoinToContracts without ctVal succeeded")
+	}
+	if _, err := i.QuoteToContracts("1", "50000", okex.RoundDown); err == nil {
+		t.Error("QuoteToContracts without ctVal succeeded")
+	}
+}
@@ -0,0 +1,126 @@
+package rfq
+
+import (
+	"github.com/pefish/go-okx"
+)
+
+type (
+	Counterparty struct {
+		TraderName string `json:"traderName"`
+		TraderCode string `json:"traderCode"`
+		Type       string `json:"type"`
+	}
+	Rfq struct {
+		RfqID                 string        `json:"rfqId"`
+		ClRfqID               string        `json:"clRfqId"`
+		Tag                   string        `json:"tag"`
+		TraderCode            string        `json:"traderCode"`
+		State                 okex.RfqState `json:"state"`
+		Counterparties        []string      `json:"counterparties"`
+		AllowPartialExecution bool          `json:"allowPartialExecution"`
+		Legs                  []*Leg        `json:"legs"`
+		ValidUntil            okex.JSONTime `json:"validUntil"`
+		CTime                 okex.JSONTime `json:"cTime"`
+		UTime                 okex.JSONTime `json:"uTime"`
+	}
+	Leg struct {
+		InstID  string            `json:"instId"`
+		TdMode  okex.TradeMode    `json:"tdMode"`
+		Ccy     string            `json:"ccy"`
+		Sz      okex.Decimal      `json:"sz"`
+		Side    okex.OrderSide    `json:"side"`
+		PosSide okex.PositionSide `json:"posSide"`
+		TgtCcy  okex.QuantityType `json:"tgtCcy"`
+	}
+	Quote struct {
+		QuoteID    string          `json:"quoteId"`
+		ClQuoteID  string          `json:"clQuoteId"`
+		RfqID      string          `json:"rfqId"`
+		ClRfqID    string          `json:"clRfqId"`
+		Tag        string          `json:"tag"`
+		TraderCode string          `json:"traderCode"`
+		QuoteSide  okex.OrderSide  `json:"quoteSide"`
+		State      okex.QuoteState `json:"state"`
+		Reason     string          `json:"reason"`
+		Legs       []*QuoteLeg     `json:"legs"`
+		ValidUntil okex.JSONTime   `json:"validUntil"`
+		CTime      okex.JSONTime   `json:"cTime"`
+		UTime      okex.JSONTime   `json:"uTime"`
+	}
+	QuoteLeg struct {
+		InstID  string            `json:"instId"`
+		TdMode  okex.TradeMode    `json:"tdMode"`
+		Ccy     string            `json:"ccy"`
+		Sz      okex.Decimal      `json:"sz"`
+		Px      okex.Decimal      `json:"px"`
+		Side    okex.OrderSide    `json:"side"`
+		PosSide okex.PositionSide `json:"posSide"`
+		TgtCcy  okex.QuantityType `json:"tgtCcy"`
+	}
+	CancelRfq struct {
+		RfqID   string         `json:"rfqId"`
+		ClRfqID string         `json:"clRfqId"`
+		SMsg    string         `json:"sMsg"`
+		SCode   okex.JSONInt64 `json:"sCode"`
+	}
+	CancelQuote struct {
+		QuoteID   string         `json:"quoteId"`
+		ClQuoteID string         `json:"clQuoteId"`
+		SMsg      string         `json:"sMsg"`
+		SCode     okex.JSONInt64 `json:"sCode"`
+	}
+	// BlockTrade is an executed quote of the account, TTraderCode is the taker and MTraderCode the maker
+	BlockTrade struct {
+		BlockTdID    string           `json:"blockTdId"`
+		RfqID        string           `json:"rfqId"`
+		ClRfqID      string           `json:"clRfqId"`
+		QuoteID      string           `json:"quoteId"`
+		ClQuoteID    string           `json:"clQuoteId"`
+		Tag          string           `json:"tag"`
+		TTraderCode  string           `json:"tTraderCode"`
+		MTraderCode  string           `json:"mTraderCode"`
+		IsSuccessful bool             `json:"isSuccessful"`
+		ErrorCode    string           `json:"errorCode"`
+		Legs         []*BlockTradeLeg `json:"legs"`
+		CTime        okex.JSONTime    `json:"cTime"`
+	}
+	BlockTradeLeg struct {
+		InstID  string         `json:"instId"`
+		Px      okex.Decimal   `json:"px"`
+		Sz      okex.Decimal   `json:"sz"`
+		Side    okex.OrderSide `json:"side"`
+		Fee     okex.Decimal   `json:"fee"`
+		FeeCcy  string         `json:"feeCcy"`
+		TradeID string         `json:"tradeId"`
+	}
+	// PublicBlockTrade is a structure executed by anyone, Side is the side of the taker
+	PublicBlockTrade struct {
+		BlockTdID string                 `json:"blockTdId"`
+		Strategy  string                 `json:"strategy"`
+		Legs      []*PublicBlockTradeLeg `json:"legs"`
+		CTime     okex.JSONTime          `json:"cTime"`
+	}
+	PublicBlockTradeLeg struct {
+		InstID  string         `json:"instId"`
+		Px      okex.Decimal   `json:"px"`
+		Sz      okex.Decimal   `json:"sz"`
+		Side    okex.OrderSide `json:"side"`
+		TradeID string         `json:"tradeId"`
+	}
+	// InstrumentBlockTrade is a block trade of a single instrument, the option fields are empty for other instruments
+	InstrumentBlockTrade struct {
+		InstID  string         `json:"instId"`
+		TradeID string         `json:"tradeId"`
+		Px      okex.Decimal   `json:"px"`
+		Sz      okex.Decimal   `json:"sz"`
+		Side    okex.OrderSide `json:"side"`
+		FillVol okex.Decimal   `json:"fillVol"`
+		FwdPx   okex.Decimal   `json:"fwdPx"`
+		IdxPx   okex.Decimal   `json:"idxPx"`
+		MarkPx  okex.Decimal   `json:"markPx"`
+		TS      okex.JSONTime  `json:"ts"`
+	}
+	MMPReset struct {
+		TS okex.JSONTime `json:"ts"`
+	}
+)
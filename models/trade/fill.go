@@ -0,0 +1,36 @@
+package trade
+
+import (
+	"time"
+
+	okex "github.com/pefish/go-okx"
+)
+
+// IsMaker reports whether the order was the maker of the trade
+func (f *Fill) IsMaker() bool {
+	return f.ExecType == okex.OrderMakerFlow
+}
+
+// FeePaid returns the fee charged in FeeCcy, zero for a rebate
+func (f *Fill) FeePaid() okex.Decimal {
+	if f.Fee.Sign() >= 0 {
+		return "0"
+	}
+	return f.Fee.Neg()
+}
+
+// Rebate returns the rebate received in FeeCcy, zero for a fee charged
+func (f *Fill) Rebate() okex.Decimal {
+	if f.Fee.Sign() <= 0 {
+		return "0"
+	}
+	return f.Fee
+}
+
+// Time returns when the trade happened, the ts of the fills channel and fillTime otherwise
+func (f *Fill) Time() time.Time {
+	if t := time.Time(f.FillTime); !t.IsZero() && t.UnixMilli() != 0 {
+		return t
+	}
+	return time.Time(f.TS)
+}
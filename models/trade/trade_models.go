@@ -28,6 +28,11 @@ type (
 	ClosePosition struct {
 		InstID  string            `json:"instId"`
 		PosSide okex.PositionSide `json:"posSide"`
+		ClOrdID string            `json:"clOrdId"`
+		Tag     string            `json:"tag"`
+	}
+	MassCancel struct {
+		Result bool `json:"result"`
 	}
 	Order struct {
 		InstID      string              `json:"instId"`
@@ -64,27 +69,51 @@ type (
 		UTime       okex.JSONTime       `json:"uTime"`
 		CTime       okex.JSONTime       `json:"cTime"`
 	}
-	TransactionDetail struct {
-		InstID   string              `json:"instId"`
-		OrdID    string              `json:"ordId"`
-		TradeID  string              `json:"tradeId"`
-		ClOrdID  string              `json:"clOrdId"`
-		BillID   string              `json:"billId"`
-		Tag      okex.JSONFloat64    `json:"tag"`
-		FillPx   okex.JSONFloat64    `json:"fillPx"`
-		FillSz   okex.JSONFloat64    `json:"fillSz"`
-		FeeCcy   okex.JSONFloat64    `json:"feeCcy"`
-		Fee      okex.JSONFloat64    `json:"fee"`
-		InstType okex.InstrumentType `json:"instType"`
-		Side     okex.OrderSide      `json:"side"`
-		PosSide  okex.PositionSide   `json:"posSide"`
-		ExecType okex.OrderFlowType  `json:"execType"`
-		TS       okex.JSONTime       `json:"ts"`
-	}
-	PlaceAlgoOrder struct {
-		AlgoID string         `json:"algoId"`
-		SMsg   string         `json:"sMsg"`
-		SCode  okex.JSONInt64 `json:"sCode"`
+	// Fill is a trade of an order, shared by Trade.GetTransactionDetails, Trade.GetFillsHistory and the fills channel.
+	// The fills channel only pushes the instrument, the price, the size, the side, the order ids, the exec type and the count.
+	Fill struct {
+		InstType      okex.InstrumentType `json:"instType"`
+		InstID        string              `json:"instId"`
+		TradeID       string              `json:"tradeId"`
+		OrdID         string              `json:"ordId"`
+		ClOrdID       string              `json:"clOrdId"`
+		BillID        string              `json:"billId"`
+		SubType       okex.BillSubType    `json:"subType,omitempty"`
+		Tag           string              `json:"tag"`
+		FillPx        okex.Decimal        `json:"fillPx"`
+		FillSz        okex.Decimal        `json:"fillSz"`
+		FillIdxPx     okex.Decimal        `json:"fillIdxPx"`
+		FillPnl       okex.Decimal        `json:"fillPnl"`
+		FillPxVol     okex.Decimal        `json:"fillPxVol"`
+		FillPxUsd     okex.Decimal        `json:"fillPxUsd"`
+		FillMarkVol   okex.Decimal        `json:"fillMarkVol"`
+		FillFwdPx     okex.Decimal        `json:"fillFwdPx"`
+		FillMarkPx    okex.Decimal        `json:"fillMarkPx"`
+		Side          okex.OrderSide      `json:"side"`
+		PosSide       okex.PositionSide   `json:"posSide"`
+		ExecType      okex.OrderFlowType  `json:"execType"`
+		FeeCcy        string              `json:"feeCcy"`
+		Fee           okex.Decimal        `json:"fee"` // negative for a fee charged, positive for a rebate
+		TradeQuoteCcy string              `json:"tradeQuoteCcy,omitempty"`
+		Count         okex.JSONInt64      `json:"count,omitempty"` // number of trades aggregated in a fills channel push
+		FillTime      okex.JSONTime       `json:"fillTime"`
+		TS            okex.JSONTime       `json:"ts"`
+	}
+	TransactionDetail = Fill
+	PlaceAlgoOrder    struct {
+		AlgoID      string         `json:"algoId"`
+		AlgoClOrdID string         `json:"algoClOrdId"`
+		ClOrdID     string         `json:"clOrdId"`
+		Tag         string         `json:"tag"`
+		SMsg        string         `json:"sMsg"`
+		SCode       okex.JSONInt64 `json:"sCode"`
+	}
+	AmendAlgoOrder struct {
+		AlgoID      string         `json:"algoId"`
+		AlgoClOrdID string         `json:"algoClOrdId"`
+		ReqID       string         `json:"reqId"`
+		SMsg        string         `json:"sMsg"`
+		SCode       okex.JSONInt64 `json:"sCode"`
 	}
 	CancelAlgoOrder struct {
 		AlgoID string         `json:"algoId"`
@@ -92,48 +121,109 @@ type (
 		SCode  okex.JSONInt64 `json:"sCode"`
 	}
 	AlgoOrder struct {
-		InstID       string              `json:"instId"`
-		Ccy          string              `json:"ccy"`
-		OrdID        string              `json:"ordId"`
-		AlgoID       string              `json:"algoId"`
-		ClOrdID      string              `json:"clOrdId"`
-		TradeID      string              `json:"tradeId"`
-		Tag          string              `json:"tag"`
-		Category     string              `json:"category"`
-		FeeCcy       string              `json:"feeCcy"`
-		RebateCcy    string              `json:"rebateCcy"`
-		TimeInterval string              `json:"timeInterval"`
-		Px           okex.JSONFloat64    `json:"px"`
-		PxVar        okex.JSONFloat64    `json:"pxVar"`
-		PxSpread     okex.JSONFloat64    `json:"pxSpread"`
-		PxLimit      okex.JSONFloat64    `json:"pxLimit"`
-		Sz           okex.JSONFloat64    `json:"sz"`
-		SzLimit      okex.JSONFloat64    `json:"szLimit"`
-		ActualSz     okex.JSONFloat64    `json:"actualSz"`
-		ActualPx     okex.JSONFloat64    `json:"actualPx"`
-		Pnl          okex.JSONFloat64    `json:"pnl"`
-		AccFillSz    okex.JSONFloat64    `json:"accFillSz"`
-		FillPx       okex.JSONFloat64    `json:"fillPx"`
-		FillSz       okex.JSONFloat64    `json:"fillSz"`
-		FillTime     okex.JSONFloat64    `json:"fillTime"`
-		AvgPx        okex.JSONFloat64    `json:"avgPx"`
-		Lever        okex.JSONFloat64    `json:"lever"`
-		TpTriggerPx  okex.JSONFloat64    `json:"tpTriggerPx"`
-		TpOrdPx      okex.JSONFloat64    `json:"tpOrdPx"`
-		SlTriggerPx  okex.JSONFloat64    `json:"slTriggerPx"`
-		SlOrdPx      okex.JSONFloat64    `json:"slOrdPx"`
-		OrdPx        okex.JSONFloat64    `json:"ordPx"`
-		Fee          okex.JSONFloat64    `json:"fee"`
-		Rebate       okex.JSONFloat64    `json:"rebate"`
-		State        okex.OrderState     `json:"state"`
-		TdMode       okex.TradeMode      `json:"tdMode"`
-		ActualSide   okex.PositionSide   `json:"actualSide"`
-		PosSide      okex.PositionSide   `json:"posSide"`
-		Side         okex.OrderSide      `json:"side"`
-		OrdType      okex.AlgoOrderType  `json:"ordType"`
-		InstType     okex.InstrumentType `json:"instType"`
-		TgtCcy       okex.QuantityType   `json:"tgtCcy"`
-		CTime        okex.JSONTime       `json:"cTime"`
-		TriggerTime  okex.JSONTime       `json:"triggerTime"`
+		InstID          string              `json:"instId"`
+		Ccy             string              `json:"ccy"`
+		OrdID           string              `json:"ordId"`
+		AlgoID          string              `json:"algoId"`
+		AlgoClOrdID     string              `json:"algoClOrdId"`
+		ClOrdID         string              `json:"clOrdId"`
+		TradeID         string              `json:"tradeId"`
+		Tag             string              `json:"tag"`
+		Category        string              `json:"category"`
+		FeeCcy          string              `json:"feeCcy"`
+		RebateCcy       string              `json:"rebateCcy"`
+		TimeInterval    string              `json:"timeInterval"`
+		Px              okex.JSONFloat64    `json:"px"`
+		PxVar           okex.JSONFloat64    `json:"pxVar"`
+		PxSpread        okex.JSONFloat64    `json:"pxSpread"`
+		PxLimit         okex.JSONFloat64    `json:"pxLimit"`
+		Sz              okex.JSONFloat64    `json:"sz"`
+		SzLimit         okex.JSONFloat64    `json:"szLimit"`
+		ActualSz        okex.JSONFloat64    `json:"actualSz"`
+		ActualPx        okex.JSONFloat64    `json:"actualPx"`
+		Pnl             okex.JSONFloat64    `json:"pnl"`
+		AccFillSz       okex.JSONFloat64    `json:"accFillSz"`
+		FillPx          okex.JSONFloat64    `json:"fillPx"`
+		FillSz          okex.JSONFloat64    `json:"fillSz"`
+		FillTime        okex.JSONFloat64    `json:"fillTime"`
+		AvgPx           okex.JSONFloat64    `json:"avgPx"`
+		Lever           okex.JSONFloat64    `json:"lever"`
+		TpTriggerPx     okex.JSONFloat64    `json:"tpTriggerPx"`
+		TpOrdPx         okex.JSONFloat64    `json:"tpOrdPx"`
+		SlTriggerPx     okex.JSONFloat64    `json:"slTriggerPx"`
+		SlOrdPx         okex.JSONFloat64    `json:"slOrdPx"`
+		OrdPx           okex.JSONFloat64    `json:"ordPx"`
+		TriggerPx       okex.JSONFloat64    `json:"triggerPx"`
+		Last            okex.JSONFloat64    `json:"last"`
+		CallbackRatio   okex.JSONFloat64    `json:"callbackRatio"`
+		CallbackSpread  okex.JSONFloat64    `json:"callbackSpread"`
+		ActivePx        okex.JSONFloat64    `json:"activePx"`
+		MoveTriggerPx   okex.JSONFloat64    `json:"moveTriggerPx"`
+		ChaseType       okex.ChaseType      `json:"chaseType"`
+		ChaseVal        okex.JSONFloat64    `json:"chaseVal"`
+		MaxChaseType    okex.ChaseType      `json:"maxChaseType"`
+		MaxChaseVal     okex.JSONFloat64    `json:"maxChaseVal"`
+		TriggerPxType   okex.TriggerPxType  `json:"triggerPxType"`
+		TpTriggerPxType okex.TriggerPxType  `json:"tpTriggerPxType"`
+		SlTriggerPxType okex.TriggerPxType  `json:"slTriggerPxType"`
+		ReduceOnly      okex.JSONBool       `json:"reduceOnly"`
+		FailCode        string              `json:"failCode"`
+		Fee             okex.JSONFloat64    `json:"fee"`
+		Rebate          okex.JSONFloat64    `json:"rebate"`
+		State           okex.OrderState     `json:"state"`
+		TdMode          okex.TradeMode      `json:"tdMode"`
+		ActualSide      okex.PositionSide   `json:"actualSide"`
+		PosSide         okex.PositionSide   `json:"posSide"`
+		Side            okex.OrderSide      `json:"side"`
+		OrdType         okex.AlgoOrderType  `json:"ordType"`
+		InstType        okex.InstrumentType `json:"instType"`
+		TgtCcy          okex.QuantityType   `json:"tgtCcy"`
+		CTime           okex.JSONTime       `json:"cTime"`
+		UTime           okex.JSONTime       `json:"uTime"`
+		TriggerTime     okex.JSONTime       `json:"triggerTime"`
+	}
+	CancelAllAfter struct {
+		Tag         string        `json:"tag"`
+		TriggerTime okex.JSONTime `json:"triggerTime"`
+		TS          okex.JSONTime `json:"ts"`
+	}
+	OneClickRepayCurrency struct {
+		DebtType  okex.DebtType         `json:"debtType"`
+		DebtData  []*OneClickRepayDebt  `json:"debtData"`
+		RepayData []*OneClickRepayAsset `json:"repayData"`
+	}
+	OneClickRepayDebt struct {
+		DebtCcy string       `json:"debtCcy"`
+		DebtAmt okex.Decimal `json:"debtAmt"`
+	}
+	OneClickRepayAsset struct {
+		RepayCcy string       `json:"repayCcy"`
+		RepayAmt okex.Decimal `json:"repayAmt"`
+	}
+	OneClickRepay struct {
+		DebtCcy     string                   `json:"debtCcy"`
+		RepayCcy    string                   `json:"repayCcy"`
+		FillDebtSz  okex.Decimal             `json:"fillDebtSz"`
+		FillRepaySz okex.Decimal             `json:"fillRepaySz"`
+		Status      okex.OneClickRepayStatus `json:"status"`
+		UTime       okex.JSONTime            `json:"uTime"`
+	}
+	// EasyConvertCurrency lists the small balances that can be converted and the currencies they can be converted into
+	EasyConvertCurrency struct {
+		FromData []*EasyConvertBalance `json:"fromData"`
+		ToCcy    []string              `json:"toCcy"`
+	}
+	EasyConvertBalance struct {
+		FromCcy string       `json:"fromCcy"`
+		FromAmt okex.Decimal `json:"fromAmt"`
+	}
+	EasyConvert struct {
+		FromCcy    string                 `json:"fromCcy"`
+		ToCcy      string                 `json:"toCcy"`
+		FillFromSz okex.Decimal           `json:"fillFromSz"`
+		FillToSz   okex.Decimal           `json:"fillToSz"`
+		Status     okex.EasyConvertStatus `json:"status"`
+		Acct       okex.EasyConvertSource `json:"acct,omitempty"`
+		UTime      okex.JSONTime          `json:"uTime"`
 	}
 )
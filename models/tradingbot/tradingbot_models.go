@@ -0,0 +1,163 @@
+package tradingbot
+
+import (
+	"github.com/pefish/go-okx"
+)
+
+type (
+	PlaceGridOrder struct {
+		AlgoID      string         `json:"algoId"`
+		AlgoClOrdID string         `json:"algoClOrdId"`
+		Tag         string         `json:"tag"`
+		SMsg        string         `json:"sMsg"`
+		SCode       okex.JSONInt64 `json:"sCode"`
+	}
+	// GridAlgoOrder is a spot or contract grid, the fields of the other kind are empty
+	GridAlgoOrder struct {
+		AlgoID              string                 `json:"algoId"`
+		AlgoClOrdID         string                 `json:"algoClOrdId"`
+		InstType            okex.InstrumentType    `json:"instType"`
+		InstID              string                 `json:"instId"`
+		InstFamily          string                 `json:"instFamily"`
+		AlgoOrdType         okex.GridAlgoOrderType `json:"algoOrdType"`
+		State               okex.GridAlgoState     `json:"state"`
+		MaxPx               okex.Decimal           `json:"maxPx"`
+		MinPx               okex.Decimal           `json:"minPx"`
+		GridNum             okex.JSONInt64         `json:"gridNum"`
+		RunType             okex.GridRunType       `json:"runType"`
+		TpTriggerPx         okex.Decimal           `json:"tpTriggerPx"`
+		SlTriggerPx         okex.Decimal           `json:"slTriggerPx"`
+		TpRatio             okex.Decimal           `json:"tpRatio"`
+		SlRatio             okex.Decimal           `json:"slRatio"`
+		TradeNum            okex.JSONInt64         `json:"tradeNum"`
+		ArbitrageNum        okex.JSONInt64         `json:"arbitrageNum"`
+		SingleAmt           okex.Decimal           `json:"singleAmt"`
+		PerMinProfitRate    okex.Decimal           `json:"perMinProfitRate"`
+		PerMaxProfitRate    okex.Decimal           `json:"perMaxProfitRate"`
+		RunPx               okex.Decimal           `json:"runPx"`
+		Investment          okex.Decimal           `json:"investment"`
+		TotalPnl            okex.Decimal           `json:"totalPnl"`
+		PnlRatio            okex.Decimal           `json:"pnlRatio"`
+		GridProfit          okex.Decimal           `json:"gridProfit"`
+		FloatProfit         okex.Decimal           `json:"floatProfit"`
+		AnnualizedRate      okex.Decimal           `json:"annualizedRate"`
+		TotalAnnualizedRate okex.Decimal           `json:"totalAnnualizedRate"`
+		Fee                 okex.Decimal           `json:"fee"`
+		FundingFee          okex.Decimal           `json:"fundingFee"`
+		CancelType          string                 `json:"cancelType"`
+		StopType            okex.GridStopType      `json:"stopType"`
+		StopResult          string                 `json:"stopResult"`
+		ActiveOrdNum        okex.JSONInt64         `json:"activeOrdNum"`
+		QuoteSz             okex.Decimal           `json:"quoteSz"`
+		BaseSz              okex.Decimal           `json:"baseSz"`
+		CurQuoteSz          okex.Decimal           `json:"curQuoteSz"`
+		CurBaseSz           okex.Decimal           `json:"curBaseSz"`
+		Profit              okex.Decimal           `json:"profit"`
+		Direction           okex.GridDirection     `json:"direction"`
+		BasePos             bool                   `json:"basePos"`
+		Sz                  okex.Decimal           `json:"sz"`
+		Lever               okex.Decimal           `json:"lever"`
+		ActualLever         okex.Decimal           `json:"actualLever"`
+		LiqPx               okex.Decimal           `json:"liqPx"`
+		Eq                  okex.Decimal           `json:"eq"`
+		OrdFrozen           okex.Decimal           `json:"ordFrozen"`
+		AvailEq             okex.Decimal           `json:"availEq"`
+		ProfitSharingRatio  okex.Decimal           `json:"profitSharingRatio"`
+		Tag                 string                 `json:"tag"`
+		TriggerParams       []*TriggerParam        `json:"triggerParams"`
+		CTime               okex.JSONTime          `json:"cTime"`
+		UTime               okex.JSONTime          `json:"uTime"`
+		PTime               okex.JSONTime          `json:"pTime,omitempty"` // push time of the grid channels
+	}
+	TriggerParam struct {
+		TriggerAction   okex.GridTriggerAction   `json:"triggerAction"`
+		TriggerStrategy okex.GridTriggerStrategy `json:"triggerStrategy"`
+		DelaySeconds    okex.JSONInt64           `json:"delaySeconds"`
+		Timeframe       okex.BarSize             `json:"timeframe"`
+		Thold           okex.Decimal             `json:"thold"`
+		TriggerCond     string                   `json:"triggerCond"`
+		TimePeriod      okex.Decimal             `json:"timePeriod"`
+		TriggerPx       okex.Decimal             `json:"triggerPx"`
+		StopType        okex.GridStopType        `json:"stopType"`
+		TriggerTime     okex.JSONTime            `json:"triggerTime"`
+		TriggerType     string                   `json:"triggerType"` // auto or manual
+	}
+	GridSubOrder struct {
+		AlgoID      string                 `json:"algoId"`
+		AlgoClOrdID string                 `json:"algoClOrdId"`
+		InstType    okex.InstrumentType    `json:"instType"`
+		InstID      string                 `json:"instId"`
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		GroupID     string                 `json:"groupId"`
+		OrdID       string                 `json:"ordId"`
+		TdMode      okex.TradeMode         `json:"tdMode"`
+		Ccy         string                 `json:"ccy"`
+		OrdType     okex.OrderType         `json:"ordType"`
+		State       okex.OrderState        `json:"state"`
+		Side        okex.OrderSide         `json:"side"`
+		PosSide     okex.PositionSide      `json:"posSide"`
+		Px          okex.Decimal           `json:"px"`
+		Sz          okex.Decimal           `json:"sz"`
+		AvgPx       okex.Decimal           `json:"avgPx"`
+		AccFillSz   okex.Decimal           `json:"accFillSz"`
+		Fee         okex.Decimal           `json:"fee"`
+		FeeCcy      string                 `json:"feeCcy"`
+		Pnl         okex.Decimal           `json:"pnl"`
+		CtVal       okex.Decimal           `json:"ctVal"`
+		Lever       okex.Decimal           `json:"lever"`
+		Tag         string                 `json:"tag"`
+		CTime       okex.JSONTime          `json:"cTime"`
+		UTime       okex.JSONTime          `json:"uTime"`
+	}
+	GridPosition struct {
+		AlgoID      string              `json:"algoId"`
+		AlgoClOrdID string              `json:"algoClOrdId"`
+		InstType    okex.InstrumentType `json:"instType"`
+		InstID      string              `json:"instId"`
+		Ccy         string              `json:"ccy"`
+		MgnMode     okex.MarginMode     `json:"mgnMode"`
+		PosSide     okex.PositionSide   `json:"posSide"`
+		Pos         okex.Decimal        `json:"pos"`
+		AvgPx       okex.Decimal        `json:"avgPx"`
+		Lever       okex.Decimal        `json:"lever"`
+		LiqPx       okex.Decimal        `json:"liqPx"`
+		MarkPx      okex.Decimal        `json:"markPx"`
+		Last        okex.Decimal        `json:"last"`
+		Imr         okex.Decimal        `json:"imr"`
+		Mmr         okex.Decimal        `json:"mmr"`
+		MgnRatio    okex.Decimal        `json:"mgnRatio"`
+		Upl         okex.Decimal        `json:"upl"`
+		UplRatio    okex.Decimal        `json:"uplRatio"`
+		NotionalUsd okex.Decimal        `json:"notionalUsd"`
+		ADL         okex.Decimal        `json:"adl"`
+		CTime       okex.JSONTime       `json:"cTime"`
+		UTime       okex.JSONTime       `json:"uTime"`
+		PTime       okex.JSONTime       `json:"pTime,omitempty"`
+	}
+	GridAIParam struct {
+		InstID             string                 `json:"instId"`
+		AlgoOrdType        okex.GridAlgoOrderType `json:"algoOrdType"`
+		Duration           string                 `json:"duration"`
+		GridNum            okex.JSONInt64         `json:"gridNum"`
+		MaxPx              okex.Decimal           `json:"maxPx"`
+		MinPx              okex.Decimal           `json:"minPx"`
+		RunType            okex.GridRunType       `json:"runType"`
+		Direction          okex.GridDirection     `json:"direction"`
+		Lever              okex.Decimal           `json:"lever"`
+		PerMaxProfitRate   okex.Decimal           `json:"perMaxProfitRate"`
+		PerMinProfitRate   okex.Decimal           `json:"perMinProfitRate"`
+		PerGridProfitRatio okex.Decimal           `json:"perGridProfitRatio"`
+		AnnualizedRate     okex.Decimal           `json:"annualizedRate"`
+		MinInvestment      okex.Decimal           `json:"minInvestment"`
+		Ccy                string                 `json:"ccy"`
+		SourceCcy          string                 `json:"sourceCcy"`
+	}
+	GridMinInvestment struct {
+		MinInvestmentData []*GridInvestment `json:"minInvestmentData"`
+		SingleAmt         okex.Decimal      `json:"singleAmt"`
+	}
+	GridInvestment struct {
+		Amt okex.Decimal `json:"amt"`
+		Ccy string       `json:"ccy"`
+	}
+)
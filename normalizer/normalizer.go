@@ -0,0 +1,294 @@
+// Package normalizer rounds order prices and sizes to the instrument rules and rejects the orders the server would refuse,
+// before any network call.
+//
+// https://www.okx.com/docs-v5/en/#public-data-rest-api-get-instruments
+package normalizer
+
+import (
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/models/publicdata"
+	requests "github.com/pefish/go-okx/requests/rest/trade"
+	wsRequests "github.com/pefish/go-okx/requests/ws/trade"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrUnknownInstrument = errors.New("unknown instrument")
+	ErrNotLive           = errors.New("instrument is not live")
+	ErrInvalidPrice      = errors.New("invalid price")
+	ErrInvalidSize       = errors.New("invalid size")
+)
+
+// Source returns the metadata of an instrument, *instruments.Registry is one
+type Source interface {
+	Get(instID string) (*publicdata.Instrument, bool)
+}
+
+// Instruments is a Source built from a PublicData.GetInstruments response
+type Instruments map[string]*publicdata.Instrument
+
+// NewInstruments indexes list by instId
+func NewInstruments(list []*publicdata.Instrument) Instruments {
+	m := make(Instruments, len(list))
+	for _, i := range list {
+		m[i.InstID] = i
+	}
+	return m
+}
+
+func (m Instruments) Get(instID string) (*publicdata.Instrument, bool) {
+	i, ok := m[instID]
+	return i, ok
+}
+
+// Normalizer checks orders against the rules of their instrument. Prices are rounded to tickSz with PxMode and sizes to lotSz with SzMode,
+// or rejected when they are not a multiple of it if Strict is set.
+//
+// Errors wrap one of ErrUnknownInstrument, ErrNotLive, ErrInvalidPrice or ErrInvalidSize and the request is left untouched.
+type Normalizer struct {
+	Source Source
+	PxMode okex.RoundingMode
+	SzMode okex.RoundingMode
+	Strict bool
+}
+
+// NewNormalizer returns a pointer to a Normalizer rounding prices to the nearest tick and sizes down to the lot size
+func NewNormalizer(s Source) *Normalizer {
+	return &Normalizer{
+		Source: s,
+		PxMode: okex.RoundHalfUp,
+		SzMode: okex.RoundDown,
+	}
+}
+
+// PlaceOrder normalizes the px and sz of a rest order
+func (n *Normalizer) PlaceOrder(o *requests.PlaceOrder) error {
+	return n.placeOrder(o.InstID, o.OrdType, o.Side, o.TdMode, o.TgtCcy, o.PxUsd != "" || o.PxVol != "", &o.Px, &o.Sz)
+}
+
+// WsPlaceOrder normalizes the px and sz of a websocket order
+func (n *Normalizer) WsPlaceOrder(o *wsRequests.PlaceOrder) error {
+	return n.placeOrder(o.InstID, o.OrdType, o.Side, o.TdMode, o.TgtCcy, o.PxUsd != "" || o.PxVol != "", &o.Px, &o.Sz)
+}
+
+// AmendOrder normalizes the newPx and newSz of a rest amendment, the fields left empty are not changed.
+// newPxUsd, newPxVol and the attached algo orders are passed through.
+func (n *Normalizer) AmendOrder(o *requests.AmendOrder) error {
+	return n.amendOrder(o.InstID, o.NewPxUsd != "" || o.NewPxVol != "" || len(o.AttachAlgoOrds) > 0, &o.NewPx, &o.NewSz)
+}
+
+// WsAmendOrder normalizes the newPx and newSz of a websocket amendment, the fields left empty are not changed.
+// newPxUsd, newPxVol and the attached algo orders are passed through.
+func (n *Normalizer) WsAmendOrder(o *wsRequests.AmendOrder) error {
+	return n.amendOrder(o.InstID, o.NewPxUsd != "" || o.NewPxVol != "" || len(o.AttachAlgoOrds) > 0, &o.NewPx, &o.NewSz)
+}
+
+// PlaceAlgoOrder normalizes the size and every price of an algo order, an order price of -1 (market) is kept
+func (n *Normalizer) PlaceAlgoOrder(o *requests.PlaceAlgoOrder) error {
+	inst, err := n.instrument(o.InstID)
+	if err != nil {
+		return err
+	}
+	var max okex.Decimal
+	switch o.OrdType {
+	case okex.AlgoOrderConditional, okex.AlgoOrderOCO, okex.AlgoOrderMoveStop:
+		max = inst.MaxStopSz
+	case okex.AlgoOrderChase:
+		max = inst.MaxLmtSz
+	case okex.AlgoOrderTrigger:
+		max = inst.MaxTriggerSz
+	case okex.AlgoOrderIceberg:
+		max = inst.MaxIcebergSz
+	case okex.AlgoOrderTwap:
+		max = inst.MaxTwapSz
+	}
+	quote := quoteSized(o.InstID, algoOrdType(o), o.Side, o.TdMode, o.TgtCcy)
+	// closeFraction closes the whole position instead of sz
+	sz, err := n.size(inst, "sz", o.Sz, o.CloseFraction == "", quote, max)
+	if err != nil {
+		return err
+	}
+	prices := []struct {
+		name   string
+		v      *okex.Decimal
+		market bool // -1 places a market order
+	}{
+		{"tpTriggerPx", &o.TpTriggerPx, false},
+		{"tpOrdPx", &o.TpOrdPx, true},
+		{"slTriggerPx", &o.SlTriggerPx, false},
+		{"slOrdPx", &o.SlOrdPx, true},
+		{"triggerPx", &o.TriggerPx, false},
+		{"ordPx", &o.OrdPx, true},
+		{"pxLimit", &o.PxLimit, false},
+		{"activePx", &o.ActivePx, false},
+		{"callbackSpread", &o.CallbackSpread, false},
+	}
+	res := make([]okex.Decimal, len(prices))
+	for i, p := range prices {
+		res[i], err = n.price(inst, p.name, *p.v, false, p.market)
+		if err != nil {
+			return err
+		}
+	}
+	szLimit, err := n.size(inst, "szLimit", o.SzLimit, false, quote, "")
+	if err != nil {
+		return err
+	}
+
+	o.Sz, o.SzLimit = sz, szLimit
+	for i, p := range prices {
+		*p.v = res[i]
+	}
+	return nil
+}
+
+// placeOrder normalizes px and sz, px is not required when the option is priced in USD or volatility
+func (n *Normalizer) placeOrder(instID string, ordType okex.OrderType, side okex.OrderSide, tdMode okex.TradeMode, tgtCcy okex.QuantityType, optionPx bool, px, sz *okex.Decimal) error {
+	inst, err := n.instrument(instID)
+	if err != nil {
+		return err
+	}
+	p, max, quote := *px, inst.MaxLmtSz, false
+	if ordType == okex.OrderMarket || ordType == okex.OrderOptimalLimitIoc {
+		// the px of market orders is ignored by the server
+		max = inst.MaxMktSz
+		if inst.InstType == okex.SpotInstrument || inst.InstType == okex.MarginInstrument {
+			// the max market size of spot and margin is in USDT
+			max = ""
+		}
+		quote = quoteSized(instID, ordType, side, tdMode, tgtCcy)
+	} else {
+		p, err = n.price(inst, "px", *px, !optionPx, false)
+		if err != nil {
+			return err
+		}
+	}
+	s, err := n.size(inst, "sz", *sz, true, quote, max)
+	if err != nil {
+		return err
+	}
+	*px, *sz = p, s
+	return nil
+}
+
+// amendOrder normalizes newPx and newSz, other tells whether the amendment changes anything else
+func (n *Normalizer) amendOrder(instID string, other bool, px, sz *okex.Decimal) error {
+	inst, err := n.instrument(instID)
+	if err != nil {
+		return err
+	}
+	if *px == "" && *sz == "" && !other {
+		return errors.Wrapf(ErrInvalidSize, "%s: nothing to amend", instID)
+	}
+	p, err := n.price(inst, "newPx", *px, false, false)
+	if err != nil {
+		return err
+	}
+	s, err := n.size(inst, "newSz", *sz, false, false, inst.MaxLmtSz)
+	if err != nil {
+		return err
+	}
+	*px, *sz = p, s
+	return nil
+}
+
+func (n *Normalizer) instrument(instID string) (*publicdata.Instrument, error) {
+	if n.Source == nil {
+		return nil, errors.Wrap(ErrUnknownInstrument, instID)
+	}
+	inst, ok := n.Source.Get(instID)
+	if !ok {
+		return nil, errors.Wrap(ErrUnknownInstrument, instID)
+	}
+	if inst.State != okex.InstrumentLive {
+		return nil, errors.Wrapf(ErrNotLive, "%s is %s", instID, inst.State)
+	}
+	return inst, nil
+}
+
+// price rounds v to the tick size, an empty v is only accepted when it's not required and -1 only when it's a market price
+func (n *Normalizer) price(inst *publicdata.Instrument, name string, v okex.Decimal, required, market bool) (okex.Decimal, error) {
+	if v == "" {
+		if required {
+			return v, errors.Wrapf(ErrInvalidPrice, "%s: %s is required", inst.InstID, name)
+		}
+		return v, nil
+	}
+	d, err := okex.NewDecimal(string(v))
+	if err != nil {
+		return v, errors.Wrapf(ErrInvalidPrice, "%s: %s %v", inst.InstID, name, err)
+	}
+	if market && d.Equal("-1") {
+		return d, nil
+	}
+	if d.Sign() <= 0 {
+		return v, errors.Wrapf(ErrInvalidPrice, "%s: %s %s is not positive", inst.InstID, name, d)
+	}
+	if n.Strict && !d.IsMultipleOf(inst.TickSz) {
+		return v, errors.Wrapf(ErrInvalidPrice, "%s: %s %s is not a multiple of tickSz %s", inst.InstID, name, d, inst.TickSz)
+	}
+	r := d.RoundStep(inst.TickSz, n.PxMode)
+	if r.Sign() <= 0 && d.Sign() > 0 {
+		return v, errors.Wrapf(ErrInvalidPrice, "%s: %s %s rounds to %s with tickSz %s", inst.InstID, name, d, r, inst.TickSz)
+	}
+	return r, nil
+}
+
+// size rounds v to the lot size and checks it against minSz and max, a size in quote currency is only checked to be positive
+func (n *Normalizer) size(inst *publicdata.Instrument, name string, v okex.Decimal, required, quote bool, max okex.Decimal) (okex.Decimal, error) {
+	if v == "" {
+		if required {
+			return v, errors.Wrapf(ErrInvalidSize, "%s: %s is required", inst.InstID, name)
+		}
+		return v, nil
+	}
+	d, err := okex.NewDecimal(string(v))
+	if err != nil {
+		return v, errors.Wrapf(ErrInvalidSize, "%s: %s %v", inst.InstID, name, err)
+	}
+	if d.Sign() <= 0 {
+		return v, errors.Wrapf(ErrInvalidSize, "%s: %s %s is not positive", inst.InstID, name, d)
+	}
+	if quote {
+		return d, nil
+	}
+	if n.Strict && !d.IsMultipleOf(inst.LotSz) {
+		return v, errors.Wrapf(ErrInvalidSize, "%s: %s %s is not a multiple of lotSz %s", inst.InstID, name, d, inst.LotSz)
+	}
+	r := d.RoundStep(inst.LotSz, n.SzMode)
+	if r.LessThan(inst.MinSz) || r.Sign() <= 0 {
+		return v, errors.Wrapf(ErrInvalidSize, "%s: %s %s rounds to %s, below minSz %s", inst.InstID, name, d, r, inst.MinSz)
+	}
+	if max != "" && !max.IsZero() && r.GreaterThan(max) {
+		return v, errors.Wrapf(ErrInvalidSize, "%s: %s %s is above the max size %s", inst.InstID, name, r, max)
+	}
+	return r, nil
+}
+
+// algoOrdType returns the type of the order placed when the algo order triggers, an order price of -1 is a market order
+func algoOrdType(o *requests.PlaceAlgoOrder) okex.OrderType {
+	switch o.OrdType {
+	case okex.AlgoOrderTrigger:
+		if o.OrdPx.Equal("-1") {
+			return okex.OrderMarket
+		}
+	case okex.AlgoOrderConditional, okex.AlgoOrderOCO:
+		if o.TpOrdPx.Equal("-1") || o.SlOrdPx.Equal("-1") {
+			return okex.OrderMarket
+		}
+	case okex.AlgoOrderMoveStop:
+		return okex.OrderMarket
+	}
+	return okex.OrderLimit
+}
+
+// quoteSized reports whether the sz of a spot or margin order is in quote currency, it's the default of cash market buys.
+// It's decided by the instId and tdMode like risk.Scenario.AddOrder, the registry may return the MARGIN version of a spot pair.
+// Lot and min sizes don't apply then.
+func quoteSized(instID string, ordType okex.OrderType, side okex.OrderSide, tdMode okex.TradeMode, tgtCcy okex.QuantityType) bool {
+	id, err := okex.ParseInstID(instID)
+	if err != nil || id.Type != okex.SpotInstrument {
+		return false
+	}
+	return tgtCcy == okex.QuantityQuoteCcy || (tgtCcy == "" && ordType == okex.OrderMarket && side == okex.OrderBuy && tdMode == okex.TradeCashMode)
+}
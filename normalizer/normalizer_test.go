@@ -0,0 +1,196 @@
+package normalizer
+
+import (
+	"testing"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/models/publicdata"
+	requests "github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+func testNormalizer() *Normalizer {
+	return NewNormalizer(NewInstruments([]*publicdata.Instrument{
+		{
+			InstID: "BTC-USDT", InstType: okex.SpotInstrument, State: okex.InstrumentLive,
+			TickSz: "0.1", LotSz: "0.0001", MinSz: "0.001", MaxLmtSz: "100", MaxMktSz: "1000000", MaxStopSz: "50",
+		},
+		{
+			InstID: "BTC-USDT-SWAP", InstType: okex.SwapInstrument, State: okex.InstrumentLive,
+			TickSz: "0.1", LotSz: "1", MinSz: "1", MaxLmtSz: "1000", MaxMktSz: "500", MaxStopSz: "200", MaxTriggerSz: "300",
+		},
+		{InstID: "ETH-USDT", InstType: okex.SpotInstrument, State: okex.InstrumentSuspend, TickSz: "0.01", LotSz: "0.0001", MinSz: "0.001"},
+	}))
+}
+
+func TestPlaceOrder(t *testing.T) {
+	limit := func(instID string, px, sz okex.Decimal) requests.PlaceOrder {
+		return requests.PlaceOrder{InstID: instID, TdMode: okex.TradeCashMode, Side: okex.OrderBuy, OrdType: okex.OrderLimit, Px: px, Sz: sz}
+	}
+	market := func(instID string, tdMode okex.TradeMode, side okex.OrderSide, sz okex.Decimal) requests.PlaceOrder {
+		return requests.PlaceOrder{InstID: instID, TdMode: tdMode, Side: side, OrdType: okex.OrderMarket, Sz: sz}
+	}
+	tests := []struct {
+		name           string
+		o              requests.PlaceOrder
+		strict         bool
+		wantPx, wantSz okex.Decimal
+		err            error
+	}{
+		{"rounded", limit("BTC-USDT", "42219.96", "0.12345"), false, "42220.0", "0.1234", nil},
+		{"strict exact", limit("BTC-USDT", "42219.9", "0.1234"), true, "42219.9", "0.1234", nil},
+		{"strict price", limit("BTC-USDT", "42219.96", "0.1234"), true, "", "", ErrInvalidPrice},
+		{"strict size", limit("BTC-USDT", "42219.9", "0.12345"), true, "", "", ErrInvalidSize},
+		{"no price", limit("BTC-USDT", "", "1"), false, "", "", ErrInvalidPrice},
+		{"price rounded to 0", limit("BTC-USDT", "0.04", "1"), false, "", "", ErrInvalidPrice},
+		{"below minSz", limit("BTC-USDT", "100", "0.00099"), false, "", "", ErrInvalidSize},
+		{"above maxLmtSz", limit("BTC-USDT", "100", "101"), false, "", "", ErrInvalidSize},
+		{"contracts", limit("BTC-USDT-SWAP", "100", "12.7"), false, "100.0", "12", nil},
+		{"above maxMktSz", market("BTC-USDT-SWAP", okex.TradeCrossMode, okex.OrderBuy, "501"), false, "", "", ErrInvalidSize},
+		{"spot market sell has no max", market("BTC-USDT", okex.TradeCashMode, okex.OrderSell, "2000.00001"), false, "", "2000.0000", nil},
+		{"spot market buy in quote", market("BTC-USDT", okex.TradeCashMode, okex.OrderBuy, "100.123456789"), true, "", "100.123456789", nil},
+		{"margin market buy in base", market("BTC-USDT", okex.TradeCrossMode, okex.OrderBuy, "0.12345"), false, "", "0.1234", nil},
+		{
+			"tgtCcy quote",
+			func() requests.PlaceOrder {
+				o := market("BTC-USDT", okex.TradeCashMode, okex.OrderSell, "0.00001")
+				o.TgtCcy = okex.QuantityQuoteCcy
+				return o
+			}(),
+			false, "", "0.00001", nil,
+		},
+		{"unknown", limit("BTC-USD", "1", "1"), false, "", "", ErrUnknownInstrument},
+		{"not live", limit("ETH-USDT", "1", "1"), false, "", "", ErrNotLive},
+	}
+	for _, tt := range tests {
+		n := testNormalizer()
+		n.Strict = tt.strict
+		o := tt.o
+		err := n.PlaceOrder(&o)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			if o.Px != tt.o.Px || o.Sz != tt.o.Sz {
+				t.Errorf("%s: request changed on error", tt.name)
+			}
+			continue
+		}
+		if o.Px != tt.wantPx || o.Sz != tt.wantSz {
+			t.Errorf("%s: px %s sz %s, want %s %s", tt.name, o.Px, o.Sz, tt.wantPx, tt.wantSz)
+		}
+	}
+}
+
+func TestAmendOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		o              requests.AmendOrder
+		wantPx, wantSz okex.Decimal
+		err            error
+	}{
+		{"price only", requests.AmendOrder{InstID: "BTC-USDT", NewPx: "42219.96"}, "42220.0", "", nil},
+		{"size only", requests.AmendOrder{InstID: "BTC-USDT", NewSz: "0.12345"}, "", "0.1234", nil},
+		{"newPxUsd passed through", requests.AmendOrder{InstID: "BTC-USDT", NewPxUsd: "100.123"}, "", "", nil},
+		{
+			"attached orders passed through",
+			requests.AmendOrder{InstID: "BTC-USDT", AttachAlgoOrds: []*requests.AmendAttachAlgoOrder{{AttachAlgoID: "1", NewTpTriggerPx: "1.23"}}},
+			"", "", nil,
+		},
+		{"nothing", requests.AmendOrder{InstID: "BTC-USDT"}, "", "", ErrInvalidSize},
+		{"below minSz", requests.AmendOrder{InstID: "BTC-USDT", NewSz: "0.0001"}, "", "", ErrInvalidSize},
+		{"market price", requests.AmendOrder{InstID: "BTC-USDT", NewPx: "-1"}, "", "", ErrInvalidPrice},
+	}
+	for _, tt := range tests {
+		o := tt.o
+		err := testNormalizer().AmendOrder(&o)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err == nil && (o.NewPx != tt.wantPx || o.NewSz != tt.wantSz || o.NewPxUsd != tt.o.NewPxUsd) {
+			t.Errorf("%s: newPx %s newSz %s, want %s %s", tt.name, o.NewPx, o.NewSz, tt.wantPx, tt.wantSz)
+		}
+	}
+}
+
+func TestPlaceAlgoOrder(t *testing.T) {
+	spot := func(ordType okex.AlgoOrderType, sz okex.Decimal) requests.PlaceAlgoOrder {
+		return requests.PlaceAlgoOrder{InstID: "BTC-USDT", TdMode: okex.TradeCashMode, Side: okex.OrderBuy, OrdType: ordType, Sz: sz}
+	}
+	tests := []struct {
+		name   string
+		o      requests.PlaceAlgoOrder
+		wantSz okex.Decimal
+		check  func(o requests.PlaceAlgoOrder) bool
+		err    error
+	}{
+		{
+			"limit trigger buy in base",
+			func() requests.PlaceAlgoOrder {
+				o := spot(okex.AlgoOrderTrigger, "0.12345")
+				o.TriggerPx, o.OrdPx = "30000.04", "30000.06"
+				return o
+			}(),
+			"0.1234",
+			func(o requests.PlaceAlgoOrder) bool { return o.TriggerPx == "30000.0" && o.OrdPx == "30000.1" },
+			nil,
+		},
+		{
+			"market trigger buy in quote",
+			func() requests.PlaceAlgoOrder {
+				o := spot(okex.AlgoOrderTrigger, "100.123")
+				o.TriggerPx, o.OrdPx = "30000", "-1"
+				return o
+			}(),
+			"100.123",
+			func(o requests.PlaceAlgoOrder) bool { return o.OrdPx == "-1" },
+			nil,
+		},
+		{
+			"market stop loss buy in quote",
+			func() requests.PlaceAlgoOrder {
+				o := spot(okex.AlgoOrderConditional, "100.123")
+				o.SlTriggerPx, o.SlOrdPx = "31000.04", "-1"
+				return o
+			}(),
+			"100.123",
+			func(o requests.PlaceAlgoOrder) bool { return o.SlTriggerPx == "31000.0" && o.SlOrdPx == "-1" },
+			nil,
+		},
+		{
+			"market trigger price",
+			func() requests.PlaceAlgoOrder {
+				o := spot(okex.AlgoOrderConditional, "1")
+				o.SlTriggerPx, o.SlOrdPx = "-1", "-1"
+				return o
+			}(),
+			"", nil, ErrInvalidPrice,
+		},
+		{
+			"above maxStopSz",
+			requests.PlaceAlgoOrder{InstID: "BTC-USDT-SWAP", TdMode: okex.TradeCrossMode, Side: okex.OrderSell, OrdType: okex.AlgoOrderMoveStop, Sz: "201"},
+			"", nil, ErrInvalidSize,
+		},
+		{
+			"closeFraction",
+			requests.PlaceAlgoOrder{InstID: "BTC-USDT-SWAP", TdMode: okex.TradeCrossMode, Side: okex.OrderSell, OrdType: okex.AlgoOrderMoveStop, CloseFraction: "1"},
+			"", nil, nil,
+		},
+	}
+	for _, tt := range tests {
+		o := tt.o
+		err := testNormalizer().PlaceAlgoOrder(&o)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if o.Sz != tt.wantSz || (tt.check != nil && !tt.check(o)) {
+			t.Errorf("%s: got %+v", tt.name, o)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package orderbook
+
+import (
+	"fmt"
+	"sync"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/ws"
+	"github.com/pefish/go-okx/events/public"
+	requests "github.com/pefish/go-okx/requests/ws/public"
+	"github.com/pkg/errors"
+)
+
+// Books keeps local order books in sync over a websocket client. A book that fails a check is resubscribed to get a new snapshot.
+//
+// It listens to the order books of the client, the OnOrderBook handler is left to the user.
+type Books struct {
+	client   *ws.ClientWs
+	mu       sync.RWMutex
+	books    map[string]*Book // instId -> book
+	reqs     map[string]requests.OrderBook
+	onResync func(instID string, err error)
+}
+
+// NewBooks returns a pointer to a fresh Books
+func NewBooks(c *ws.ClientWs) *Books {
+	b := &Books{
+		client: c,
+		books:  make(map[string]*Book),
+		reqs:   make(map[string]requests.OrderBook),
+	}
+	c.Public.ListenOrderBook(b.process)
+	return b
+}
+
+// OnResync registers the callback called when a book failed a check and is being resubscribed
+func (b *Books) OnResync(fn func(instID string, err error)) {
+	b.mu.Lock()
+	b.onResync = fn
+	b.mu.Unlock()
+}
+
+// Subscribe subscribes to the order book channel of req and returns the book it maintains.
+// An instrument has a single book, subscribing to another depth channel of it is an error.
+func (b *Books) Subscribe(req requests.OrderBook) (*Book, error) {
+	b.mu.Lock()
+	book, ok := b.books[req.InstID]
+	if ok && b.reqs[req.InstID].Channel != req.Channel {
+		b.mu.Unlock()
+		return nil, errors.Errorf("%s is already subscribed to %s", req.InstID, b.reqs[req.InstID].Channel)
+	}
+	if !ok {
+		book = NewBook(req.InstID)
+		b.books[req.InstID] = book
+		b.reqs[req.InstID] = req
+	}
+	b.mu.Unlock()
+	if ok {
+		return book, nil
+	}
+	if err := b.client.Public.OrderBook([]requests.OrderBook{req}); err != nil {
+		b.mu.Lock()
+		if b.books[req.InstID] == book {
+			delete(b.books, req.InstID)
+			delete(b.reqs, req.InstID)
+		}
+		b.mu.Unlock()
+		return nil, err
+	}
+	return book, nil
+}
+
+// Get returns the book of an instrument that was subscribed
+func (b *Books) Get(instID string) (*Book, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	book, ok := b.books[instID]
+	return book, ok
+}
+
+func (b *Books) process(e *public.OrderBook) {
+	if e.Arg == nil {
+		return
+	}
+	id, _ := e.Arg.Get("instId")
+	instID := fmt.Sprint(id)
+	b.mu.RLock()
+	book, ok := b.books[instID]
+	req := b.reqs[instID]
+	onResync := b.onResync
+	b.mu.RUnlock()
+	// the user may subscribe to other depth channels of the instrument on the same client
+	if ch, _ := e.Arg.Get("channel"); !ok || fmt.Sprint(ch) != req.Channel {
+		return
+	}
+	err := book.Apply(e)
+	if err == nil {
+		return
+	}
+	if onResync != nil {
+		onResync(instID, err)
+	}
+	err = b.client.Resubscribe(false, okex.StructSlice2MapSlice([]requests.OrderBook{req}))
+	if err != nil && onResync != nil {
+		onResync(instID, err)
+	}
+}
@@ -0,0 +1,30 @@
+package orderbook
+
+import (
+	"context"
+	"testing"
+
+	i_logger "github.com/pefish/go-interface/i-logger"
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/ws"
+	requests "github.com/pefish/go-okx/requests/ws/public"
+)
+
+func TestSubscribeFailureKeepsNoBook(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// nothing listens there, the subscription fails
+	c := ws.NewClient(ctx, &i_logger.DefaultLogger, "", "", "", map[bool]okex.BaseURL{false: "ws://127.0.0.1:1"})
+	b := NewBooks(c)
+	req := requests.OrderBook{InstID: "BTC-USDT", Channel: "books"}
+	if _, err := b.Subscribe(req); err == nil {
+		t.Fatal("Subscribe succeeded")
+	}
+	if _, ok := b.Get("BTC-USDT"); ok {
+		t.Fatal("book of a failed subscription kept")
+	}
+	// a retry subscribes again instead of returning the dead book
+	if _, err := b.Subscribe(req); err == nil {
+		t.Fatal("retry didn't subscribe")
+	}
+}
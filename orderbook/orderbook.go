@@ -0,0 +1,242 @@
+// Package orderbook maintains local order books from the websocket books channels.
+//
+// https://www.okx.com/docs-v5/en/#order-book-trading-market-data-ws-order-book-channel
+package orderbook
+
+import (
+	"hash/crc32"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pefish/go-okx/events/public"
+	"github.com/pefish/go-okx/models/market"
+	"github.com/pkg/errors"
+)
+
+const (
+	snapshotAction = "snapshot"
+	updateAction   = "update"
+
+	// checksumDepth is the number of levels of each side the server computes the checksum on
+	checksumDepth = 25
+)
+
+var (
+	ErrChecksum = errors.New("order book checksum mismatch")
+	ErrSequence = errors.New("order book sequence gap")
+)
+
+// Book is the local order book of a single instrument, it's safe for concurrent use
+type Book struct {
+	InstID   string
+	mu       sync.RWMutex
+	bids     []*market.OrderBookEntity // best first
+	asks     []*market.OrderBookEntity // best first
+	seqID    int64
+	synced   bool
+	ts       time.Time
+	onChange func(*Book)
+}
+
+// NewBook returns a pointer to a fresh Book, it's filled by the first snapshot passed to Apply
+func NewBook(instID string) *Book {
+	return &Book{InstID: instID}
+}
+
+// OnChange registers the callback called after every snapshot or update that was applied
+func (b *Book) OnChange(fn func(*Book)) {
+	b.mu.Lock()
+	b.onChange = fn
+	b.mu.Unlock()
+}
+
+// Apply applies a snapshot or an update pushed on a books channel. Updates are ignored until a snapshot arrives.
+//
+// When the checksum or the seqId/prevSeqId continuity check fails the book is cleared and ErrChecksum or ErrSequence is returned,
+// the book has to be resynced from a new snapshot.
+func (b *Book) Apply(e *public.OrderBook) error {
+	b.mu.Lock()
+	for _, d := range e.Books {
+		var err error
+		switch e.Action {
+		case updateAction:
+			err = b.update(d)
+		default:
+			// channels without action like books5 push full snapshots only
+			err = b.snapshot(d, e.Action == snapshotAction)
+		}
+		if err != nil {
+			b.reset()
+			b.mu.Unlock()
+			return err
+		}
+	}
+	onChange := b.onChange
+	synced := b.synced
+	b.mu.Unlock()
+
+	if onChange != nil && synced {
+		onChange(b)
+	}
+	return nil
+}
+
+func (b *Book) snapshot(d *market.OrderBookWs, verify bool) error {
+	b.bids = b.bids[:0]
+	b.asks = b.asks[:0]
+	for _, l := range d.Bids {
+		b.set(&b.bids, l, true)
+	}
+	for _, l := range d.Asks {
+		b.set(&b.asks, l, false)
+	}
+	if verify && !b.verify(d.Checksum) {
+		return ErrChecksum
+	}
+	b.seqID = d.SeqID
+	b.ts = time.Time(d.TS)
+	b.synced = true
+	return nil
+}
+
+func (b *Book) update(d *market.OrderBookWs) error {
+	if !b.synced {
+		return nil
+	}
+	if d.PrevSeqID != 0 && b.seqID != 0 && d.PrevSeqID != b.seqID {
+		return errors.Wrapf(ErrSequence, "prevSeqId %d, last seqId %d", d.PrevSeqID, b.seqID)
+	}
+	for _, l := range d.Bids {
+		b.set(&b.bids, l, true)
+	}
+	for _, l := range d.Asks {
+		b.set(&b.asks, l, false)
+	}
+	if !b.verify(d.Checksum) {
+		return ErrChecksum
+	}
+	b.seqID = d.SeqID
+	b.ts = time.Time(d.TS)
+	return nil
+}
+
+// set replaces the level at the price of l, a zero size removes it
+func (b *Book) set(side *[]*market.OrderBookEntity, l *market.OrderBookEntity, desc bool) {
+	levels := *side
+	i := sort.Search(len(levels), func(i int) bool {
+		if desc {
+			return levels[i].DepthPrice <= l.DepthPrice
+		}
+		return levels[i].DepthPrice >= l.DepthPrice
+	})
+	found := i < len(levels) && levels[i].DepthPrice == l.DepthPrice
+	switch {
+	case l.Size == 0:
+		if found {
+			*side = append(levels[:i], levels[i+1:]...)
+		}
+	case found:
+		cp := *l
+		levels[i] = &cp
+	default:
+		cp := *l
+		levels = append(levels, nil)
+		copy(levels[i+1:], levels[i:])
+		levels[i] = &cp
+		*side = levels
+	}
+}
+
+// verify compares the crc32 of the top levels with the pushed checksum
+//
+// https://www.okx.com/docs-v5/en/#overview-websocket-checksum
+func (b *Book) verify(cs int) bool {
+	var sb strings.Builder
+	for i := 0; i < checksumDepth; i++ {
+		if i < len(b.bids) {
+			sb.WriteString(b.bids[i].RawDepthPrice)
+			sb.WriteByte(':')
+			sb.WriteString(b.bids[i].RawSize)
+			sb.WriteByte(':')
+		}
+		if i < len(b.asks) {
+			sb.WriteString(b.asks[i].RawDepthPrice)
+			sb.WriteByte(':')
+			sb.WriteString(b.asks[i].RawSize)
+			sb.WriteByte(':')
+		}
+	}
+	s := strings.TrimSuffix(sb.String(), ":")
+	return int32(crc32.ChecksumIEEE([]byte(s))) == int32(cs)
+}
+
+func (b *Book) reset() {
+	b.bids = nil
+	b.asks = nil
+	b.seqID = 0
+	b.synced = false
+}
+
+// Synced reports whether the book holds a verified snapshot
+func (b *Book) Synced() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.synced
+}
+
+// SeqID returns the sequence id of the last applied message
+func (b *Book) SeqID() int64 {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.seqID
+}
+
+// TS returns the server time of the last applied message
+func (b *Book) TS() time.Time {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.ts
+}
+
+// BestBid returns a copy of the highest bid level
+func (b *Book) BestBid() (*market.OrderBookEntity, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.bids) == 0 {
+		return nil, false
+	}
+	cp := *b.bids[0]
+	return &cp, true
+}
+
+// BestAsk returns a copy of the lowest ask level
+func (b *Book) BestAsk() (*market.OrderBookEntity, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if len(b.asks) == 0 {
+		return nil, false
+	}
+	cp := *b.asks[0]
+	return &cp, true
+}
+
+// Depth returns copies of up to n levels of each side best first, n <= 0 returns every level
+func (b *Book) Depth(n int) (bids, asks []*market.OrderBookEntity) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return copyLevels(b.bids, n), copyLevels(b.asks, n)
+}
+
+func copyLevels(levels []*market.OrderBookEntity, n int) []*market.OrderBookEntity {
+	if n <= 0 || n > len(levels) {
+		n = len(levels)
+	}
+	res := make([]*market.OrderBookEntity, n)
+	for i := 0; i < n; i++ {
+		cp := *levels[i]
+		res[i] = &cp
+	}
+	return res
+}
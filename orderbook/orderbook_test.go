@@ -0,0 +1,183 @@
+package orderbook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+	"testing"
+
+	"github.com/pefish/go-okx/events/public"
+)
+
+// push is a books message, its checksum is computed on want unless checksum is set
+type push struct {
+	action     string
+	bids, asks string
+	seqID      int64
+	prevSeqID  int64
+	want       string // the checksum string of the book after the push
+	checksum   *int32
+}
+
+func checksumOf(s string) int32 {
+	return int32(crc32.ChecksumIEEE([]byte(s)))
+}
+
+func (p push) event(t *testing.T) *public.OrderBook {
+	cs := checksumOf(p.want)
+	if p.checksum != nil {
+		cs = *p.checksum
+	}
+	data := fmt.Sprintf(`{"arg":{"channel":"books","instId":"BTC-USDT"},"action":%q,"data":[{"asks":%s,"bids":%s,"ts":"1597026383085","checksum":%d,"seqId":%d,"prevSeqId":%d}]}`,
+		p.action, p.asks, p.bids, cs, p.seqID, p.prevSeqID)
+	e := &public.OrderBook{}
+	if err := json.Unmarshal([]byte(data), e); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// levels returns the checksum string of the book, the same way the server builds it
+func levels(b *Book) string {
+	bids, asks := b.Depth(checksumDepth)
+	var parts []string
+	for i := 0; i < len(bids) || i < len(asks); i++ {
+		if i < len(bids) {
+			parts = append(parts, bids[i].RawDepthPrice+":"+bids[i].RawSize)
+		}
+		if i < len(asks) {
+			parts = append(parts, asks[i].RawDepthPrice+":"+asks[i].RawSize)
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+func TestChecksumDocsExample(t *testing.T) {
+	// https://www.okx.com/docs-v5/en/#overview-websocket-checksum
+	tests := []struct {
+		bids, asks string
+		want       string
+		checksum   int32
+	}{
+		{
+			`[["3366.1","7","0","3"],["3366","6","3","4"]]`,
+			`[["3366.8","9","10","3"],["3368","8","3","4"]]`,
+			"3366.1:7:3366.8:9:3366:6:3368:8",
+			-1881014294,
+		},
+		{
+			`[["3366.1","7","0","3"],["3366","6","3","4"]]`,
+			`[["3366.8","9","10","3"]]`,
+			"3366.1:7:3366.8:9:3366:6",
+			1164732920,
+		},
+	}
+	for _, tt := range tests {
+		if got := checksumOf(tt.want); got != tt.checksum {
+			t.Fatalf("crc32(%s) = %d, want %d", tt.want, got, tt.checksum)
+		}
+		b := NewBook("BTC-USDT")
+		cs := tt.checksum
+		if err := b.Apply(push{action: "snapshot", bids: tt.bids, asks: tt.asks, seqID: 1, checksum: &cs}.event(t)); err != nil {
+			t.Fatalf("%s: %v", tt.want, err)
+		}
+		if got := levels(b); got != tt.want {
+			t.Fatalf("book %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	snapshot := push{
+		action: "snapshot",
+		bids:   `[["3366.1","7","0","3"],["3366","6","3","4"]]`,
+		asks:   `[["3366.8","9","10","3"],["3368","8","3","4"]]`,
+		seqID:  10,
+		want:   "3366.1:7:3366.8:9:3366:6:3368:8",
+	}
+	bad := int32(1)
+	tests := []struct {
+		name   string
+		pushes []push
+		err    error // of the last push
+		synced bool
+		seqID  int64
+	}{
+		{"snapshot", []push{snapshot}, nil, true, 10},
+		{
+			"snapshot checksum mismatch",
+			[]push{{action: "snapshot", bids: snapshot.bids, asks: snapshot.asks, seqID: 10, checksum: &bad}},
+			ErrChecksum, false, 0,
+		},
+		{
+			"update before snapshot is ignored",
+			[]push{{action: "update", bids: `[["3366.1","1","0","1"]]`, asks: `[]`, seqID: 11, prevSeqID: 10}},
+			nil, false, 0,
+		},
+		{
+			"update changes, inserts and removes levels",
+			[]push{snapshot, {
+				action:    "update",
+				bids:      `[["3366.1","1","0","1"],["3366.5","2","0","1"],["3366","0","0","0"]]`,
+				asks:      `[["3367","4","0","1"]]`,
+				seqID:     11,
+				prevSeqID: 10,
+				want:      "3366.5:2:3366.8:9:3366.1:1:3367:4:3368:8",
+			}},
+			nil, true, 11,
+		},
+		{
+			"update without change keeps the seqId",
+			[]push{snapshot, {action: "update", bids: `[]`, asks: `[]`, seqID: 10, prevSeqID: 10, want: snapshot.want}},
+			nil, true, 10,
+		},
+		{
+			"seqId gap",
+			[]push{snapshot, {action: "update", bids: `[["3366.1","1","0","1"]]`, asks: `[]`, seqID: 13, prevSeqID: 12, want: "3366.1:1:3366.8:9:3366:6:3368:8"}},
+			ErrSequence, false, 0,
+		},
+		{
+			"update checksum mismatch",
+			[]push{snapshot, {action: "update", bids: `[["3366.1","1","0","1"]]`, asks: `[]`, seqID: 11, prevSeqID: 10, want: snapshot.want}},
+			ErrChecksum, false, 0,
+		},
+		{
+			"snapshot after a gap resyncs",
+			[]push{
+				snapshot,
+				{action: "update", bids: `[]`, asks: `[]`, seqID: 13, prevSeqID: 12, want: snapshot.want},
+				{action: "snapshot", bids: `[["3370","1","0","1"]]`, asks: `[["3371","2","0","1"]]`, seqID: 20, want: "3370:1:3371:2"},
+			},
+			nil, true, 20,
+		},
+		{
+			"snapshot replaces every level",
+			[]push{snapshot, {action: "snapshot", bids: `[["3370","1","0","1"]]`, asks: `[]`, seqID: 20, want: "3370:1"}},
+			nil, true, 20,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBook("BTC-USDT")
+			var err error
+			for _, p := range tt.pushes {
+				err = b.Apply(p.event(t))
+			}
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Apply() = %v, want %v", err, tt.err)
+			}
+			if b.Synced() != tt.synced || b.SeqID() != tt.seqID {
+				t.Fatalf("synced %v seqId %d, want %v %d", b.Synced(), b.SeqID(), tt.synced, tt.seqID)
+			}
+			last := tt.pushes[len(tt.pushes)-1]
+			if tt.synced && levels(b) != last.want {
+				t.Fatalf("book %s, want %s", levels(b), last.want)
+			}
+			if !tt.synced && levels(b) != "" {
+				t.Fatalf("book %s not cleared", levels(b))
+			}
+		})
+	}
+}
@@ -15,9 +15,14 @@ type (
 		InstType okex.InstrumentType `json:"instType,omitempty"`
 	}
 	GetHistoryPositions struct {
-		Limit    int64 `json:"limit,omitempty,string"`
-		SortType int64 `json:"sortType,omitempty,string"`
-		EndTime  int64 `json:"t,omitempty,string"`
+		InstType okex.InstrumentType    `json:"instType,omitempty"`
+		InstID   string                 `json:"instId,omitempty"`
+		MgnMode  okex.MarginMode        `json:"mgnMode,omitempty"`
+		Type     okex.PositionCloseType `json:"type,omitempty,string"`
+		PosID    string                 `json:"posId,omitempty"`
+		After    int64                  `json:"after,omitempty,string"`  // uTime in ms, older records
+		Before   int64                  `json:"before,omitempty,string"` // uTime in ms, newer records
+		Limit    int64                  `json:"limit,omitempty,string"`
 	}
 	GetBills struct {
 		Ccy      string              `json:"ccy,omitempty"`
@@ -44,7 +49,7 @@ type (
 	}
 	GetMaxBuySellAmount struct {
 		Ccy    string         `json:"ccy,omitempty"`
-		Px     float64        `json:"px,string,omitempty"`
+		Px     okex.Decimal   `json:"px,omitempty"`
 		InstID []string       `json:"instId"`
 		TdMode okex.TradeMode `json:"tdMode"`
 	}
@@ -56,7 +61,7 @@ type (
 	}
 	IncreaseDecreaseMargin struct {
 		InstID     string            `json:"instId"`
-		Amt        float64           `json:"amt,string"`
+		Amt        okex.Decimal      `json:"amt"`
 		PosSide    okex.PositionSide `json:"posSide"`
 		ActionType okex.CountAction  `json:"actionType"`
 	}
@@ -70,10 +75,11 @@ type (
 		MgnMode okex.MarginMode `json:"mgnMode"`
 	}
 	GetFeeRates struct {
-		InstID   string              `json:"instId,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		Category okex.FeeCategory    `json:"category,omitempty,string"`
-		InstType okex.InstrumentType `json:"instType"`
+		InstID     string              `json:"instId,omitempty"`
+		Uly        string              `json:"uly,omitempty"`
+		InstFamily string              `json:"instFamily,omitempty"`
+		Category   okex.FeeCategory    `json:"category,omitempty,string"`
+		InstType   okex.InstrumentType `json:"instType"`
 	}
 	GetInterestAccrued struct {
 		InstID  string          `json:"instId,omitempty"`
@@ -86,4 +92,99 @@ type (
 	SetGreeks struct {
 		GreeksType okex.GreekType `json:"greeksType"`
 	}
+	SetAccountLevel struct {
+		AcctLv okex.AccountLevel `json:"acctLv"`
+	}
+	SetIsolatedMode struct {
+		IsoMode okex.IsolatedMarginMode `json:"isoMode"`
+		Type    okex.IsolatedMarginType `json:"type"`
+	}
+	SetAutoLoan struct {
+		AutoLoan bool `json:"autoLoan"`
+	}
+	SetRiskOffsetType struct {
+		Type okex.RiskOffsetType `json:"type"`
+	}
+	SetRiskOffsetAmount struct {
+		Ccy            string       `json:"ccy"`
+		ClSpotInUseAmt okex.Decimal `json:"clSpotInUseAmt"`
+	}
+	// SpotBorrowRepay borrows or repays manually in multi-currency margin and portfolio margin
+	SpotBorrowRepay struct {
+		Ccy  string               `json:"ccy"`
+		Side okex.BorrowRepaySide `json:"side"`
+		Amt  okex.Decimal         `json:"amt"`
+	}
+	SetAutoRepay struct {
+		AutoRepay bool `json:"autoRepay"`
+	}
+	GetSpotBorrowRepayHistory struct {
+		Ccy    string               `json:"ccy,omitempty"`
+		Type   okex.BorrowRepayType `json:"type,omitempty"`
+		After  int64                `json:"after,omitempty,string"` // ts in ms
+		Before int64                `json:"before,omitempty,string"`
+		Limit  int64                `json:"limit,omitempty,string"`
+	}
+	// VIPBorrowRepay borrows or repays a VIP loan, OrdID is the loan order to repay
+	VIPBorrowRepay struct {
+		Ccy   string               `json:"ccy"`
+		Side  okex.BorrowRepaySide `json:"side"`
+		Amt   okex.Decimal         `json:"amt"`
+		OrdID string               `json:"ordId,omitempty"`
+	}
+	GetVIPBorrowRepayHistory struct {
+		Ccy    string `json:"ccy,omitempty"`
+		After  int64  `json:"after,omitempty,string"` // ts in ms
+		Before int64  `json:"before,omitempty,string"`
+		Limit  int64  `json:"limit,omitempty,string"`
+	}
+	GetInterestLimits struct {
+		Type okex.LoanType `json:"type,omitempty"`
+		Ccy  string        `json:"ccy,omitempty"`
+	}
+	GetVIPInterest struct {
+		OrdID  string `json:"ordId,omitempty"`
+		Ccy    string `json:"ccy,omitempty"`
+		After  int64  `json:"after,omitempty,string"` // ts in ms
+		Before int64  `json:"before,omitempty,string"`
+		Limit  int64  `json:"limit,omitempty,string"`
+	}
+	GetVIPLoanOrders struct {
+		OrdID  string            `json:"ordId,omitempty"`
+		State  okex.VIPLoanState `json:"state,omitempty"`
+		Ccy    string            `json:"ccy,omitempty"`
+		After  string            `json:"after,omitempty"` // ordId
+		Before string            `json:"before,omitempty"`
+		Limit  int64             `json:"limit,omitempty,string"`
+	}
+	GetVIPLoanOrderDetail struct {
+		OrdID  string `json:"ordId"`
+		Ccy    string `json:"ccy,omitempty"`
+		After  int64  `json:"after,omitempty,string"` // ts in ms
+		Before int64  `json:"before,omitempty,string"`
+		Limit  int64  `json:"limit,omitempty,string"`
+	}
+	// PositionBuilder simulates the margin of hypothetical positions and assets.
+	// InclRealPosAndEq adds them to the real positions and equity, it's always sent and the server default is true.
+	PositionBuilder struct {
+		AcctLv           okex.AccountLevel `json:"acctLv,omitempty"`
+		InclRealPosAndEq bool              `json:"inclRealPosAndEq"`
+		Lever            okex.Decimal      `json:"lever,omitempty"` // cross leverage of multi-currency margin
+		GreeksType       okex.GreekType    `json:"greeksType,omitempty"`
+		IdxVol           okex.Decimal      `json:"idxVol,omitempty"` // index price change, from -0.99 to 1
+		SimPos           []*SimPosition    `json:"simPos,omitempty"`
+		SimAsset         []*SimAsset       `json:"simAsset,omitempty"`
+	}
+	// SimPosition is a derivatives position, Pos is negative for short positions
+	SimPosition struct {
+		InstID string       `json:"instId"`
+		Pos    okex.Decimal `json:"pos"`
+		AvgPx  okex.Decimal `json:"avgPx"`
+		Lever  okex.Decimal `json:"lever,omitempty"`
+	}
+	// SimAsset is a spot asset, Amt is negative for a borrowing
+	SimAsset struct {
+		Ccy string       `json:"ccy"`
+		Amt okex.Decimal `json:"amt"`
+	}
 )
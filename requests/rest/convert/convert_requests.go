@@ -0,0 +1,38 @@
+package convert
+
+import "github.com/pefish/go-okx"
+
+type (
+	GetCurrencyPair struct {
+		FromCcy string `json:"fromCcy"`
+		ToCcy   string `json:"toCcy"`
+	}
+	// EstimateQuote asks a quote to buy or sell BaseCcy against QuoteCcy, RfqSz is an amount of RfqSzCcy
+	EstimateQuote struct {
+		BaseCcy  string         `json:"baseCcy"`
+		QuoteCcy string         `json:"quoteCcy"`
+		Side     okex.OrderSide `json:"side"`
+		RfqSz    okex.Decimal   `json:"rfqSz"`
+		RfqSzCcy string         `json:"rfqSzCcy"`
+		ClQReqID string         `json:"clQReqId,omitempty"`
+		Tag      string         `json:"tag,omitempty"`
+	}
+	// Trade executes a quote before it expires, Sz and SzCcy are the RfqSz and RfqSzCcy of the quote
+	Trade struct {
+		QuoteID  string         `json:"quoteId"`
+		BaseCcy  string         `json:"baseCcy"`
+		QuoteCcy string         `json:"quoteCcy"`
+		Side     okex.OrderSide `json:"side"`
+		Sz       okex.Decimal   `json:"sz"`
+		SzCcy    string         `json:"szCcy"`
+		ClTReqID string         `json:"clTReqId,omitempty"`
+		Tag      string         `json:"tag,omitempty"`
+	}
+	GetHistory struct {
+		ClTReqID string `json:"clTReqId,omitempty"`
+		After    int64  `json:"after,omitempty,string"` // ts in ms
+		Before   int64  `json:"before,omitempty,string"`
+		Limit    int64  `json:"limit,omitempty,string"`
+		Tag      string `json:"tag,omitempty"`
+	}
+)
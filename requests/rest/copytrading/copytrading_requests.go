@@ -0,0 +1,56 @@
+package copytrading
+
+import "github.com/pefish/go-okx"
+
+type (
+	// GetSubPositions lists the lead positions, InstType defaults to SWAP
+	GetSubPositions struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+		InstID   string              `json:"instId,omitempty"`
+		After    string              `json:"after,omitempty"` // subPosId
+		Before   string              `json:"before,omitempty"`
+		Limit    int64               `json:"limit,omitempty,string"`
+	}
+	// PlaceSubPositionTpSl places or amends the take profit and the stop loss of a lead position, an empty price leaves it unchanged and 0 cancels it
+	PlaceSubPositionTpSl struct {
+		InstType        okex.InstrumentType  `json:"instType,omitempty"`
+		SubPosID        string               `json:"subPosId"`
+		SubPosType      okex.SubPositionType `json:"subPosType,omitempty"`
+		TpTriggerPx     okex.Decimal         `json:"tpTriggerPx,omitempty"`
+		SlTriggerPx     okex.Decimal         `json:"slTriggerPx,omitempty"`
+		TpOrdPx         okex.Decimal         `json:"tpOrdPx,omitempty"` // -1 for a market order
+		SlOrdPx         okex.Decimal         `json:"slOrdPx,omitempty"`
+		TpTriggerPxType okex.TriggerPxType   `json:"tpTriggerPxType,omitempty"`
+		SlTriggerPxType okex.TriggerPxType   `json:"slTriggerPxType,omitempty"`
+		Tag             string               `json:"tag,omitempty"`
+	}
+	// CloseSubPosition closes a lead position at market, or at Px with a limit order
+	CloseSubPosition struct {
+		InstType   okex.InstrumentType  `json:"instType,omitempty"`
+		SubPosID   string               `json:"subPosId"`
+		SubPosType okex.SubPositionType `json:"subPosType,omitempty"`
+		OrdType    okex.OrderType       `json:"ordType,omitempty"`
+		Px         okex.Decimal         `json:"px,omitempty"`
+		Tag        string               `json:"tag,omitempty"`
+	}
+	GetInstruments struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+	}
+	// SetInstruments sets the instruments that are lead traded, the ones left out stop being lead traded
+	SetInstruments struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+		InstID   []string            `json:"instId"`
+	}
+	GetProfitSharingDetails struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+		After    string              `json:"after,omitempty"` // profitSharingId
+		Before   string              `json:"before,omitempty"`
+		Limit    int64               `json:"limit,omitempty,string"`
+	}
+	GetTotalProfitSharing struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+	}
+	GetUnrealizedProfitSharing struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+	}
+)
@@ -4,9 +4,10 @@ import "github.com/pefish/go-okx"
 
 type (
 	GetInstruments struct {
-		Uly      string              `json:"uly,omitempty"`
-		InstID   string              `json:"instId,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
+		Uly        string              `json:"uly,omitempty"`
+		InstFamily string              `json:"instFamily,omitempty"`
+		InstID     string              `json:"instId,omitempty"`
+		InstType   okex.InstrumentType `json:"instType"`
 	}
 	GetDeliveryExerciseHistory struct {
 		Uly      string              `json:"uly"`
@@ -57,15 +58,24 @@ type (
 		InstType okex.InstrumentType `json:"instType"`
 	}
 	GetPositionTiers struct {
-		InstID   string              `json:"instId,omitempty"`
-		Uly      string              `json:"uly,omitempty"`
-		InstType okex.InstrumentType `json:"instType"`
-		TdMode   okex.TradeMode      `json:"tdMode"`
-		Tier     okex.JSONInt64      `json:"tier,omitempty"`
+		InstID     string              `json:"instId,omitempty"`
+		Uly        string              `json:"uly,omitempty"`
+		InstFamily string              `json:"instFamily,omitempty"`
+		InstType   okex.InstrumentType `json:"instType"`
+		TdMode     okex.TradeMode      `json:"tdMode"`
+		Tier       okex.JSONInt64      `json:"tier,omitempty"`
 	}
 	GetUnderlying struct {
 		InstType okex.InstrumentType `json:"instType"`
 	}
+	ConvertContractCoin struct {
+		InstID string             `json:"instId"`
+		Sz     okex.Decimal       `json:"sz"`
+		Px     okex.Decimal       `json:"px,omitempty"`
+		Type   okex.ConvertType   `json:"type,omitempty"`
+		Unit   okex.ConvertUnit   `json:"unit,omitempty"`
+		OpType okex.ConvertOpType `json:"opType,omitempty"`
+	}
 	Status struct {
 		State string `json:"state,omitempty"`
 	}
@@ -0,0 +1,108 @@
+package rfq
+
+import "github.com/pefish/go-okx"
+
+type (
+	// CreateRfq asks Counterparties to quote a structure of up to 15 legs
+	CreateRfq struct {
+		Counterparties        []string `json:"counterparties"`
+		Anonymous             bool     `json:"anonymous,omitempty"`
+		AllowPartialExecution bool     `json:"allowPartialExecution,omitempty"`
+		ClRfqID               string   `json:"clRfqId,omitempty"`
+		Tag                   string   `json:"tag,omitempty"`
+		Legs                  []*Leg   `json:"legs"`
+	}
+	// Leg is a leg of an RFQ, Side is the side of the taker
+	Leg struct {
+		InstID  string            `json:"instId"`
+		TdMode  okex.TradeMode    `json:"tdMode,omitempty"`
+		Ccy     string            `json:"ccy,omitempty"`
+		Sz      okex.Decimal      `json:"sz"`
+		Side    okex.OrderSide    `json:"side"`
+		PosSide okex.PositionSide `json:"posSide,omitempty"`
+		TgtCcy  okex.QuantityType `json:"tgtCcy,omitempty"`
+	}
+	CancelRfq struct {
+		RfqID   string `json:"rfqId,omitempty"`
+		ClRfqID string `json:"clRfqId,omitempty"`
+	}
+	CancelBatchRfqs struct {
+		RfqIDs   []string `json:"rfqIds,omitempty"`
+		ClRfqIDs []string `json:"clRfqIds,omitempty"`
+	}
+	// CreateQuote quotes an RFQ, QuoteSide is the side of the maker on the legs of the RFQ
+	CreateQuote struct {
+		RfqID     string         `json:"rfqId"`
+		ClQuoteID string         `json:"clQuoteId,omitempty"`
+		Tag       string         `json:"tag,omitempty"`
+		Anonymous bool           `json:"anonymous,omitempty"`
+		QuoteSide okex.OrderSide `json:"quoteSide"`
+		ExpiresIn int64          `json:"expiresIn,omitempty,string"` // seconds, 10 to 120, 60 by default
+		Legs      []*QuoteLeg    `json:"legs"`
+	}
+	QuoteLeg struct {
+		InstID  string            `json:"instId"`
+		TdMode  okex.TradeMode    `json:"tdMode,omitempty"`
+		Ccy     string            `json:"ccy,omitempty"`
+		Sz      okex.Decimal      `json:"sz"`
+		Px      okex.Decimal      `json:"px"`
+		Side    okex.OrderSide    `json:"side"`
+		PosSide okex.PositionSide `json:"posSide,omitempty"`
+		TgtCcy  okex.QuantityType `json:"tgtCcy,omitempty"`
+	}
+	CancelQuote struct {
+		QuoteID   string `json:"quoteId,omitempty"`
+		ClQuoteID string `json:"clQuoteId,omitempty"`
+		RfqID     string `json:"rfqId,omitempty"`
+	}
+	CancelBatchQuotes struct {
+		QuoteIDs   []string `json:"quoteIds,omitempty"`
+		ClQuoteIDs []string `json:"clQuoteIds,omitempty"`
+	}
+	// ExecuteQuote executes a quote of an RFQ, Legs executes a part of it when the RFQ allows partial execution
+	ExecuteQuote struct {
+		RfqID   string        `json:"rfqId"`
+		QuoteID string        `json:"quoteId"`
+		Legs    []*ExecuteLeg `json:"legs,omitempty"`
+	}
+	ExecuteLeg struct {
+		InstID string       `json:"instId"`
+		Sz     okex.Decimal `json:"sz"`
+	}
+	GetRfqs struct {
+		RfqID   string        `json:"rfqId,omitempty"`
+		ClRfqID string        `json:"clRfqId,omitempty"`
+		State   okex.RfqState `json:"state,omitempty"`
+		BeginID string        `json:"beginId,omitempty"`
+		EndID   string        `json:"endId,omitempty"`
+		Limit   int64         `json:"limit,omitempty,string"`
+	}
+	GetQuotes struct {
+		RfqID     string          `json:"rfqId,omitempty"`
+		ClRfqID   string          `json:"clRfqId,omitempty"`
+		QuoteID   string          `json:"quoteId,omitempty"`
+		ClQuoteID string          `json:"clQuoteId,omitempty"`
+		State     okex.QuoteState `json:"state,omitempty"`
+		BeginID   string          `json:"beginId,omitempty"`
+		EndID     string          `json:"endId,omitempty"`
+		Limit     int64           `json:"limit,omitempty,string"`
+	}
+	// GetTrades lists the block trades of the account, BeginTs and EndTs are Unix timestamps in milliseconds
+	GetTrades struct {
+		RfqID     string `json:"rfqId,omitempty"`
+		ClRfqID   string `json:"clRfqId,omitempty"`
+		QuoteID   string `json:"quoteId,omitempty"`
+		ClQuoteID string `json:"clQuoteId,omitempty"`
+		BlockTdID string `json:"blockTdId,omitempty"`
+		BeginID   string `json:"beginId,omitempty"`
+		EndID     string `json:"endId,omitempty"`
+		BeginTs   int64  `json:"beginTs,omitempty,string"`
+		EndTs     int64  `json:"endTs,omitempty,string"`
+		Limit     int64  `json:"limit,omitempty,string"`
+	}
+	GetPublicTrades struct {
+		BeginID string `json:"beginId,omitempty"`
+		EndID   string `json:"endId,omitempty"`
+		Limit   int64  `json:"limit,omitempty,string"`
+	}
+)
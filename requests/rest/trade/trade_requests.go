@@ -1,22 +1,45 @@
 package trade
 
-import okex "github.com/pefish/go-okx"
+import (
+	"time"
+
+	okex "github.com/pefish/go-okx"
+)
 
 type (
 	PlaceOrder struct {
-		ID         string            `json:"-"`
-		InstID     string            `json:"instId"`
-		Ccy        string            `json:"ccy,omitempty"`
-		ClOrdID    string            `json:"clOrdId,omitempty"`
-		Tag        string            `json:"tag,omitempty"`
-		ReduceOnly bool              `json:"reduceOnly,omitempty"`
-		Sz         float64           `json:"sz,string"`
-		Px         float64           `json:"px,omitempty,string"`
-		TdMode     okex.TradeMode    `json:"tdMode"`
-		Side       okex.OrderSide    `json:"side"`
-		PosSide    okex.PositionSide `json:"posSide,omitempty"`
-		OrdType    okex.OrderType    `json:"ordType"`
-		TgtCcy     okex.QuantityType `json:"tgtCcy,omitempty"`
+		ID             string               `json:"-"`
+		InstID         string               `json:"instId"`
+		Ccy            string               `json:"ccy,omitempty"`
+		ClOrdID        string               `json:"clOrdId,omitempty"`
+		Tag            string               `json:"tag,omitempty"`
+		ReduceOnly     bool                 `json:"reduceOnly,omitempty"`
+		Sz             okex.Decimal         `json:"sz"`
+		Px             okex.Decimal         `json:"px,omitempty"`
+		PxUsd          okex.Decimal         `json:"pxUsd,omitempty"`
+		PxVol          okex.Decimal         `json:"pxVol,omitempty"`
+		TdMode         okex.TradeMode       `json:"tdMode"`
+		Side           okex.OrderSide       `json:"side"`
+		PosSide        okex.PositionSide    `json:"posSide,omitempty"`
+		OrdType        okex.OrderType       `json:"ordType"`
+		TgtCcy         okex.QuantityType    `json:"tgtCcy,omitempty"`
+		StpMode        okex.SelfTradeMode   `json:"stpMode,omitempty"`
+		BanAmend       bool                 `json:"banAmend,omitempty"`
+		QuickMgnType   okex.QuickMarginType `json:"quickMgnType,omitempty"`
+		AttachAlgoOrds []*AttachAlgoOrder   `json:"attachAlgoOrds,omitempty"`
+		ExpTime        time.Time            `json:"-"` // the order is rejected when the server gets it after ExpTime
+	}
+	AttachAlgoOrder struct {
+		AttachAlgoClOrdID    string             `json:"attachAlgoClOrdId,omitempty"`
+		TpTriggerPx          okex.Decimal       `json:"tpTriggerPx,omitempty"`
+		TpOrdPx              okex.Decimal       `json:"tpOrdPx,omitempty"`
+		TpOrdKind            okex.TpOrdKind     `json:"tpOrdKind,omitempty"`
+		TpTriggerPxType      okex.TriggerPxType `json:"tpTriggerPxType,omitempty"`
+		SlTriggerPx          okex.Decimal       `json:"slTriggerPx,omitempty"`
+		SlOrdPx              okex.Decimal       `json:"slOrdPx,omitempty"`
+		SlTriggerPxType      okex.TriggerPxType `json:"slTriggerPxType,omitempty"`
+		Sz                   okex.Decimal       `json:"sz,omitempty"`
+		AmendPxOnTriggerType string             `json:"amendPxOnTriggerType,omitempty"`
 	}
 	CancelOrder struct {
 		ID      string `json:"-"`
@@ -25,20 +48,46 @@ type (
 		ClOrdID string `json:"clOrdId,omitempty"`
 	}
 	AmendOrder struct {
-		ID        string  `json:"-"`
-		InstID    string  `json:"instId"`
-		OrdID     string  `json:"ordId,omitempty"`
-		ClOrdID   string  `json:"clOrdId,omitempty"`
-		ReqID     string  `json:"reqId,omitempty"`
-		NewSz     int64   `json:"newSz,omitempty,string"`
-		NewPx     float64 `json:"newPx,omitempty,string"`
-		CxlOnFail bool    `json:"cxlOnFail,omitempty"`
+		ID             string                  `json:"-"`
+		InstID         string                  `json:"instId"`
+		OrdID          string                  `json:"ordId,omitempty"`
+		ClOrdID        string                  `json:"clOrdId,omitempty"`
+		ReqID          string                  `json:"reqId,omitempty"`
+		NewSz          okex.Decimal            `json:"newSz,omitempty"`
+		NewPx          okex.Decimal            `json:"newPx,omitempty"`
+		NewPxUsd       okex.Decimal            `json:"newPxUsd,omitempty"`
+		NewPxVol       okex.Decimal            `json:"newPxVol,omitempty"`
+		CxlOnFail      bool                    `json:"cxlOnFail,omitempty"`
+		AttachAlgoOrds []*AmendAttachAlgoOrder `json:"attachAlgoOrds,omitempty"`
+		ExpTime        time.Time               `json:"-"` // the amendment is rejected when the server gets it after ExpTime
+	}
+	AmendAttachAlgoOrder struct {
+		AttachAlgoID         string             `json:"attachAlgoId,omitempty"`
+		AttachAlgoClOrdID    string             `json:"attachAlgoClOrdId,omitempty"`
+		NewTpTriggerPx       okex.Decimal       `json:"newTpTriggerPx,omitempty"`
+		NewTpOrdPx           okex.Decimal       `json:"newTpOrdPx,omitempty"`
+		NewTpOrdKind         okex.TpOrdKind     `json:"newTpOrdKind,omitempty"`
+		NewTpTriggerPxType   okex.TriggerPxType `json:"newTpTriggerPxType,omitempty"`
+		NewSlTriggerPx       okex.Decimal       `json:"newSlTriggerPx,omitempty"`
+		NewSlOrdPx           okex.Decimal       `json:"newSlOrdPx,omitempty"`
+		NewSlTriggerPxType   okex.TriggerPxType `json:"newSlTriggerPxType,omitempty"`
+		Sz                   okex.Decimal       `json:"sz,omitempty"`
+		AmendPxOnTriggerType string             `json:"amendPxOnTriggerType,omitempty"`
 	}
 	ClosePosition struct {
 		InstID  string            `json:"instId"`
 		Ccy     string            `json:"ccy,omitempty"`
 		PosSide okex.PositionSide `json:"posSide,omitempty"`
 		MgnMode okex.MarginMode   `json:"mgnMode"`
+		AutoCxl bool              `json:"autoCxl,omitempty"` // cancel the pending orders blocking the close instead of failing
+		ClOrdID string            `json:"clOrdId,omitempty"`
+		Tag     string            `json:"tag,omitempty"`
+	}
+	// MassCancel cancels the MMP pending orders of an option instrument family, LockInterval (ms) blocks trading after it
+	MassCancel struct {
+		InstType     okex.InstrumentType `json:"instType"`
+		InstFamily   string              `json:"instFamily"`
+		LockInterval int64               `json:"lockInterval,omitempty,string"`
 	}
 	OrderDetails struct {
 		InstID  string `json:"instId"`
@@ -48,8 +97,8 @@ type (
 	OrderList struct {
 		Uly      string              `json:"uly,omitempty"`
 		InstID   string              `json:"instId,omitempty"`
-		After    float64             `json:"after,omitempty,string"`
-		Before   float64             `json:"before,omitempty,string"`
+		After    string              `json:"after,omitempty"` // ordId
+		Before   string              `json:"before,omitempty"`
 		Limit    float64             `json:"limit,omitempty,string"`
 		InstType okex.InstrumentType `json:"instType,omitempty"`
 		OrdType  okex.OrderType      `json:"ordType,omitempty"`
@@ -64,53 +113,140 @@ type (
 		Limit    float64             `json:"limit,omitempty,string"`
 		InstType okex.InstrumentType `json:"instType,omitempty"`
 	}
+	// FillsHistory lists the fills of the last 3 months, After and Before are bill ids, Begin and End are Unix timestamps in milliseconds
+	FillsHistory struct {
+		InstType   okex.InstrumentType `json:"instType"`
+		InstFamily string              `json:"instFamily,omitempty"`
+		InstID     string              `json:"instId,omitempty"`
+		OrdID      string              `json:"ordId,omitempty"`
+		SubType    okex.BillSubType    `json:"subType,omitempty,string"`
+		After      string              `json:"after,omitempty"`
+		Before     string              `json:"before,omitempty"`
+		Begin      int64               `json:"begin,omitempty,string"`
+		End        int64               `json:"end,omitempty,string"`
+		Limit      int64               `json:"limit,omitempty,string"`
+	}
 	PlaceAlgoOrder struct {
-		InstID     string             `json:"instId"`
-		TdMode     okex.TradeMode     `json:"tdMode"`
-		Ccy        string             `json:"ccy,omitempty"`
-		Side       okex.OrderSide     `json:"side"`
-		PosSide    okex.PositionSide  `json:"posSide,omitempty"`
-		OrdType    okex.AlgoOrderType `json:"ordType"`
-		Sz         int64              `json:"sz,string"`
-		ReduceOnly bool               `json:"reduceOnly,omitempty"`
-		TgtCcy     okex.QuantityType  `json:"tgtCcy,omitempty"`
+		InstID        string             `json:"instId"`
+		TdMode        okex.TradeMode     `json:"tdMode"`
+		Ccy           string             `json:"ccy,omitempty"`
+		Side          okex.OrderSide     `json:"side"`
+		PosSide       okex.PositionSide  `json:"posSide,omitempty"`
+		OrdType       okex.AlgoOrderType `json:"ordType"`
+		Sz            okex.Decimal       `json:"sz,omitempty"`
+		AlgoClOrdID   string             `json:"algoClOrdId,omitempty"`
+		Tag           string             `json:"tag,omitempty"`
+		ReduceOnly    bool               `json:"reduceOnly,omitempty"`
+		CloseFraction okex.Decimal       `json:"closeFraction,omitempty"`
+		TgtCcy        okex.QuantityType  `json:"tgtCcy,omitempty"`
 		StopOrder
 		TriggerOrder
 		IcebergOrder
 		TWAPOrder
+		MoveStopOrder
+		ChaseOrder
 	}
 	StopOrder struct {
-		TpTriggerPx float64 `json:"tpTriggerPx,string,omitempty"`
-		TpOrdPx     float64 `json:"tpOrdPx,string,omitempty"`
-		SlTriggerPx float64 `json:"slTriggerPx,string,omitempty"`
-		SlOrdPx     float64 `json:"slOrdPx,string,omitempty"`
+		TpTriggerPx     okex.Decimal       `json:"tpTriggerPx,omitempty"`
+		TpOrdPx         okex.Decimal       `json:"tpOrdPx,omitempty"`
+		TpTriggerPxType okex.TriggerPxType `json:"tpTriggerPxType,omitempty"`
+		SlTriggerPx     okex.Decimal       `json:"slTriggerPx,omitempty"`
+		SlOrdPx         okex.Decimal       `json:"slOrdPx,omitempty"`
+		SlTriggerPxType okex.TriggerPxType `json:"slTriggerPxType,omitempty"`
 	}
 	TriggerOrder struct {
-		TriggerPx float64 `json:"triggerPx,string,omitempty"`
-		OrdPx     float64 `json:"ordPx,string,omitempty"`
+		TriggerPx     okex.Decimal       `json:"triggerPx,omitempty"`
+		OrdPx         okex.Decimal       `json:"ordPx,omitempty"`
+		TriggerPxType okex.TriggerPxType `json:"triggerPxType,omitempty"`
 	}
 	IcebergOrder struct {
-		PxVar    float64 `json:"pxVar,string,omitempty"`
-		PxSpread float64 `json:"pxSpread,string,omitempty"`
-		SzLimit  int64   `json:"szLimit,string"`
-		PxLimit  float64 `json:"pxLimit,string"`
+		PxVar    okex.Decimal `json:"pxVar,omitempty"`
+		PxSpread okex.Decimal `json:"pxSpread,omitempty"`
+		SzLimit  okex.Decimal `json:"szLimit,omitempty"`
+		PxLimit  okex.Decimal `json:"pxLimit,omitempty"`
 	}
 	TWAPOrder struct {
-		IcebergOrder
-		TimeInterval string `json:"timeInterval"`
+		TimeInterval string `json:"timeInterval,omitempty"`
+	}
+	// MoveStopOrder is a trailing stop, it follows the price by CallbackRatio or CallbackSpread once ActivePx is reached
+	MoveStopOrder struct {
+		CallbackRatio  okex.Decimal `json:"callbackRatio,omitempty"`
+		CallbackSpread okex.Decimal `json:"callbackSpread,omitempty"`
+		ActivePx       okex.Decimal `json:"activePx,omitempty"`
+	}
+	// ChaseOrder keeps a limit order at the best bid or ask, at ChaseVal of it, until the price moved by MaxChaseVal
+	ChaseOrder struct {
+		ChaseType    okex.ChaseType `json:"chaseType,omitempty"`
+		ChaseVal     okex.Decimal   `json:"chaseVal,omitempty"`
+		MaxChaseType okex.ChaseType `json:"maxChaseType,omitempty"`
+		MaxChaseVal  okex.Decimal   `json:"maxChaseVal,omitempty"`
 	}
 	CancelAlgoOrder struct {
 		InstID string `json:"instId"`
-		AlgoID string `json:"AlgoId"`
+		AlgoID string `json:"algoId"`
+	}
+	AmendAlgoOrder struct {
+		InstID             string             `json:"instId"`
+		AlgoID             string             `json:"algoId,omitempty"`
+		AlgoClOrdID        string             `json:"algoClOrdId,omitempty"`
+		ReqID              string             `json:"reqId,omitempty"`
+		CxlOnFail          bool               `json:"cxlOnFail,omitempty"`
+		NewSz              okex.Decimal       `json:"newSz,omitempty"`
+		NewTpTriggerPx     okex.Decimal       `json:"newTpTriggerPx,omitempty"`
+		NewTpOrdPx         okex.Decimal       `json:"newTpOrdPx,omitempty"`
+		NewTpTriggerPxType okex.TriggerPxType `json:"newTpTriggerPxType,omitempty"`
+		NewSlTriggerPx     okex.Decimal       `json:"newSlTriggerPx,omitempty"`
+		NewSlOrdPx         okex.Decimal       `json:"newSlOrdPx,omitempty"`
+		NewSlTriggerPxType okex.TriggerPxType `json:"newSlTriggerPxType,omitempty"`
+		NewTriggerPx       okex.Decimal       `json:"newTriggerPx,omitempty"`
+		NewOrdPx           okex.Decimal       `json:"newOrdPx,omitempty"`
+		NewTriggerPxType   okex.TriggerPxType `json:"newTriggerPxType,omitempty"`
+	}
+	// CancelAllAfter arms the countdown cancelling every order when it elapses, a TimeOut of 0 disarms it
+	CancelAllAfter struct {
+		TimeOut int64  `json:"timeOut,string"`
+		Tag     string `json:"tag,omitempty"`
+	}
+	AlgoOrderDetails struct {
+		AlgoID      string `json:"algoId,omitempty"`
+		AlgoClOrdID string `json:"algoClOrdId,omitempty"`
 	}
 	AlgoOrderList struct {
 		InstType okex.InstrumentType `json:"instType,omitempty"`
 		Uly      string              `json:"uly,omitempty"`
 		InstID   string              `json:"instId,omitempty"`
-		After    float64             `json:"after,omitempty,string"`
-		Before   float64             `json:"before,omitempty,string"`
+		AlgoID   string              `json:"algoId,omitempty"`
+		After    string              `json:"after,omitempty"` // algoId
+		Before   string              `json:"before,omitempty"`
 		Limit    float64             `json:"limit,omitempty,string"`
 		OrdType  okex.AlgoOrderType  `json:"ordType,omitempty"`
 		State    okex.OrderState     `json:"state,omitempty"`
 	}
+	GetOneClickRepayCurrencies struct {
+		DebtType okex.DebtType `json:"debtType,omitempty"`
+	}
+	// OneClickRepay repays the debts of DebtCcy by selling RepayCcy, up to 5 debt currencies
+	OneClickRepay struct {
+		DebtCcy  []string `json:"debtCcy"`
+		RepayCcy string   `json:"repayCcy"`
+	}
+	GetOneClickRepayHistory struct {
+		After  int64 `json:"after,omitempty,string"` // uTime in ms
+		Before int64 `json:"before,omitempty,string"`
+		Limit  int64 `json:"limit,omitempty,string"`
+	}
+	GetEasyConvertCurrencies struct {
+		Source okex.EasyConvertSource `json:"source,omitempty"`
+	}
+	// EasyConvert converts the small balances of FromCcy into ToCcy, up to 5 currencies
+	EasyConvert struct {
+		FromCcy []string               `json:"fromCcy"`
+		ToCcy   string                 `json:"toCcy"`
+		Source  okex.EasyConvertSource `json:"source,omitempty"`
+	}
+	GetEasyConvertHistory struct {
+		After  int64 `json:"after,omitempty,string"` // uTime in ms
+		Before int64 `json:"before,omitempty,string"`
+		Limit  int64 `json:"limit,omitempty,string"`
+	}
 )
@@ -0,0 +1,466 @@
+package trade
+
+import (
+	"time"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pkg/errors"
+)
+
+// Validate checks the order before it's sent, the instrument rules like tick and lot sizes are checked by the normalizer package
+func (o PlaceOrder) Validate() error {
+	if o.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if o.TdMode == "" || o.Side == "" || o.OrdType == "" {
+		return errors.Errorf("%s: tdMode, side and ordType are required", o.InstID)
+	}
+	if err := positive("sz", o.Sz, true); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if err := validClientID("clOrdId", o.ClOrdID); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if len(o.Tag) > 16 {
+		return errors.Errorf("%s: tag is longer than 16 characters", o.InstID)
+	}
+	if err := validExpTime(o.ExpTime); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+
+	option := isOption(o.InstID)
+	prices := 0
+	for _, p := range []struct {
+		name string
+		v    okex.Decimal
+	}{{"px", o.Px}, {"pxUsd", o.PxUsd}, {"pxVol", o.PxVol}} {
+		if p.v == "" {
+			continue
+		}
+		prices++
+		if err := positive(p.name, p.v, false); err != nil {
+			return errors.Wrap(err, o.InstID)
+		}
+		if p.name != "px" && !option {
+			return errors.Errorf("%s: %s is only applicable to options", o.InstID, p.name)
+		}
+	}
+	if prices > 1 {
+		return errors.Errorf("%s: only one of px, pxUsd and pxVol can be set", o.InstID)
+	}
+	switch o.OrdType {
+	case okex.OrderMarket, okex.OrderOptimalLimitIoc:
+	case okex.OrderLimit, okex.OrderPostOnly, okex.OrderFOK, okex.OrderIOC, okex.OrderELP:
+		if prices == 0 {
+			return errors.Errorf("%s: a price is required by %s orders", o.InstID, o.OrdType)
+		}
+	case okex.OrderMMP, okex.OrderMMPAndPostOnly, okex.OrderOpFOK:
+		if !option {
+			return errors.Errorf("%s: %s orders are only applicable to options", o.InstID, o.OrdType)
+		}
+		if prices == 0 {
+			return errors.Errorf("%s: a price is required by %s orders", o.InstID, o.OrdType)
+		}
+	default:
+		return errors.Errorf("%s: unknown ordType %q", o.InstID, o.OrdType)
+	}
+
+	switch o.StpMode {
+	case "", okex.SelfTradeCancelMaker, okex.SelfTradeCancelTaker, okex.SelfTradeCancelBoth:
+	default:
+		return errors.Errorf("%s: unknown stpMode %q", o.InstID, o.StpMode)
+	}
+	switch o.QuickMgnType {
+	case "":
+	case okex.QuickMarginManual, okex.QuickMarginAutoBorrow, okex.QuickMarginAutoRepay:
+		if o.TdMode == okex.TradeCashMode || isDerivative(o.InstID) {
+			return errors.Errorf("%s: quickMgnType is only applicable to margin orders", o.InstID)
+		}
+	default:
+		return errors.Errorf("%s: unknown quickMgnType %q", o.InstID, o.QuickMgnType)
+	}
+
+	for _, a := range o.AttachAlgoOrds {
+		if err := a.validate(len(o.AttachAlgoOrds) > 1); err != nil {
+			return errors.Wrap(err, o.InstID)
+		}
+	}
+	return nil
+}
+
+// validate checks an attached TP/SL, split take profits need their own sz
+func (a *AttachAlgoOrder) validate(split bool) error {
+	if a == nil {
+		return errors.New("attachAlgoOrds: nil order")
+	}
+	if err := validClientID("attachAlgoClOrdId", a.AttachAlgoClOrdID); err != nil {
+		return err
+	}
+	if a.TpTriggerPx == "" && a.SlTriggerPx == "" && a.TpOrdKind != okex.TpOrdLimit {
+		return errors.New("attachAlgoOrds: tpTriggerPx or slTriggerPx is required")
+	}
+	if err := triggerPair("tp", a.TpTriggerPx, a.TpOrdPx, a.TpOrdKind == okex.TpOrdLimit); err != nil {
+		return err
+	}
+	if err := triggerPair("sl", a.SlTriggerPx, a.SlOrdPx, false); err != nil {
+		return err
+	}
+	if err := validTriggerPxType(a.TpTriggerPxType, a.SlTriggerPxType); err != nil {
+		return err
+	}
+	if split {
+		if err := positive("attachAlgoOrds sz", a.Sz, true); err != nil {
+			return errors.Wrap(err, "split take profits")
+		}
+	}
+	return validAmendPxOnTriggerType(a.AmendPxOnTriggerType)
+}
+
+// Validate checks the cancellation before it's sent
+func (o CancelOrder) Validate() error {
+	if o.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if o.OrdID == "" && o.ClOrdID == "" {
+		return errors.Errorf("%s: ordId or clOrdId is required", o.InstID)
+	}
+	return nil
+}
+
+// Validate checks the amendment before it's sent
+func (o AmendOrder) Validate() error {
+	if o.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if o.OrdID == "" && o.ClOrdID == "" {
+		return errors.Errorf("%s: ordId or clOrdId is required", o.InstID)
+	}
+	if o.NewSz == "" && o.NewPx == "" && o.NewPxUsd == "" && o.NewPxVol == "" && len(o.AttachAlgoOrds) == 0 {
+		return errors.Errorf("%s: nothing to amend", o.InstID)
+	}
+	if err := validExpTime(o.ExpTime); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if err := positive("newSz", o.NewSz, false); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	prices := 0
+	for _, p := range []struct {
+		name string
+		v    okex.Decimal
+	}{{"newPx", o.NewPx}, {"newPxUsd", o.NewPxUsd}, {"newPxVol", o.NewPxVol}} {
+		if p.v == "" {
+			continue
+		}
+		prices++
+		if err := positive(p.name, p.v, false); err != nil {
+			return errors.Wrap(err, o.InstID)
+		}
+		if p.name != "newPx" && !isOption(o.InstID) {
+			return errors.Errorf("%s: %s is only applicable to options", o.InstID, p.name)
+		}
+	}
+	if prices > 1 {
+		return errors.Errorf("%s: only one of newPx, newPxUsd and newPxVol can be set", o.InstID)
+	}
+	for _, a := range o.AttachAlgoOrds {
+		if err := a.validate(); err != nil {
+			return errors.Wrap(err, o.InstID)
+		}
+	}
+	return nil
+}
+
+// validate checks an attached TP/SL amendment, a trigger price of 0 removes the take profit or the stop loss
+func (a *AmendAttachAlgoOrder) validate() error {
+	if a == nil {
+		return errors.New("attachAlgoOrds: nil order")
+	}
+	if a.AttachAlgoID == "" && a.AttachAlgoClOrdID == "" {
+		return errors.New("attachAlgoOrds: attachAlgoId or attachAlgoClOrdId is required")
+	}
+	for _, p := range []struct {
+		name string
+		v    okex.Decimal
+	}{{"newTpTriggerPx", a.NewTpTriggerPx}, {"newSlTriggerPx", a.NewSlTriggerPx}} {
+		if err := decimal(p.name, p.v); err != nil {
+			return err
+		}
+		if p.v != "" && p.v.Sign() < 0 {
+			return errors.Errorf("attachAlgoOrds: %s %s is negative", p.name, p.v)
+		}
+	}
+	if err := validTriggerPxType(a.NewTpTriggerPxType, a.NewSlTriggerPxType); err != nil {
+		return err
+	}
+	if err := positive("attachAlgoOrds sz", a.Sz, false); err != nil {
+		return err
+	}
+	return validAmendPxOnTriggerType(a.AmendPxOnTriggerType)
+}
+
+// triggerPair checks a take profit or a stop loss has both its trigger and order prices, an order price of -1 is a market order
+func triggerPair(name string, trigger, ord okex.Decimal, limit bool) error {
+	if limit {
+		// limit take profits have no trigger
+		return positive(name+"OrdPx", ord, true)
+	}
+	if trigger == "" && ord == "" {
+		return nil
+	}
+	if err := positive(name+"TriggerPx", trigger, true); err != nil {
+		return err
+	}
+	if ord == "" {
+		return errors.Errorf("%sOrdPx is required with %sTriggerPx", name, name)
+	}
+	return orderPx(name+"OrdPx", ord)
+}
+
+// orderPx checks the price of the order placed when an algo order triggers, -1 places a market order
+func orderPx(name string, v okex.Decimal) error {
+	if v == "" {
+		return errors.Errorf("%s is required", name)
+	}
+	if err := decimal(name, v); err != nil {
+		return err
+	}
+	if v.Sign() <= 0 && !v.Equal("-1") {
+		return errors.Errorf("%s %s is neither positive nor -1", name, v)
+	}
+	return nil
+}
+
+// validExpTime checks an expTime is still ahead, the server rejects the request once it passed
+func validExpTime(t time.Time) error {
+	if !t.IsZero() && !t.After(time.Now()) {
+		return errors.Errorf("expTime %s has passed", t.Format(time.RFC3339Nano))
+	}
+	return nil
+}
+
+func validTriggerPxType(types ...okex.TriggerPxType) error {
+	for _, t := range types {
+		switch t {
+		case "", okex.TriggerPxLast, okex.TriggerPxIndex, okex.TriggerPxMark:
+		default:
+			return errors.Errorf("unknown trigger price type %q", t)
+		}
+	}
+	return nil
+}
+
+func validAmendPxOnTriggerType(t string) error {
+	switch t {
+	case "", "0", "1":
+		return nil
+	}
+	return errors.Errorf("amendPxOnTriggerType %q is neither 0 nor 1", t)
+}
+
+// validClientID checks a client supplied id: up to 32 letters and digits, all digits like a timestamp is fine
+func validClientID(name, id string) error {
+	if id == "" {
+		return nil
+	}
+	if len(id) > 32 {
+		return errors.Errorf("%s %q is longer than 32 characters", name, id)
+	}
+	for _, c := range id {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return errors.Errorf("%s %q must be letters and digits", name, id)
+		}
+	}
+	return nil
+}
+
+func decimal(name string, v okex.Decimal) error {
+	if v == "" {
+		return nil
+	}
+	if _, err := okex.NewDecimal(string(v)); err != nil {
+		return errors.Wrap(err, name)
+	}
+	return nil
+}
+
+func positive(name string, v okex.Decimal, required bool) error {
+	if v == "" {
+		if required {
+			return errors.Errorf("%s is required", name)
+		}
+		return nil
+	}
+	if err := decimal(name, v); err != nil {
+		return err
+	}
+	if v.Sign() <= 0 {
+		return errors.Errorf("%s %s is not positive", name, v)
+	}
+	return nil
+}
+
+func isOption(instID string) bool {
+	id, err := okex.ParseInstID(instID)
+	return err == nil && id.Type == okex.OptionsInstrument
+}
+
+func isDerivative(instID string) bool {
+	id, err := okex.ParseInstID(instID)
+	return err == nil && id.IsDerivative()
+}
+
+// Validate checks the algo order before it's sent
+func (o PlaceAlgoOrder) Validate() error {
+	if o.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if o.TdMode == "" || o.Side == "" || o.OrdType == "" {
+		return errors.Errorf("%s: tdMode, side and ordType are required", o.InstID)
+	}
+	if err := validClientID("algoClOrdId", o.AlgoClOrdID); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if o.CloseFraction != "" {
+		if !o.CloseFraction.Equal("1") {
+			return errors.Errorf("%s: closeFraction can only be 1", o.InstID)
+		}
+		if o.OrdType != okex.AlgoOrderConditional && o.OrdType != okex.AlgoOrderOCO && o.OrdType != okex.AlgoOrderMoveStop {
+			return errors.Errorf("%s: closeFraction is not applicable to %s orders", o.InstID, o.OrdType)
+		}
+	} else if err := positive("sz", o.Sz, true); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if err := validTriggerPxType(o.TpTriggerPxType, o.SlTriggerPxType, o.TriggerPxType); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+
+	var err error
+	switch o.OrdType {
+	case okex.AlgoOrderConditional, okex.AlgoOrderOCO:
+		err = o.StopOrder.validate(o.OrdType == okex.AlgoOrderOCO)
+	case okex.AlgoOrderTrigger:
+		err = positive("triggerPx", o.TriggerPx, true)
+		if err == nil {
+			err = orderPx("ordPx", o.OrdPx)
+		}
+	case okex.AlgoOrderIceberg:
+		err = o.IcebergOrder.validate()
+	case okex.AlgoOrderTwap:
+		err = o.IcebergOrder.validate()
+		if err == nil && o.TimeInterval == "" {
+			err = errors.New("timeInterval is required")
+		}
+	case okex.AlgoOrderMoveStop:
+		err = o.MoveStopOrder.validate()
+	case okex.AlgoOrderChase:
+		err = o.ChaseOrder.validate()
+	default:
+		err = errors.Errorf("unknown ordType %q", o.OrdType)
+	}
+	return errors.Wrap(err, o.InstID)
+}
+
+// validate checks a conditional order has a take profit or a stop loss, an oco one needs both
+func (o StopOrder) validate(oco bool) error {
+	if o.TpTriggerPx == "" && o.SlTriggerPx == "" {
+		return errors.New("tpTriggerPx or slTriggerPx is required")
+	}
+	if oco && (o.TpTriggerPx == "" || o.SlTriggerPx == "") {
+		return errors.New("tpTriggerPx and slTriggerPx are required by oco orders")
+	}
+	if err := triggerPair("tp", o.TpTriggerPx, o.TpOrdPx, false); err != nil {
+		return err
+	}
+	return triggerPair("sl", o.SlTriggerPx, o.SlOrdPx, false)
+}
+
+func (o IcebergOrder) validate() error {
+	if (o.PxVar == "") == (o.PxSpread == "") {
+		return errors.New("one of pxVar and pxSpread is required")
+	}
+	if err := positive("pxVar", o.PxVar, false); err != nil {
+		return err
+	}
+	if err := positive("pxSpread", o.PxSpread, false); err != nil {
+		return err
+	}
+	if err := positive("szLimit", o.SzLimit, true); err != nil {
+		return err
+	}
+	return positive("pxLimit", o.PxLimit, true)
+}
+
+// validate checks a trailing stop follows the price either by a ratio lower than 1 or by a spread
+func (o MoveStopOrder) validate() error {
+	if (o.CallbackRatio == "") == (o.CallbackSpread == "") {
+		return errors.New("one of callbackRatio and callbackSpread is required")
+	}
+	if err := positive("callbackRatio", o.CallbackRatio, false); err != nil {
+		return err
+	}
+	if o.CallbackRatio != "" && !o.CallbackRatio.LessThan("1") {
+		return errors.Errorf("callbackRatio %s is not lower than 1", o.CallbackRatio)
+	}
+	if err := positive("callbackSpread", o.CallbackSpread, false); err != nil {
+		return err
+	}
+	return positive("activePx", o.ActivePx, false)
+}
+
+func (o ChaseOrder) validate() error {
+	for _, t := range []okex.ChaseType{o.ChaseType, o.MaxChaseType} {
+		switch t {
+		case "", okex.ChaseDistance, okex.ChaseRatio:
+		default:
+			return errors.Errorf("unknown chase type %q", t)
+		}
+	}
+	if err := decimal("chaseVal", o.ChaseVal); err != nil {
+		return err
+	}
+	if o.ChaseVal != "" && o.ChaseVal.Sign() < 0 {
+		return errors.Errorf("chaseVal %s is negative", o.ChaseVal)
+	}
+	if (o.MaxChaseType == "") != (o.MaxChaseVal == "") {
+		return errors.New("maxChaseType and maxChaseVal go together")
+	}
+	return positive("maxChaseVal", o.MaxChaseVal, false)
+}
+
+// Validate checks the algo order amendment before it's sent
+func (o AmendAlgoOrder) Validate() error {
+	if o.InstID == "" {
+		return errors.New("instId is required")
+	}
+	if o.AlgoID == "" && o.AlgoClOrdID == "" {
+		return errors.Errorf("%s: algoId or algoClOrdId is required", o.InstID)
+	}
+	if o.NewSz == "" && o.NewTpTriggerPx == "" && o.NewTpOrdPx == "" && o.NewSlTriggerPx == "" && o.NewSlOrdPx == "" &&
+		o.NewTriggerPx == "" && o.NewOrdPx == "" {
+		return errors.Errorf("%s: nothing to amend", o.InstID)
+	}
+	if err := positive("newSz", o.NewSz, false); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	if err := validTriggerPxType(o.NewTpTriggerPxType, o.NewSlTriggerPxType, o.NewTriggerPxType); err != nil {
+		return errors.Wrap(err, o.InstID)
+	}
+	return nil
+}
+
+// Validate checks the algo order lookup has an id
+func (o AlgoOrderDetails) Validate() error {
+	if o.AlgoID == "" && o.AlgoClOrdID == "" {
+		return errors.New("algoId or algoClOrdId is required")
+	}
+	return nil
+}
+
+// Validate checks the timeout is 0 or within the 10-120 seconds range accepted by the server
+func (r CancelAllAfter) Validate() error {
+	if r.TimeOut != 0 && (r.TimeOut < 10 || r.TimeOut > 120) {
+		return errors.Errorf("timeOut %d is neither 0 nor within 10-120", r.TimeOut)
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package trade
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	okex "github.com/pefish/go-okx"
+)
+
+// check fails when err doesn't contain want, an empty want expects no error
+func check(t *testing.T, name string, err error, want string) {
+	t.Helper()
+	switch {
+	case want == "" && err != nil:
+		t.Errorf("%s: unexpected error %v", name, err)
+	case want != "" && err == nil:
+		t.Errorf("%s: no error, want %q", name, want)
+	case want != "" && !strings.Contains(err.Error(), want):
+		t.Errorf("%s: error %q, want %q", name, err, want)
+	}
+}
+
+const option = "BTC-USD-240628-60000-C"
+
+func TestPlaceOrderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(o *PlaceOrder)
+		err  string
+	}{
+		{"valid", func(o *PlaceOrder) {}, ""},
+		{"no instId", func(o *PlaceOrder) { o.InstID = "" }, "instId is required"},
+		{"no tdMode", func(o *PlaceOrder) { o.TdMode = "" }, "tdMode, side and ordType are required"},
+		{"no side", func(o *PlaceOrder) { o.Side = "" }, "tdMode, side and ordType are required"},
+		{"no sz", func(o *PlaceOrder) { o.Sz = "" }, "sz is required"},
+		{"zero sz", func(o *PlaceOrder) { o.Sz = "0" }, "sz 0 is not positive"},
+		{"malformed sz", func(o *PlaceOrder) { o.Sz = "1.2.3" }, "sz"},
+		{"clOrdId", func(o *PlaceOrder) { o.ClOrdID = "b15" }, ""},
+		{"all digit clOrdId", func(o *PlaceOrder) { o.ClOrdID = "1697040000123" }, ""},
+		{"clOrdId with a dash", func(o *PlaceOrder) { o.ClOrdID = "b-15" }, "must be letters and digits"},
+		{"long clOrdId", func(o *PlaceOrder) { o.ClOrdID = strings.Repeat("a", 33) }, "longer than 32 characters"},
+		{"long tag", func(o *PlaceOrder) { o.Tag = strings.Repeat("a", 17) }, "tag is longer than 16 characters"},
+		{"future expTime", func(o *PlaceOrder) { o.ExpTime = time.Now().Add(time.Minute) }, ""},
+		{"passed expTime", func(o *PlaceOrder) { o.ExpTime = time.Now().Add(-time.Second) }, "has passed"},
+		{"market without price", func(o *PlaceOrder) { o.OrdType, o.Px = okex.OrderMarket, "" }, ""},
+		{"limit without price", func(o *PlaceOrder) { o.Px = "" }, "a price is required by limit orders"},
+		{"negative price", func(o *PlaceOrder) { o.Px = "-1" }, "px -1 is not positive"},
+		{"pxUsd of a spot", func(o *PlaceOrder) { o.Px, o.PxUsd = "", "100" }, "pxUsd is only applicable to options"},
+		{"pxVol of an option", func(o *PlaceOrder) { o.InstID, o.Px, o.PxVol = option, "", "0.5" }, ""},
+		{"two prices of an option", func(o *PlaceOrder) { o.InstID, o.PxUsd = option, "100" }, "only one of px, pxUsd and pxVol"},
+		{"mmp of a spot", func(o *PlaceOrder) { o.OrdType = okex.OrderMMP }, "mmp orders are only applicable to options"},
+		{"mmp of an option", func(o *PlaceOrder) { o.InstID, o.OrdType = option, okex.OrderMMP }, ""},
+		{"unknown ordType", func(o *PlaceOrder) { o.OrdType = "stop" }, `unknown ordType "stop"`},
+		{"stpMode", func(o *PlaceOrder) { o.StpMode = okex.SelfTradeCancelMaker }, ""},
+		{"unknown stpMode", func(o *PlaceOrder) { o.StpMode = "cancel_all" }, "unknown stpMode"},
+		{"quickMgnType of a margin", func(o *PlaceOrder) { o.TdMode, o.QuickMgnType = okex.TradeCrossMode, okex.QuickMarginManual }, ""},
+		{"quickMgnType of a cash", func(o *PlaceOrder) { o.QuickMgnType = okex.QuickMarginManual }, "quickMgnType is only applicable to margin"},
+		{
+			"quickMgnType of a swap",
+			func(o *PlaceOrder) {
+				o.InstID, o.TdMode, o.QuickMgnType = "BTC-USDT-SWAP", okex.TradeCrossMode, okex.QuickMarginManual
+			},
+			"quickMgnType is only applicable to margin",
+		},
+		{"unknown quickMgnType", func(o *PlaceOrder) { o.QuickMgnType = "auto" }, "unknown quickMgnType"},
+
+		// attached TP/SL
+		{"tp and sl", attach(&AttachAlgoOrder{TpTriggerPx: "110", TpOrdPx: "-1", SlTriggerPx: "90", SlOrdPx: "89"}), ""},
+		{"nil attached order", attach(nil), "nil order"},
+		{"no trigger", attach(&AttachAlgoOrder{TpOrdPx: "110"}), "tpTriggerPx or slTriggerPx is required"},
+		{"tp trigger without order price", attach(&AttachAlgoOrder{TpTriggerPx: "110"}), "tpOrdPx is required with tpTriggerPx"},
+		{"sl order price of 0", attach(&AttachAlgoOrder{SlTriggerPx: "90", SlOrdPx: "0"}), "slOrdPx 0 is neither positive nor -1"},
+		{"sl trigger of 0", attach(&AttachAlgoOrder{SlTriggerPx: "0", SlOrdPx: "-1"}), "slTriggerPx 0 is not positive"},
+		{"limit tp", attach(&AttachAlgoOrder{TpOrdKind: okex.TpOrdLimit, TpOrdPx: "110"}), ""},
+		{"limit tp without price", attach(&AttachAlgoOrder{TpOrdKind: okex.TpOrdLimit}), "tpOrdPx is required"},
+		{"limit tp at market", attach(&AttachAlgoOrder{TpOrdKind: okex.TpOrdLimit, TpOrdPx: "-1"}), "tpOrdPx -1 is not positive"},
+		{
+			"unknown trigger price type",
+			attach(&AttachAlgoOrder{TpTriggerPx: "110", TpOrdPx: "-1", TpTriggerPxType: "bid"}),
+			`unknown trigger price type "bid"`,
+		},
+		{
+			"attachAlgoClOrdId with a dash",
+			attach(&AttachAlgoOrder{AttachAlgoClOrdID: "tp-1", TpTriggerPx: "110", TpOrdPx: "-1"}),
+			"attachAlgoClOrdId",
+		},
+		{
+			"unknown amendPxOnTriggerType",
+			attach(&AttachAlgoOrder{SlTriggerPx: "90", SlOrdPx: "-1", AmendPxOnTriggerType: "2"}),
+			"amendPxOnTriggerType",
+		},
+		{
+			"split take profits without sz",
+			attach(&AttachAlgoOrder{TpTriggerPx: "110", TpOrdPx: "-1", Sz: "1"}, &AttachAlgoOrder{TpTriggerPx: "120", TpOrdPx: "-1"}),
+			"split take profits: attachAlgoOrds sz is required",
+		},
+		{
+			"split take profits",
+			attach(&AttachAlgoOrder{TpTriggerPx: "110", TpOrdPx: "-1", Sz: "1"}, &AttachAlgoOrder{TpTriggerPx: "120", TpOrdPx: "-1", Sz: "1"}),
+			"",
+		},
+	}
+	for _, tt := range tests {
+		o := PlaceOrder{
+			InstID:  "BTC-USDT",
+			TdMode:  okex.TradeCashMode,
+			Side:    okex.OrderBuy,
+			OrdType: okex.OrderLimit,
+			Sz:      "1",
+			Px:      "100",
+		}
+		tt.edit(&o)
+		check(t, tt.name, o.Validate(), tt.err)
+	}
+}
+
+func attach(a ...*AttachAlgoOrder) func(o *PlaceOrder) {
+	return func(o *PlaceOrder) { o.AttachAlgoOrds = a }
+}
+
+func TestCancelOrderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    CancelOrder
+		err  string
+	}{
+		{"ordId", CancelOrder{InstID: "BTC-USDT", OrdID: "1"}, ""},
+		{"clOrdId", CancelOrder{InstID: "BTC-USDT", ClOrdID: "b15"}, ""},
+		{"no instId", CancelOrder{OrdID: "1"}, "instId is required"},
+		{"no id", CancelOrder{InstID: "BTC-USDT"}, "ordId or clOrdId is required"},
+	}
+	for _, tt := range tests {
+		check(t, tt.name, tt.o.Validate(), tt.err)
+	}
+}
+
+func TestAmendOrderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(o *AmendOrder)
+		err  string
+	}{
+		{"valid", func(o *AmendOrder) {}, ""},
+		{"no instId", func(o *AmendOrder) { o.InstID = "" }, "instId is required"},
+		{"no id", func(o *AmendOrder) { o.OrdID = "" }, "ordId or clOrdId is required"},
+		{"clOrdId", func(o *AmendOrder) { o.OrdID, o.ClOrdID = "", "1697040000123" }, ""},
+		{"nothing to amend", func(o *AmendOrder) { o.NewSz = "" }, "nothing to amend"},
+		{"zero newSz", func(o *AmendOrder) { o.NewSz = "0" }, "newSz 0 is not positive"},
+		{"newPx", func(o *AmendOrder) { o.NewSz, o.NewPx = "", "101" }, ""},
+		{"negative newPx", func(o *AmendOrder) { o.NewPx = "-1" }, "newPx -1 is not positive"},
+		{"newPxUsd of a spot", func(o *AmendOrder) { o.NewPxUsd = "100" }, "newPxUsd is only applicable to options"},
+		{"newPxVol of an option", func(o *AmendOrder) { o.InstID, o.NewPxVol = option, "0.5" }, ""},
+		{"two prices of an option", func(o *AmendOrder) { o.InstID, o.NewPx, o.NewPxVol = option, "1", "0.5" }, "only one of newPx, newPxUsd and newPxVol"},
+		{"future expTime", func(o *AmendOrder) { o.ExpTime = time.Now().Add(time.Minute) }, ""},
+		{"passed expTime", func(o *AmendOrder) { o.ExpTime = time.Now().Add(-time.Second) }, "has passed"},
+
+		// attached TP/SL
+		{"attached only", amendAttach(&AmendAttachAlgoOrder{AttachAlgoID: "1", NewTpTriggerPx: "110"}), ""},
+		{"removed tp", amendAttach(&AmendAttachAlgoOrder{AttachAlgoClOrdID: "tp1", NewTpTriggerPx: "0"}), ""},
+		{"nil attached order", amendAttach(nil), "nil order"},
+		{"attached without id", amendAttach(&AmendAttachAlgoOrder{NewTpTriggerPx: "110"}), "attachAlgoId or attachAlgoClOrdId is required"},
+		{"negative trigger", amendAttach(&AmendAttachAlgoOrder{AttachAlgoID: "1", NewSlTriggerPx: "-1"}), "newSlTriggerPx -1 is negative"},
+		{
+			"unknown trigger price type",
+			amendAttach(&AmendAttachAlgoOrder{AttachAlgoID: "1", NewTpTriggerPx: "110", NewTpTriggerPxType: "bid"}),
+			`unknown trigger price type "bid"`,
+		},
+		{"zero sz", amendAttach(&AmendAttachAlgoOrder{AttachAlgoID: "1", Sz: "0"}), "attachAlgoOrds sz 0 is not positive"},
+		{"unknown amendPxOnTriggerType", amendAttach(&AmendAttachAlgoOrder{AttachAlgoID: "1", AmendPxOnTriggerType: "2"}), "amendPxOnTriggerType"},
+	}
+	for _, tt := range tests {
+		o := AmendOrder{InstID: "BTC-USDT", OrdID: "1", NewSz: "2"}
+		tt.edit(&o)
+		check(t, tt.name, o.Validate(), tt.err)
+	}
+}
+
+func amendAttach(a ...*AmendAttachAlgoOrder) func(o *AmendOrder) {
+	return func(o *AmendOrder) { o.NewSz, o.AttachAlgoOrds = "", a }
+}
+
+func TestCancelAllAfterValidate(t *testing.T) {
+	tests := []struct {
+		timeOut int64
+		err     string
+	}{
+		{0, ""},
+		{5, "neither 0 nor within 10-120"},
+		{10, ""},
+		{120, ""},
+		{121, "neither 0 nor within 10-120"},
+	}
+	for _, tt := range tests {
+		check(t, "timeOut", CancelAllAfter{TimeOut: tt.timeOut}.Validate(), tt.err)
+	}
+}
+
+func TestPlaceAlgoOrderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    PlaceAlgoOrder
+		err  string
+	}{
+		{"no instId", PlaceAlgoOrder{}, "instId is required"},
+		{"no ordType", PlaceAlgoOrder{InstID: "BTC-USDT", TdMode: okex.TradeCashMode, Side: okex.OrderSell}, "tdMode, side and ordType are required"},
+		{"unknown ordType", algo("stop", "1"), `unknown ordType "stop"`},
+		{"algoClOrdId with a dash", func() PlaceAlgoOrder { o := moveStop("0.01", "", ""); o.AlgoClOrdID = "a-1"; return o }(), "algoClOrdId"},
+		{"no sz", moveStopSz("", "0.01"), "sz is required"},
+		{"closeFraction instead of sz", func() PlaceAlgoOrder { o := moveStopSz("", "0.01"); o.CloseFraction = "1"; return o }(), ""},
+		{"closeFraction lower than 1", func() PlaceAlgoOrder { o := moveStopSz("", "0.01"); o.CloseFraction = "0.5"; return o }(), "closeFraction can only be 1"},
+		{"closeFraction of a chase", func() PlaceAlgoOrder { o := chase("", "", "", ""); o.Sz, o.CloseFraction = "", "1"; return o }(), "closeFraction is not applicable to chase orders"},
+
+		// trailing stops
+		{"callbackRatio", moveStop("0.01", "", ""), ""},
+		{"callbackSpread", moveStop("", "50", ""), ""},
+		{"activePx", moveStop("0.01", "", "30000"), ""},
+		{"no callback", moveStop("", "", ""), "one of callbackRatio and callbackSpread is required"},
+		{"both callbacks", moveStop("0.01", "50", ""), "one of callbackRatio and callbackSpread is required"},
+		{"callbackRatio of 1", moveStop("1", "", ""), "callbackRatio 1 is not lower than 1"},
+		{"zero callbackRatio", moveStop("0", "", ""), "callbackRatio 0 is not positive"},
+		{"negative callbackSpread", moveStop("", "-5", ""), "callbackSpread -5 is not positive"},
+		{"zero activePx", moveStop("", "50", "0"), "activePx 0 is not positive"},
+
+		// chase orders
+		{"chase at the best price", chase("", "", "", ""), ""},
+		{"chase distance", chase(okex.ChaseDistance, "0.5", "", ""), ""},
+		{"chase ratio with a limit", chase(okex.ChaseRatio, "0.001", okex.ChaseRatio, "0.01"), ""},
+		{"unknown chaseType", chase("best", "0.5", "", ""), `unknown chase type "best"`},
+		{"unknown maxChaseType", chase("", "", "far", "1"), `unknown chase type "far"`},
+		{"negative chaseVal", chase(okex.ChaseDistance, "-0.5", "", ""), "chaseVal -0.5 is negative"},
+		{"maxChaseType alone", chase("", "", okex.ChaseDistance, ""), "maxChaseType and maxChaseVal go together"},
+		{"maxChaseVal alone", chase("", "", "", "10"), "maxChaseType and maxChaseVal go together"},
+		{"zero maxChaseVal", chase("", "", okex.ChaseDistance, "0"), "maxChaseVal 0 is not positive"},
+
+		// the other types
+		{"conditional", stop(okex.AlgoOrderConditional, "", "", "90", "-1"), ""},
+		{"conditional without trigger", stop(okex.AlgoOrderConditional, "", "", "", ""), "tpTriggerPx or slTriggerPx is required"},
+		{"oco", stop(okex.AlgoOrderOCO, "110", "-1", "90", "-1"), ""},
+		{"oco without tp", stop(okex.AlgoOrderOCO, "", "", "90", "-1"), "tpTriggerPx and slTriggerPx are required by oco orders"},
+		{"trigger", func() PlaceAlgoOrder {
+			o := algo(okex.AlgoOrderTrigger, "1")
+			o.TriggerPx, o.OrdPx = "100", "-1"
+			return o
+		}(), ""},
+		{"trigger without ordPx", func() PlaceAlgoOrder { o := algo(okex.AlgoOrderTrigger, "1"); o.TriggerPx = "100"; return o }(), "ordPx is required"},
+		{"iceberg", iceberg(okex.AlgoOrderIceberg, "0.01", ""), ""},
+		{"iceberg with both variances", iceberg(okex.AlgoOrderIceberg, "0.01", "1"), "one of pxVar and pxSpread is required"},
+		{"twap without interval", iceberg(okex.AlgoOrderTwap, "", "1"), "timeInterval is required"},
+	}
+	for _, tt := range tests {
+		check(t, tt.name, tt.o.Validate(), tt.err)
+	}
+}
+
+func algo(ordType okex.AlgoOrderType, sz okex.Decimal) PlaceAlgoOrder {
+	return PlaceAlgoOrder{InstID: "BTC-USDT-SWAP", TdMode: okex.TradeCrossMode, Side: okex.OrderSell, OrdType: ordType, Sz: sz}
+}
+
+func moveStop(ratio, spread, activePx okex.Decimal) PlaceAlgoOrder {
+	o := algo(okex.AlgoOrderMoveStop, "1")
+	o.MoveStopOrder = MoveStopOrder{CallbackRatio: ratio, CallbackSpread: spread, ActivePx: activePx}
+	return o
+}
+
+func moveStopSz(sz, ratio okex.Decimal) PlaceAlgoOrder {
+	o := moveStop(ratio, "", "")
+	o.Sz = sz
+	return o
+}
+
+func chase(chaseType okex.ChaseType, val okex.Decimal, maxType okex.ChaseType, maxVal okex.Decimal) PlaceAlgoOrder {
+	o := algo(okex.AlgoOrderChase, "1")
+	o.ChaseOrder = ChaseOrder{ChaseType: chaseType, ChaseVal: val, MaxChaseType: maxType, MaxChaseVal: maxVal}
+	return o
+}
+
+func stop(ordType okex.AlgoOrderType, tpTrigger, tpOrd, slTrigger, slOrd okex.Decimal) PlaceAlgoOrder {
+	o := algo(ordType, "1")
+	o.StopOrder = StopOrder{TpTriggerPx: tpTrigger, TpOrdPx: tpOrd, SlTriggerPx: slTrigger, SlOrdPx: slOrd}
+	return o
+}
+
+func iceberg(ordType okex.AlgoOrderType, pxVar, pxSpread okex.Decimal) PlaceAlgoOrder {
+	o := algo(ordType, "10")
+	o.IcebergOrder = IcebergOrder{PxVar: pxVar, PxSpread: pxSpread, SzLimit: "1", PxLimit: "100"}
+	return o
+}
+
+func TestAmendAlgoOrderValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		o    AmendAlgoOrder
+		err  string
+	}{
+		{"newSz", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoID: "1", NewSz: "2"}, ""},
+		{"algoClOrdId", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoClOrdID: "a1", NewTpTriggerPx: "110"}, ""},
+		{"new trigger", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoID: "1", NewTriggerPx: "100", NewOrdPx: "-1"}, ""},
+		{"no instId", AmendAlgoOrder{AlgoID: "1", NewSz: "2"}, "instId is required"},
+		{"no id", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", NewSz: "2"}, "algoId or algoClOrdId is required"},
+		{"nothing to amend", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoID: "1"}, "nothing to amend"},
+		{"zero newSz", AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoID: "1", NewSz: "0"}, "newSz 0 is not positive"},
+		{
+			"unknown trigger price type",
+			AmendAlgoOrder{InstID: "BTC-USDT-SWAP", AlgoID: "1", NewSlTriggerPx: "90", NewSlTriggerPxType: "bid"},
+			`unknown trigger price type "bid"`,
+		},
+	}
+	for _, tt := range tests {
+		check(t, tt.name, tt.o.Validate(), tt.err)
+	}
+	check(t, "details", AlgoOrderDetails{AlgoClOrdID: "a1"}.Validate(), "")
+	check(t, "details without id", AlgoOrderDetails{}.Validate(), "algoId or algoClOrdId is required")
+}
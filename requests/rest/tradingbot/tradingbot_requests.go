@@ -0,0 +1,107 @@
+package tradingbot
+
+import "github.com/pefish/go-okx"
+
+type (
+	// PlaceGridOrder creates a grid, QuoteSz or BaseSz invest in a spot grid, Sz (margin in USDT or coin) in a contract grid
+	PlaceGridOrder struct {
+		InstID             string                 `json:"instId"`
+		AlgoOrdType        okex.GridAlgoOrderType `json:"algoOrdType"`
+		MaxPx              okex.Decimal           `json:"maxPx"`
+		MinPx              okex.Decimal           `json:"minPx"`
+		GridNum            int64                  `json:"gridNum,string"`
+		RunType            okex.GridRunType       `json:"runType,omitempty"`
+		TpTriggerPx        okex.Decimal           `json:"tpTriggerPx,omitempty"`
+		SlTriggerPx        okex.Decimal           `json:"slTriggerPx,omitempty"`
+		TpRatio            okex.Decimal           `json:"tpRatio,omitempty"` // contract grids only
+		SlRatio            okex.Decimal           `json:"slRatio,omitempty"` // contract grids only
+		AlgoClOrdID        string                 `json:"algoClOrdId,omitempty"`
+		Tag                string                 `json:"tag,omitempty"`
+		ProfitSharingRatio okex.Decimal           `json:"profitSharingRatio,omitempty"`
+		TriggerParams      []*TriggerParam        `json:"triggerParams,omitempty"`
+		QuoteSz            okex.Decimal           `json:"quoteSz,omitempty"`
+		BaseSz             okex.Decimal           `json:"baseSz,omitempty"`
+		Sz                 okex.Decimal           `json:"sz,omitempty"`
+		Direction          okex.GridDirection     `json:"direction,omitempty"`
+		Lever              okex.Decimal           `json:"lever,omitempty"`
+		BasePos            bool                   `json:"basePos,omitempty"` // open a position when a long or short contract grid starts
+	}
+	// TriggerParam starts or stops a grid on a signal instead of right away
+	TriggerParam struct {
+		TriggerAction   okex.GridTriggerAction   `json:"triggerAction"`
+		TriggerStrategy okex.GridTriggerStrategy `json:"triggerStrategy"`
+		DelaySeconds    int64                    `json:"delaySeconds,omitempty,string"`
+		Timeframe       okex.BarSize             `json:"timeframe,omitempty"`
+		Thold           okex.Decimal             `json:"thold,omitempty"`
+		TriggerCond     string                   `json:"triggerCond,omitempty"` // cross_up, cross_down, above, below or cross
+		TimePeriod      okex.Decimal             `json:"timePeriod,omitempty"`
+		TriggerPx       okex.Decimal             `json:"triggerPx,omitempty"`
+		StopType        okex.GridStopType        `json:"stopType,omitempty"`
+	}
+	AmendGridOrder struct {
+		AlgoID        string          `json:"algoId"`
+		InstID        string          `json:"instId"`
+		TpTriggerPx   okex.Decimal    `json:"tpTriggerPx,omitempty"` // -1 cancels the take profit
+		SlTriggerPx   okex.Decimal    `json:"slTriggerPx,omitempty"` // -1 cancels the stop loss
+		TpRatio       okex.Decimal    `json:"tpRatio,omitempty"`
+		SlRatio       okex.Decimal    `json:"slRatio,omitempty"`
+		TriggerParams []*TriggerParam `json:"triggerParams,omitempty"`
+	}
+	StopGridOrder struct {
+		AlgoID      string                 `json:"algoId"`
+		InstID      string                 `json:"instId"`
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		StopType    okex.GridStopType      `json:"stopType"`
+	}
+	GridOrderList struct {
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		AlgoID      string                 `json:"algoId,omitempty"`
+		InstID      string                 `json:"instId,omitempty"`
+		InstType    okex.InstrumentType    `json:"instType,omitempty"`
+		After       string                 `json:"after,omitempty"` // algoId
+		Before      string                 `json:"before,omitempty"`
+		Limit       int64                  `json:"limit,omitempty,string"`
+	}
+	GridOrderDetails struct {
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		AlgoID      string                 `json:"algoId"`
+	}
+	GridSubOrders struct {
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		AlgoID      string                 `json:"algoId"`
+		Type        okex.GridSubOrderType  `json:"type"`
+		GroupID     string                 `json:"groupId,omitempty"`
+		After       string                 `json:"after,omitempty"` // ordId
+		Before      string                 `json:"before,omitempty"`
+		Limit       int64                  `json:"limit,omitempty,string"`
+	}
+	GridPositions struct {
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		AlgoID      string                 `json:"algoId"`
+	}
+	// GridAIParam asks the parameters OKX suggests for a grid, Direction is needed by contract grids
+	GridAIParam struct {
+		AlgoOrdType okex.GridAlgoOrderType `json:"algoOrdType"`
+		InstID      string                 `json:"instId"`
+		Direction   okex.GridDirection     `json:"direction,omitempty"`
+		Duration    string                 `json:"duration,omitempty"` // back testing duration, 7D, 30D or 180D
+	}
+	// GridMinInvestment computes the minimum investment of a grid, InvestmentData lists the currencies invested
+	GridMinInvestment struct {
+		InstID         string                 `json:"instId"`
+		AlgoOrdType    okex.GridAlgoOrderType `json:"algoOrdType"`
+		MaxPx          okex.Decimal           `json:"maxPx"`
+		MinPx          okex.Decimal           `json:"minPx"`
+		GridNum        int64                  `json:"gridNum,string"`
+		RunType        okex.GridRunType       `json:"runType"`
+		Direction      okex.GridDirection     `json:"direction,omitempty"`
+		Lever          okex.Decimal           `json:"lever,omitempty"`
+		BasePos        bool                   `json:"basePos,omitempty"`
+		InvestmentData []*GridInvestment      `json:"investmentData,omitempty"`
+		TriggerParams  []*TriggerParam        `json:"triggerParams,omitempty"`
+	}
+	GridInvestment struct {
+		Amt okex.Decimal `json:"amt"`
+		Ccy string       `json:"ccy"`
+	}
+)
@@ -21,4 +21,29 @@ type (
 		InstID   string              `json:"instId,omitempty"`
 		InstType okex.InstrumentType `json:"instType"`
 	}
+	AdvanceAlgoOrder struct {
+		InstID   string              `json:"instId,omitempty"`
+		AlgoID   string              `json:"algoId,omitempty"`
+		InstType okex.InstrumentType `json:"instType"`
+	}
+	Fill struct {
+		InstID string `json:"instId,omitempty"`
+	}
+	// GridOrder subscribes to grid-orders-spot with SpotInstrument or grid-orders-contract with SwapInstrument, FuturesInstrument or AnyInstrument
+	GridOrder struct {
+		InstType okex.InstrumentType `json:"instType"`
+		InstID   string              `json:"instId,omitempty"`
+		AlgoID   string              `json:"algoId,omitempty"`
+	}
+	GridPosition struct {
+		AlgoID string `json:"algoId"`
+	}
+	LeadNotification struct {
+		InstType okex.InstrumentType `json:"instType,omitempty"`
+		InstID   string              `json:"instId,omitempty"`
+	}
+	// Rfq, Quote and StrucBlockTrade have no parameters, a single value subscribes to the channel
+	Rfq             struct{}
+	Quote           struct{}
+	StrucBlockTrade struct{}
 )
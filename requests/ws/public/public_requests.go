@@ -52,4 +52,7 @@ type (
 	IndexTickers struct {
 		InstID string `json:"instId"`
 	}
+	PublicBlockTrades struct {
+		InstID string `json:"instId"`
+	}
 )
@@ -1,22 +1,35 @@
 package trade
 
-import "github.com/pefish/go-okx"
+import (
+	"time"
+
+	"github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/requests/rest/trade"
+)
 
 type (
+	// PlaceOrder has the fields of the rest one, ID is the id of the message
 	PlaceOrder struct {
-		ID         string            `json:"-"`
-		InstID     string            `json:"instId"`
-		Ccy        string            `json:"ccy,omitempty"`
-		ClOrdID    string            `json:"clOrdId,omitempty"`
-		Tag        string            `json:"tag,omitempty"`
-		ReduceOnly bool              `json:"reduceOnly,omitempty"`
-		Sz         float64           `json:"sz,string"`
-		Px         float64           `json:"px,omitempty,string"`
-		TdMode     okex.TradeMode    `json:"tdMode"`
-		Side       okex.OrderSide    `json:"side"`
-		PosSide    okex.PositionSide `json:"posSide,omitempty"`
-		OrdType    okex.OrderType    `json:"ordType"`
-		TgtCcy     okex.QuantityType `json:"tgtCcy,omitempty"`
+		ID             string                   `json:"-"`
+		InstID         string                   `json:"instId"`
+		Ccy            string                   `json:"ccy,omitempty"`
+		ClOrdID        string                   `json:"clOrdId,omitempty"`
+		Tag            string                   `json:"tag,omitempty"`
+		ReduceOnly     bool                     `json:"reduceOnly,omitempty"`
+		Sz             okex.Decimal             `json:"sz"`
+		Px             okex.Decimal             `json:"px,omitempty"`
+		PxUsd          okex.Decimal             `json:"pxUsd,omitempty"`
+		PxVol          okex.Decimal             `json:"pxVol,omitempty"`
+		TdMode         okex.TradeMode           `json:"tdMode"`
+		Side           okex.OrderSide           `json:"side"`
+		PosSide        okex.PositionSide        `json:"posSide,omitempty"`
+		OrdType        okex.OrderType           `json:"ordType"`
+		TgtCcy         okex.QuantityType        `json:"tgtCcy,omitempty"`
+		StpMode        okex.SelfTradeMode       `json:"stpMode,omitempty"`
+		BanAmend       bool                     `json:"banAmend,omitempty"`
+		QuickMgnType   okex.QuickMarginType     `json:"quickMgnType,omitempty"`
+		AttachAlgoOrds []*trade.AttachAlgoOrder `json:"attachAlgoOrds,omitempty"`
+		ExpTime        time.Time                `json:"-"` // sent as the expTime of the message
 	}
 	CancelOrder struct {
 		ID      string `json:"-"`
@@ -24,14 +37,34 @@ type (
 		OrdID   string `json:"ordId,omitempty"`
 		ClOrdID string `json:"clOrdId,omitempty"`
 	}
+	// AmendOrder has the fields of the rest one, ID is the id of the message
 	AmendOrder struct {
-		ID        string  `json:"-"`
-		InstID    string  `json:"instId"`
-		OrdID     string  `json:"ordId,omitempty"`
-		ClOrdID   string  `json:"clOrdId,omitempty"`
-		ReqID     string  `json:"reqId,omitempty"`
-		NewSz     int64   `json:"newSz,omitempty,string"`
-		NewPx     float64 `json:"newPx,omitempty,string"`
-		CxlOnFail bool    `json:"cxlOnFail,omitempty"`
+		ID             string                        `json:"-"`
+		InstID         string                        `json:"instId"`
+		OrdID          string                        `json:"ordId,omitempty"`
+		ClOrdID        string                        `json:"clOrdId,omitempty"`
+		ReqID          string                        `json:"reqId,omitempty"`
+		NewSz          okex.Decimal                  `json:"newSz,omitempty"`
+		NewPx          okex.Decimal                  `json:"newPx,omitempty"`
+		NewPxUsd       okex.Decimal                  `json:"newPxUsd,omitempty"`
+		NewPxVol       okex.Decimal                  `json:"newPxVol,omitempty"`
+		CxlOnFail      bool                          `json:"cxlOnFail,omitempty"`
+		AttachAlgoOrds []*trade.AmendAttachAlgoOrder `json:"attachAlgoOrds,omitempty"`
+		ExpTime        time.Time                     `json:"-"` // sent as the expTime of the message
 	}
 )
+
+// Validate checks the order like the rest one
+func (o PlaceOrder) Validate() error {
+	return trade.PlaceOrder(o).Validate()
+}
+
+// Validate checks the cancellation like the rest one
+func (o CancelOrder) Validate() error {
+	return trade.CancelOrder(o).Validate()
+}
+
+// Validate checks the amendment like the rest one
+func (o AmendOrder) Validate() error {
+	return trade.AmendOrder(o).Validate()
+}
@@ -70,8 +70,68 @@ type (
 		responses.Basic
 		Greeks []*models.Greek `json:"data"`
 	}
+	SetAccountLevel struct {
+		responses.Basic
+		AccountLevels []*models.AccountLevel `json:"data"`
+	}
+	SetIsolatedMode struct {
+		responses.Basic
+		IsolatedModes []*models.IsolatedMode `json:"data"`
+	}
+	SetAutoLoan struct {
+		responses.Basic
+		AutoLoans []*models.AutoLoan `json:"data"`
+	}
+	SetRiskOffsetType struct {
+		responses.Basic
+		RiskOffsetTypes []*models.RiskOffsetType `json:"data"`
+	}
+	SetRiskOffsetAmount struct {
+		responses.Basic
+		RiskOffsetAmounts []*models.RiskOffsetAmount `json:"data"`
+	}
 	GetMaxWithdrawals struct {
 		responses.Basic
 		MaxWithdrawals []*models.MaxWithdrawal `json:"data"`
 	}
+	SpotBorrowRepay struct {
+		responses.Basic
+		SpotBorrowRepays []*models.SpotBorrowRepay `json:"data"`
+	}
+	SetAutoRepay struct {
+		responses.Basic
+		AutoRepays []*models.AutoRepay `json:"data"`
+	}
+	GetSpotBorrowRepayHistory struct {
+		responses.Basic
+		SpotBorrowRepayHistories []*models.SpotBorrowRepayHistory `json:"data"`
+	}
+	VIPBorrowRepay struct {
+		responses.Basic
+		VIPBorrowRepays []*models.VIPBorrowRepay `json:"data"`
+	}
+	GetVIPBorrowRepayHistory struct {
+		responses.Basic
+		VIPBorrowRepayHistories []*models.VIPBorrowRepayHistory `json:"data"`
+	}
+	GetInterestLimits struct {
+		responses.Basic
+		InterestLimits []*models.InterestLimits `json:"data"`
+	}
+	GetVIPInterest struct {
+		responses.Basic
+		VIPInterests []*models.VIPInterest `json:"data"`
+	}
+	GetVIPLoanOrders struct {
+		responses.Basic
+		VIPLoanOrders []*models.VIPLoanOrder `json:"data"`
+	}
+	GetVIPLoanOrderDetail struct {
+		responses.Basic
+		VIPLoanOrderDetails []*models.VIPLoanOrderDetail `json:"data"`
+	}
+	PositionBuilder struct {
+		responses.Basic
+		PositionBuilders []*models.PositionBuilder `json:"data"`
+	}
 )
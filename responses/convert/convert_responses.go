@@ -0,0 +1,25 @@
+package convert
+
+import (
+	models "github.com/pefish/go-okx/models/convert"
+	"github.com/pefish/go-okx/responses"
+)
+
+type (
+	GetCurrencies struct {
+		responses.Basic
+		Currencies []*models.Currency `json:"data"`
+	}
+	GetCurrencyPair struct {
+		responses.Basic
+		CurrencyPairs []*models.CurrencyPair `json:"data"`
+	}
+	EstimateQuote struct {
+		responses.Basic
+		Quotes []*models.Quote `json:"data"`
+	}
+	Trade struct {
+		responses.Basic
+		Trades []*models.Trade `json:"data"`
+	}
+)
@@ -0,0 +1,33 @@
+package copytrading
+
+import (
+	models "github.com/pefish/go-okx/models/copytrading"
+	"github.com/pefish/go-okx/responses"
+)
+
+type (
+	GetSubPositions struct {
+		responses.Basic
+		SubPositions []*models.SubPosition `json:"data"`
+	}
+	SubPositionAction struct {
+		responses.Basic
+		SubPositionActions []*models.SubPositionAction `json:"data"`
+	}
+	GetInstruments struct {
+		responses.Basic
+		Instruments []*models.Instrument `json:"data"`
+	}
+	GetProfitSharingDetails struct {
+		responses.Basic
+		ProfitSharingDetails []*models.ProfitSharingDetail `json:"data"`
+	}
+	GetTotalProfitSharing struct {
+		responses.Basic
+		TotalProfitSharings []*models.TotalProfitSharing `json:"data"`
+	}
+	GetUnrealizedProfitSharing struct {
+		responses.Basic
+		UnrealizedProfitSharings []*models.UnrealizedProfitSharing `json:"data"`
+	}
+)
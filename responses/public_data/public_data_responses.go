@@ -62,6 +62,10 @@ type (
 		responses.Basic
 		Underlings [][]string `json:"data,omitempty"`
 	}
+	ConvertContractCoin struct {
+		responses.Basic
+		Conversions []*publicdata.ContractCoinConversion `json:"data,omitempty"`
+	}
 	Status struct {
 		responses.Basic
 		States []publicdata.State `json:"data,omitempty"`
@@ -0,0 +1,41 @@
+package rfq
+
+import (
+	models "github.com/pefish/go-okx/models/rfq"
+	"github.com/pefish/go-okx/responses"
+)
+
+type (
+	GetCounterparties struct {
+		responses.Basic
+		Counterparties []*models.Counterparty `json:"data"`
+	}
+	Rfq struct {
+		responses.Basic
+		Rfqs []*models.Rfq `json:"data"`
+	}
+	CancelRfq struct {
+		responses.Basic
+		CancelRfqs []*models.CancelRfq `json:"data"`
+	}
+	Quote struct {
+		responses.Basic
+		Quotes []*models.Quote `json:"data"`
+	}
+	CancelQuote struct {
+		responses.Basic
+		CancelQuotes []*models.CancelQuote `json:"data"`
+	}
+	BlockTrade struct {
+		responses.Basic
+		BlockTrades []*models.BlockTrade `json:"data"`
+	}
+	GetPublicTrades struct {
+		responses.Basic
+		PublicBlockTrades []*models.PublicBlockTrade `json:"data"`
+	}
+	MMPReset struct {
+		responses.Basic
+		MMPResets []*models.MMPReset `json:"data"`
+	}
+)
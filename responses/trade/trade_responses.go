@@ -30,6 +30,10 @@ type (
 		responses.Basic
 		TransactionDetails []*trade.TransactionDetail `json:"data"`
 	}
+	GetFillsHistory struct {
+		responses.Basic
+		Fills []*trade.Fill `json:"data"`
+	}
 	PlaceAlgoOrder struct {
 		responses.Basic
 		PlaceAlgoOrders []*trade.PlaceAlgoOrder `json:"data"`
@@ -38,8 +42,36 @@ type (
 		responses.Basic
 		CancelAlgoOrders []*trade.CancelAlgoOrder `json:"data"`
 	}
+	AmendAlgoOrder struct {
+		responses.Basic
+		AmendAlgoOrders []*trade.AmendAlgoOrder `json:"data"`
+	}
+	MassCancel struct {
+		responses.Basic
+		MassCancels []*trade.MassCancel `json:"data"`
+	}
+	CancelAllAfter struct {
+		responses.Basic
+		CancelAllAfters []*trade.CancelAllAfter `json:"data"`
+	}
 	AlgoOrderList struct {
 		responses.Basic
 		AlgoOrders []*trade.AlgoOrder `json:"data"`
 	}
+	GetOneClickRepayCurrencies struct {
+		responses.Basic
+		OneClickRepayCurrencies []*trade.OneClickRepayCurrency `json:"data"`
+	}
+	OneClickRepay struct {
+		responses.Basic
+		OneClickRepays []*trade.OneClickRepay `json:"data"`
+	}
+	GetEasyConvertCurrencies struct {
+		responses.Basic
+		EasyConvertCurrencies []*trade.EasyConvertCurrency `json:"data"`
+	}
+	EasyConvert struct {
+		responses.Basic
+		EasyConverts []*trade.EasyConvert `json:"data"`
+	}
 )
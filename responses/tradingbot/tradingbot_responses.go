@@ -0,0 +1,33 @@
+package tradingbot
+
+import (
+	models "github.com/pefish/go-okx/models/tradingbot"
+	"github.com/pefish/go-okx/responses"
+)
+
+type (
+	PlaceGridOrder struct {
+		responses.Basic
+		PlaceGridOrders []*models.PlaceGridOrder `json:"data"`
+	}
+	GridOrderList struct {
+		responses.Basic
+		GridAlgoOrders []*models.GridAlgoOrder `json:"data"`
+	}
+	GridSubOrders struct {
+		responses.Basic
+		GridSubOrders []*models.GridSubOrder `json:"data"`
+	}
+	GridPositions struct {
+		responses.Basic
+		GridPositions []*models.GridPosition `json:"data"`
+	}
+	GridAIParam struct {
+		responses.Basic
+		GridAIParams []*models.GridAIParam `json:"data"`
+	}
+	GridMinInvestment struct {
+		responses.Basic
+		GridMinInvestments []*models.GridMinInvestment `json:"data"`
+	}
+)
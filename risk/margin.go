@@ -0,0 +1,246 @@
+package risk
+
+import (
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/models/account"
+	"github.com/pefish/go-okx/models/publicdata"
+	"github.com/pkg/errors"
+)
+
+// Position is an existing or hypothetical SWAP or FUTURES position of the margin calculator
+type Position struct {
+	Instrument *publicdata.Instrument
+	Pos        okex.Decimal      // contracts, negative for a short in net mode
+	PosSide    okex.PositionSide // long or short, the sign of Pos tells the side of net positions
+	AvgPx      okex.Decimal
+	MarkPx     okex.Decimal
+	Lever      okex.Decimal
+	MgnMode    okex.MarginMode
+	Margin     okex.Decimal // isolated margin, the initial margin when empty like a position being opened
+}
+
+// FromPosition returns the calculator position of a position of Account.GetPositions, inst is its instrument
+func FromPosition(p *account.Position, inst *publicdata.Instrument) *Position {
+	pos := &Position{
+		Instrument: inst,
+		Pos:        okex.DecimalFromFloat(float64(p.Pos)),
+		PosSide:    p.PosSide,
+		AvgPx:      okex.DecimalFromFloat(float64(p.AvgPx)),
+		MarkPx:     okex.DecimalFromFloat(float64(p.MarkPx)),
+		Lever:      okex.DecimalFromFloat(float64(p.Lever)),
+		MgnMode:    p.MgnMode,
+	}
+	if p.MgnMode == okex.MarginIsolatedMode {
+		pos.Margin = okex.DecimalFromFloat(float64(p.Margin))
+	}
+	return pos
+}
+
+// Margin is the margin of a position, amounts are in the margin currency: the settlement currency of linear contracts,
+// the coin of inverse ones
+type Margin struct {
+	Notional okex.Decimal // value at the mark price
+	Upl      okex.Decimal
+	Imr      okex.Decimal
+	Mmr      okex.Decimal
+	Fee      okex.Decimal // taker fee of closing at the mark price, the liquidation fee
+	Tier     *publicdata.PositionTier
+	MgnRatio okex.Decimal // (margin + upl) / (mmr + fee) of isolated positions, liquidated at 1. Empty for cross positions
+	LiqPx    okex.Decimal // estimated liquidation price, empty when the position can't be liquidated
+}
+
+// Calculator estimates the margin and the liquidation price of positions offline from the position tiers and the fee rates.
+// It follows the formulas of OKX single-currency margin, the results agree with the liqPx and mgnRatio of GetPositions
+// up to the rounding and the mark price moves between the calls.
+type Calculator struct {
+	tiers map[string][]*publicdata.PositionTier // by instId, instFamily or uly
+	taker map[okex.InstrumentType]okex.Decimal
+}
+
+// NewCalculator returns a pointer to a fresh Calculator
+func NewCalculator() *Calculator {
+	return &Calculator{
+		tiers: make(map[string][]*publicdata.PositionTier),
+		taker: make(map[okex.InstrumentType]okex.Decimal),
+	}
+}
+
+// AddTiers adds the tiers of PublicData.GetPositionTiers, the tiers of an instrument family are shared by its instruments
+func (c *Calculator) AddTiers(tiers []*publicdata.PositionTier) {
+	for _, t := range tiers {
+		key := t.InstID
+		if key == "" {
+			key = t.InstFamily
+		}
+		if key == "" {
+			key = t.Uly
+		}
+		list := c.tiers[key]
+		i := len(list)
+		for i > 0 && list[i-1].Tier > t.Tier {
+			i--
+		}
+		list = append(list, nil)
+		copy(list[i+1:], list[i:])
+		list[i] = t
+		c.tiers[key] = list
+	}
+}
+
+// SetFees sets the taker fee rates of Account.GetFeeRates, a fee rate applies to every instrument of its type
+func (c *Calculator) SetFees(fees []*account.Fee) {
+	for _, f := range fees {
+		c.taker[f.InstType] = okex.DecimalFromFloat(float64(f.Taker)).Abs()
+	}
+}
+
+// SetTakerRate sets the taker fee rate of an instrument type, like 0.0005
+func (c *Calculator) SetTakerRate(t okex.InstrumentType, rate okex.Decimal) {
+	c.taker[t] = rate.Abs()
+}
+
+// Tier returns the tier of sz contracts of inst
+func (c *Calculator) Tier(inst *publicdata.Instrument, sz okex.Decimal) (*publicdata.PositionTier, error) {
+	list := c.tiers[inst.InstID]
+	if len(list) == 0 {
+		list = c.tiers[inst.InstFamily]
+	}
+	if len(list) == 0 {
+		list = c.tiers[inst.Uly]
+	}
+	if len(list) == 0 {
+		return nil, errors.Errorf("%s: no position tiers", inst.InstID)
+	}
+	f := sz.Abs().Float64()
+	for _, t := range list {
+		if f <= float64(t.MaxSz) {
+			return t, nil
+		}
+	}
+	return nil, errors.Errorf("%s: %s contracts exceed the last tier", inst.InstID, sz.Abs())
+}
+
+// Isolated returns the margin of an isolated position
+func (c *Calculator) Isolated(p *Position) (*Margin, error) {
+	m, err := c.margin(p)
+	if err != nil {
+		return nil, err
+	}
+	margin := p.Margin
+	if margin.IsZero() {
+		margin = m.Imr
+	}
+	if req := m.Mmr.Add(m.Fee); req.Sign() > 0 {
+		m.MgnRatio = margin.Add(m.Upl).Div(req, pxPlaces, okex.RoundHalfEven).Trim()
+	}
+	m.LiqPx, err = c.liqPx(p, m, margin)
+	return m, err
+}
+
+// Cross returns the margins of the cross positions of a margin currency and the margin ratio of the currency,
+// eq is the equity of the currency with the upl of the positions like the eq of Account.GetBalance.
+// The liquidation price of a position is the mark price it's liquidated at while the others don't move.
+func (c *Calculator) Cross(eq okex.Decimal, positions []*Position) ([]*Margin, okex.Decimal, error) {
+	margins := make([]*Margin, len(positions))
+	req := okex.Decimal("0")
+	for i, p := range positions {
+		if i > 0 && p.Instrument.SettleCcy != positions[0].Instrument.SettleCcy {
+			return nil, "", errors.Errorf("%s is not margined in %s", p.Instrument.InstID, positions[0].Instrument.SettleCcy)
+		}
+		m, err := c.margin(p)
+		if err != nil {
+			return nil, "", err
+		}
+		margins[i] = m
+		req = req.Add(m.Mmr).Add(m.Fee)
+	}
+	ratio := okex.Decimal("")
+	if req.Sign() > 0 {
+		ratio = eq.Div(req, pxPlaces, okex.RoundHalfEven).Trim()
+	}
+	for i, p := range positions {
+		m := margins[i]
+		// the collateral of the position is the equity without its own upl and the requirements of the others
+		collateral := eq.Sub(m.Upl).Sub(req.Sub(m.Mmr).Sub(m.Fee))
+		px, err := c.liqPx(p, m, collateral)
+		if err != nil {
+			return nil, "", err
+		}
+		m.LiqPx = px
+	}
+	return margins, ratio, nil
+}
+
+// margin computes everything but the margin ratio and the liquidation price
+func (c *Calculator) margin(p *Position) (*Margin, error) {
+	inst := p.Instrument
+	if inst == nil {
+		return nil, errors.New("position without instrument")
+	}
+	if inst.InstType != okex.SwapInstrument && inst.InstType != okex.FuturesInstrument {
+		return nil, errors.Errorf("%s: only SWAP and FUTURES positions are supported", inst.InstID)
+	}
+	if p.MarkPx.Sign() <= 0 || p.AvgPx.Sign() <= 0 {
+		return nil, errors.Errorf("%s: invalid mark price %s or average price %s", inst.InstID, p.MarkPx, p.AvgPx)
+	}
+	if p.Lever.Sign() <= 0 {
+		return nil, errors.Errorf("%s: invalid leverage %s", inst.InstID, p.Lever)
+	}
+	cv := inst.ContractValue()
+	if cv.Sign() <= 0 {
+		return nil, errors.Errorf("%s has no contract value", inst.InstID)
+	}
+	tier, err := c.Tier(inst, p.Pos)
+	if err != nil {
+		return nil, err
+	}
+	q := p.Pos.Abs().Mul(cv)
+	m := &Margin{Tier: tier}
+	if inst.IsInverse() {
+		m.Notional = q.Div(p.MarkPx, pxPlaces, okex.RoundHalfEven)
+		m.Upl = q.Div(p.AvgPx, pxPlaces, okex.RoundHalfEven).Sub(m.Notional)
+	} else {
+		m.Notional = q.Mul(p.MarkPx)
+		m.Upl = q.Mul(p.MarkPx.Sub(p.AvgPx))
+	}
+	if p.short() {
+		m.Upl = m.Upl.Neg()
+	}
+	m.Notional, m.Upl = m.Notional.Trim(), m.Upl.Trim()
+	m.Imr = m.Notional.Div(p.Lever, pxPlaces, okex.RoundHalfEven).Trim()
+	m.Mmr = m.Notional.Mul(okex.DecimalFromFloat(float64(tier.Mmr))).Trim()
+	m.Fee = m.Notional.Mul(c.taker[inst.InstType]).Trim()
+	return m, nil
+}
+
+// liqPx solves collateral + upl(P) = (mmr + fee rate) * notional(P) for the mark price P
+func (c *Calculator) liqPx(p *Position, m *Margin, collateral okex.Decimal) (okex.Decimal, error) {
+	q := p.Pos.Abs().Mul(p.Instrument.ContractValue())
+	if q.IsZero() {
+		return "", nil
+	}
+	r := okex.DecimalFromFloat(float64(m.Tier.Mmr)).Add(c.taker[p.Instrument.InstType])
+	one := okex.Decimal("1")
+	var num, den okex.Decimal
+	switch {
+	case !p.Instrument.IsInverse() && !p.short():
+		num, den = q.Mul(p.AvgPx).Sub(collateral), q.Mul(one.Sub(r))
+	case !p.Instrument.IsInverse():
+		num, den = collateral.Add(q.Mul(p.AvgPx)), q.Mul(one.Add(r))
+	case !p.short():
+		num, den = q.Mul(one.Add(r)), collateral.Add(q.Div(p.AvgPx, pxPlaces, okex.RoundHalfEven))
+	default:
+		num, den = q.Mul(one.Sub(r)), q.Div(p.AvgPx, pxPlaces, okex.RoundHalfEven).Sub(collateral)
+	}
+	if num.Sign() <= 0 || den.Sign() <= 0 {
+		return "", nil
+	}
+	return num.Div(den, pxPlaces, okex.RoundHalfEven).Trim(), nil
+}
+
+func (p *Position) short() bool {
+	if p.PosSide == okex.PositionLongSide || p.PosSide == okex.PositionShortSide {
+		return p.PosSide == okex.PositionShortSide
+	}
+	return p.Pos.Sign() < 0
+}
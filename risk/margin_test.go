@@ -0,0 +1,208 @@
+package risk
+
+import (
+	"testing"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/models/publicdata"
+)
+
+var (
+	// 0.01 BTC per contract, margined in USDT
+	btcLinear = &publicdata.Instrument{InstID: "BTC-USDT-SWAP", InstFamily: "BTC-USDT", InstType: okex.SwapInstrument,
+		CtType: okex.ContractLinearType, CtVal: "0.01", CtMult: "1", SettleCcy: "USDT"}
+	// 0.1 ETH per contract, margined in USDT
+	ethLinear = &publicdata.Instrument{InstID: "ETH-USDT-SWAP", InstFamily: "ETH-USDT", InstType: okex.SwapInstrument,
+		CtType: okex.ContractLinearType, CtVal: "0.1", CtMult: "1", SettleCcy: "USDT"}
+	// 100 USD per contract, margined in BTC
+	btcInverse = &publicdata.Instrument{InstID: "BTC-USD-SWAP", InstFamily: "BTC-USD", InstType: okex.SwapInstrument,
+		CtType: okex.ContractInverseType, CtVal: "100", CtMult: "1", SettleCcy: "BTC"}
+)
+
+// newTestCalculator has a maintenance margin rate of 0.4% up to 100 BTC-USDT-SWAP contracts and 0.5% up to 500,
+// 0.4% for the other families, and a taker fee rate of 0.05%: the liquidation rate r = mmr + fee rate is 0.45%
+func newTestCalculator() *Calculator {
+	c := NewCalculator()
+	c.AddTiers([]*publicdata.PositionTier{
+		{InstFamily: "BTC-USDT", Tier: 2, MaxSz: 500, Mmr: 0.005},
+		{InstFamily: "BTC-USDT", Tier: 1, MaxSz: 100, Mmr: 0.004},
+		{InstFamily: "ETH-USDT", Tier: 1, MaxSz: 1000, Mmr: 0.004},
+		{InstFamily: "BTC-USD", Tier: 1, MaxSz: 1000, Mmr: 0.004},
+	})
+	c.SetTakerRate(okex.SwapInstrument, "-0.0005")
+	return c
+}
+
+func TestTier(t *testing.T) {
+	c := newTestCalculator()
+	tests := []struct {
+		sz   okex.Decimal
+		tier int64
+	}{
+		{"1", 1},
+		{"100", 1},
+		{"-100", 1},
+		{"100.01", 2},
+		{"500", 2},
+	}
+	for _, tt := range tests {
+		tier, err := c.Tier(btcLinear, tt.sz)
+		if err != nil || int64(tier.Tier) != tt.tier {
+			t.Errorf("Tier(%s) = %+v, %v, want tier %d", tt.sz, tier, err, tt.tier)
+		}
+	}
+	if _, err := c.Tier(btcLinear, "501"); err == nil {
+		t.Error("size above the last tier accepted")
+	}
+	if _, err := c.Tier(&publicdata.Instrument{InstID: "LTC-USDT-SWAP", InstFamily: "LTC-USDT"}, "1"); err == nil {
+		t.Error("instrument without tiers accepted")
+	}
+}
+
+// The isolated liquidation price solves margin + upl(P) = r * notional(P), with q the position in the currency of
+// the contract value:
+//
+//	linear long:   P = (q * avgPx - margin) / (q * (1 - r))
+//	linear short:  P = (q * avgPx + margin) / (q * (1 + r))
+//	inverse long:  P = q * (1 + r) / (margin + q / avgPx)
+//	inverse short: P = q * (1 - r) / (q / avgPx - margin)
+func TestIsolated(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Position
+		want Margin
+	}{
+		// q = 1 BTC, imr = 50000 / 10 = 5000 USDT, mmr = 200, fee = 25, ratio = 5000 / 225
+		// P = (50000 - 5000) / 0.9955
+		{"linear long", &Position{Instrument: btcLinear, Pos: "100", PosSide: okex.PositionLongSide, AvgPx: "50000", MarkPx: "50000", Lever: "10"},
+			Margin{Notional: "50000", Upl: "0", Imr: "5000", Mmr: "200", Fee: "25", MgnRatio: "22.2222222222222222", LiqPx: "45203.4153691612255148"}},
+		// P = (50000 + 5000) / 1.0045
+		{"linear short", &Position{Instrument: btcLinear, Pos: "-100", PosSide: okex.PositionNetSide, AvgPx: "50000", MarkPx: "50000", Lever: "10"},
+			Margin{Notional: "50000", Upl: "0", Imr: "5000", Mmr: "200", Fee: "25", MgnRatio: "22.2222222222222222", LiqPx: "54753.6087605774016924"}},
+		// q = 50000 USD, notional = 1 BTC, imr = 0.1 BTC, mmr = 0.004, fee = 0.0005
+		// P = 50000 * 1.0045 / (0.1 + 1)
+		{"inverse long", &Position{Instrument: btcInverse, Pos: "500", PosSide: okex.PositionNetSide, AvgPx: "50000", MarkPx: "50000", Lever: "10"},
+			Margin{Notional: "1", Upl: "0", Imr: "0.1", Mmr: "0.004", Fee: "0.0005", MgnRatio: "22.2222222222222222", LiqPx: "45659.0909090909090909"}},
+		// P = 50000 * 0.9955 / (1 - 0.1)
+		{"inverse short", &Position{Instrument: btcInverse, Pos: "500", PosSide: okex.PositionShortSide, AvgPx: "50000", MarkPx: "50000", Lever: "10"},
+			Margin{Notional: "1", Upl: "0", Imr: "0.1", Mmr: "0.004", Fee: "0.0005", MgnRatio: "22.2222222222222222", LiqPx: "55305.5555555555555556"}},
+		// the mark price moves the upl and the ratio, not the liquidation price
+		// notional = 49000, upl = -1000, mmr = 196, fee = 24.5, ratio = (5000 - 1000) / 220.5
+		{"linear long at a loss", &Position{Instrument: btcLinear, Pos: "100", AvgPx: "50000", MarkPx: "49000", Lever: "10", MgnMode: okex.MarginIsolatedMode, Margin: "5000"},
+			Margin{Notional: "49000", Upl: "-1000", Imr: "4900", Mmr: "196", Fee: "24.5", MgnRatio: "18.1405895691609977", LiqPx: "45203.4153691612255148"}},
+		// 200 contracts are in the second tier: mmr = 100000 * 0.005, r = 0.55%
+		// P = (100000 - 10000) / (2 * 0.9945)
+		{"linear long of tier 2", &Position{Instrument: btcLinear, Pos: "200", AvgPx: "50000", MarkPx: "50000", Lever: "10"},
+			Margin{Notional: "100000", Upl: "0", Imr: "10000", Mmr: "500", Fee: "50", MgnRatio: "18.1818181818181818", LiqPx: "45248.8687782805429864"}},
+		// a long without leverage can't be liquidated
+		{"linear long of lever 1", &Position{Instrument: btcLinear, Pos: "100", AvgPx: "50000", MarkPx: "50000", Lever: "1"},
+			Margin{Notional: "50000", Upl: "0", Imr: "50000", Mmr: "200", Fee: "25", MgnRatio: "222.2222222222222222", LiqPx: ""}},
+	}
+	c := newTestCalculator()
+	for _, tt := range tests {
+		m, err := c.Isolated(tt.p)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		checkMargin(t, tt.name, m, &tt.want)
+	}
+}
+
+func checkMargin(t *testing.T, name string, got, want *Margin) {
+	t.Helper()
+	fields := []struct {
+		name      string
+		got, want okex.Decimal
+	}{
+		{"Notional", got.Notional, want.Notional},
+		{"Upl", got.Upl, want.Upl},
+		{"Imr", got.Imr, want.Imr},
+		{"Mmr", got.Mmr, want.Mmr},
+		{"Fee", got.Fee, want.Fee},
+		{"MgnRatio", got.MgnRatio, want.MgnRatio},
+		{"LiqPx", got.LiqPx, want.LiqPx},
+	}
+	for _, f := range fields {
+		if f.got != f.want && !(f.got != "" && f.want != "" && f.got.Equal(f.want)) {
+			t.Errorf("%s: %s = %q, want %q", name, f.name, f.got, f.want)
+		}
+	}
+}
+
+func TestCross(t *testing.T) {
+	c := newTestCalculator()
+	btcLong := &Position{Instrument: btcLinear, Pos: "100", AvgPx: "50000", MarkPx: "50000", Lever: "10", MgnMode: okex.MarginCrossMode}
+
+	// alone with an equity of the isolated margin, a cross position is liquidated at the isolated price
+	tests := []struct {
+		name string
+		eq   okex.Decimal
+		p    *Position
+		liq  okex.Decimal
+	}{
+		{"linear long", "5000", btcLong, "45203.4153691612255148"},
+		{"linear short", "5000", &Position{Instrument: btcLinear, Pos: "-100", AvgPx: "50000", MarkPx: "50000", Lever: "10"}, "54753.6087605774016924"},
+		{"inverse long", "0.1", &Position{Instrument: btcInverse, Pos: "500", AvgPx: "50000", MarkPx: "50000", Lever: "10"}, "45659.0909090909090909"},
+		{"inverse short", "0.1", &Position{Instrument: btcInverse, Pos: "-500", AvgPx: "50000", MarkPx: "50000", Lever: "10"}, "55305.5555555555555556"},
+		// the equity holds the upl: 4000 of equity at a loss of 1000 is 5000 of collateral
+		{"linear long at a loss", "4000", &Position{Instrument: btcLinear, Pos: "100", AvgPx: "50000", MarkPx: "49000", Lever: "10"}, "45203.4153691612255148"},
+	}
+	for _, tt := range tests {
+		margins, _, err := c.Cross(tt.eq, []*Position{tt.p})
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if got := margins[0].LiqPx; !got.Equal(tt.liq) {
+			t.Errorf("%s: LiqPx = %s, want %s", tt.name, got, tt.liq)
+		}
+		if margins[0].MgnRatio != "" {
+			t.Errorf("%s: cross position with a margin ratio", tt.name)
+		}
+	}
+
+	// 1 BTC long at a loss of 1000 and 1 ETH short, 9000 USDT of equity with the upl:
+	// req = 196 + 24.5 for BTC and 8 + 1 for ETH, ratio = 9000 / 229.5
+	// BTC collateral = 9000 + 1000 - 9, P = (50000 - 9991) / 0.9955
+	// ETH collateral = 9000 - 220.5, P = (2000 + 8779.5) / 1.0045
+	btcLoss := &Position{Instrument: btcLinear, Pos: "100", AvgPx: "50000", MarkPx: "49000", Lever: "10"}
+	ethShort := &Position{Instrument: ethLinear, Pos: "10", PosSide: okex.PositionShortSide, AvgPx: "2000", MarkPx: "2000", Lever: "5"}
+	margins, ratio, err := c.Cross("9000", []*Position{btcLoss, ethShort})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ratio.Equal("39.2156862745098039") {
+		t.Errorf("ratio = %s, want 39.2156862745098039", ratio)
+	}
+	if got := margins[0].LiqPx; !got.Equal("40189.8543445504771472") {
+		t.Errorf("BTC LiqPx = %s, want 40189.8543445504771472", got)
+	}
+	if got := margins[1].LiqPx; !got.Equal("10731.209556993529119") {
+		t.Errorf("ETH LiqPx = %s, want 10731.209556993529119", got)
+	}
+
+	if _, _, err := c.Cross("1", []*Position{btcLong, {Instrument: btcInverse, Pos: "1", AvgPx: "50000", MarkPx: "50000", Lever: "10"}}); err == nil {
+		t.Error("positions of two margin currencies accepted")
+	}
+}
+
+func TestMarginInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		p    *Position
+	}{
+		{"no instrument", &Position{Pos: "1", AvgPx: "1", MarkPx: "1", Lever: "1"}},
+		{"option", &Position{Instrument: &publicdata.Instrument{InstID: "BTC-USD-250328-90000-C", InstType: okex.OptionsInstrument, CtVal: "1"}, Pos: "1", AvgPx: "1", MarkPx: "1", Lever: "1"}},
+		{"no mark price", &Position{Instrument: btcLinear, Pos: "1", AvgPx: "1", Lever: "1"}},
+		{"no leverage", &Position{Instrument: btcLinear, Pos: "1", AvgPx: "1", MarkPx: "1"}},
+		{"no contract value", &Position{Instrument: &publicdata.Instrument{InstID: "BTC-USDT-SWAP", InstFamily: "BTC-USDT", InstType: okex.SwapInstrument}, Pos: "1", AvgPx: "1", MarkPx: "1", Lever: "1"}},
+		{"above the last tier", &Position{Instrument: btcLinear, Pos: "1000", AvgPx: "1", MarkPx: "1", Lever: "1"}},
+	}
+	c := newTestCalculator()
+	for _, tt := range tests {
+		if _, err := c.Isolated(tt.p); err == nil {
+			t.Errorf("%s: accepted", tt.name)
+		}
+	}
+}
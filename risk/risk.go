@@ -0,0 +1,258 @@
+// Package risk asks the position builder what the margin of the account becomes with hypothetical positions,
+// it's the pre-trade check of "what happens to the MMR if this hedge is added".
+// The Calculator estimates the margin and the liquidation price of single positions offline.
+//
+// https://www.okx.com/docs-v5/en/#trading-account-rest-api-position-builder-new
+package risk
+
+import (
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+	"github.com/pefish/go-okx/models/account"
+	requests "github.com/pefish/go-okx/requests/rest/account"
+	tradeRequests "github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+// pxPlaces is the precision of the computed average prices and spot amounts
+const pxPlaces = 16
+
+// Scenario is a set of hypothetical positions and assets, positions of the same instrument are merged
+type Scenario struct {
+	inclReal   bool
+	acctLv     okex.AccountLevel
+	lever      okex.Decimal
+	greeksType okex.GreekType
+	idxVol     okex.Decimal
+	positions  []*requests.SimPosition
+	assets     []*requests.SimAsset
+}
+
+// NewScenario returns a pointer to a fresh Scenario on top of the real positions and equity
+func NewScenario() *Scenario {
+	return &Scenario{inclReal: true}
+}
+
+// FromPositions returns a Scenario made of the derivatives positions of Account.GetPositions alone,
+// the real positions and equity are left out so the positions can be changed before simulating.
+// MARGIN positions are skipped, their assets and liabilities are added with AddAsset.
+func FromPositions(positions []*account.Position) *Scenario {
+	s := &Scenario{}
+	s.AddPositions(positions)
+	return s
+}
+
+// IncludeReal sets whether the real positions and equity are added to the scenario
+func (s *Scenario) IncludeReal(b bool) *Scenario {
+	s.inclReal = b
+	return s
+}
+
+// SetAccountLevel simulates another account level, only multi-currency margin and portfolio margin apply
+func (s *Scenario) SetAccountLevel(lv okex.AccountLevel) *Scenario {
+	s.acctLv = lv
+	return s
+}
+
+// SetLever sets the cross leverage of multi-currency margin
+func (s *Scenario) SetLever(lever okex.Decimal) *Scenario {
+	s.lever = lever
+	return s
+}
+
+// SetGreeksType sets how the greeks of the result are expressed
+func (s *Scenario) SetGreeksType(t okex.GreekType) *Scenario {
+	s.greeksType = t
+	return s
+}
+
+// SetIdxVol shocks the index prices by a ratio from -0.99 to 1
+func (s *Scenario) SetIdxVol(v okex.Decimal) *Scenario {
+	s.idxVol = v
+	return s
+}
+
+// AddPosition adds a derivatives position of pos contracts, negative for a short, opened at avgPx.
+// Adding to a position of the same instrument in the same direction averages the price, reducing it keeps the price
+// and flipping it takes avgPx.
+func (s *Scenario) AddPosition(instID string, pos, avgPx okex.Decimal) *Scenario {
+	for i, p := range s.positions {
+		if p.InstID != instID {
+			continue
+		}
+		sum := p.Pos.Add(pos)
+		switch {
+		case sum.IsZero():
+			s.positions = append(s.positions[:i], s.positions[i+1:]...)
+		case p.Pos.Sign() == pos.Sign():
+			p.AvgPx = p.Pos.Mul(p.AvgPx).Add(pos.Mul(avgPx)).Div(sum, pxPlaces, okex.RoundHalfEven).Trim()
+			p.Pos = sum
+		case p.Pos.Sign() == sum.Sign():
+			p.Pos = sum
+		default:
+			p.Pos, p.AvgPx = sum, avgPx
+		}
+		return s
+	}
+	if !pos.IsZero() {
+		s.positions = append(s.positions, &requests.SimPosition{InstID: instID, Pos: pos, AvgPx: avgPx})
+	}
+	return s
+}
+
+// AddPositions adds the derivatives positions of Account.GetPositions, long positions are positive and short ones negative.
+// The leverage of a position is kept when it's the first one of its instrument.
+func (s *Scenario) AddPositions(positions []*account.Position) *Scenario {
+	for _, p := range positions {
+		if p.Pos == 0 || p.InstType == okex.MarginInstrument || p.InstType == okex.SpotInstrument {
+			continue
+		}
+		pos := okex.DecimalFromFloat(float64(p.Pos))
+		if p.PosSide == okex.PositionShortSide {
+			pos = pos.Neg()
+		}
+		existing := s.position(p.InstID) != nil
+		s.AddPosition(p.InstID, pos, okex.DecimalFromFloat(float64(p.AvgPx)))
+		if sim := s.position(p.InstID); !existing && sim != nil && p.Lever != 0 {
+			sim.Lever = okex.DecimalFromFloat(float64(p.Lever))
+		}
+	}
+	return s
+}
+
+// AddAsset adds amt of ccy, negative for a borrowing
+func (s *Scenario) AddAsset(ccy string, amt okex.Decimal) *Scenario {
+	for i, a := range s.assets {
+		if a.Ccy != ccy {
+			continue
+		}
+		a.Amt = a.Amt.Add(amt).Trim()
+		if a.Amt.IsZero() {
+			s.assets = append(s.assets[:i], s.assets[i+1:]...)
+		}
+		return s
+	}
+	if !amt.IsZero() {
+		s.assets = append(s.assets, &requests.SimAsset{Ccy: ccy, Amt: amt})
+	}
+	return s
+}
+
+// AddOrder adds the outcome of an order filled at px, the order price is used when px is empty.
+// Derivatives orders change the position of their instrument, spot and margin orders change the base and quote assets.
+func (s *Scenario) AddOrder(o tradeRequests.PlaceOrder, px okex.Decimal) error {
+	if px.IsZero() {
+		px = o.Px
+	}
+	if px.Sign() <= 0 {
+		return errors.Errorf("%s: a fill price is needed", o.InstID)
+	}
+	if o.Sz.Sign() <= 0 {
+		return errors.Errorf("%s: invalid size %s", o.InstID, o.Sz)
+	}
+	id, err := okex.ParseInstID(o.InstID)
+	if err != nil {
+		return err
+	}
+	if id.Type != okex.SpotInstrument {
+		sz := o.Sz
+		if o.Side == okex.OrderSell {
+			sz = sz.Neg()
+		}
+		s.AddPosition(o.InstID, sz, px)
+		return nil
+	}
+	// spot market buys are sized in quote currency unless told otherwise
+	base, quote := o.Sz, o.Sz.Mul(px)
+	if o.TgtCcy == okex.QuantityQuoteCcy || (o.TgtCcy == "" && o.OrdType == okex.OrderMarket && o.Side == okex.OrderBuy && o.TdMode == okex.TradeCashMode) {
+		base, quote = o.Sz.Div(px, pxPlaces, okex.RoundDown).Trim(), o.Sz
+	}
+	if o.Side == okex.OrderSell {
+		base = base.Neg()
+	} else {
+		quote = quote.Neg()
+	}
+	s.AddAsset(id.Base, base)
+	s.AddAsset(id.Quote, quote)
+	return nil
+}
+
+// Request returns the position builder request of the scenario
+func (s *Scenario) Request() requests.PositionBuilder {
+	return requests.PositionBuilder{
+		AcctLv:           s.acctLv,
+		InclRealPosAndEq: s.inclReal,
+		Lever:            s.lever,
+		GreeksType:       s.greeksType,
+		IdxVol:           s.idxVol,
+		SimPos:           s.positions,
+		SimAsset:         s.assets,
+	}
+}
+
+func (s *Scenario) position(instID string) *requests.SimPosition {
+	for _, p := range s.positions {
+		if p.InstID == instID {
+			return p
+		}
+	}
+	return nil
+}
+
+// Comparison is the margin of the account before and after a scenario
+type Comparison struct {
+	Before *account.PositionBuilder
+	After  *account.PositionBuilder
+}
+
+// MmrChange returns how much the maintenance margin requirement grows, in USD
+func (c *Comparison) MmrChange() okex.Decimal {
+	return c.After.TotalMmr.Sub(c.Before.TotalMmr)
+}
+
+// ImrChange returns how much the initial margin requirement grows, in USD
+func (c *Comparison) ImrChange() okex.Decimal {
+	return c.After.TotalImr.Sub(c.Before.TotalImr)
+}
+
+// MarginRatioChange returns how much the margin ratio grows, a negative change is closer to liquidation
+func (c *Comparison) MarginRatioChange() okex.Decimal {
+	return c.After.MarginRatio.Sub(c.Before.MarginRatio)
+}
+
+// Simulate runs the scenario through the position builder
+func Simulate(a *rest.Account, s *Scenario) (*account.PositionBuilder, error) {
+	res, err := a.PositionBuilder(s.Request())
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 {
+		return nil, errors.Errorf("PositionBuilder failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	if len(res.PositionBuilders) == 0 {
+		return nil, errors.New("PositionBuilder returned no data")
+	}
+	return res.PositionBuilders[0], nil
+}
+
+// WhatIf compares the margin of the account as it is with the margin once the orders are filled at px, the price of each order when px is empty.
+//
+// The scenario of the orders is built before anything is simulated: an order AddOrder rejects aborts the whole batch,
+// the error names its index, and the position builder is not called.
+func WhatIf(a *rest.Account, orders []tradeRequests.PlaceOrder, px okex.Decimal) (*Comparison, error) {
+	s := NewScenario()
+	for i, o := range orders {
+		if err := s.AddOrder(o, px); err != nil {
+			return nil, errors.Wrapf(err, "order %d", i)
+		}
+	}
+	before, err := Simulate(a, NewScenario())
+	if err != nil {
+		return nil, err
+	}
+	after, err := Simulate(a, s)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{Before: before, After: after}, nil
+}
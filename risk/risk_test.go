@@ -0,0 +1,161 @@
+package risk
+
+import (
+	"strings"
+	"testing"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/models/account"
+	requests "github.com/pefish/go-okx/requests/rest/account"
+	tradeRequests "github.com/pefish/go-okx/requests/rest/trade"
+)
+
+func TestAddPositionMerge(t *testing.T) {
+	tests := []struct {
+		name       string
+		pos, avgPx okex.Decimal // the position of the scenario
+		add, px    okex.Decimal // the added one
+		want       *requests.SimPosition
+	}{
+		{"long added to", "2", "100", "2", "200", &requests.SimPosition{Pos: "4", AvgPx: "150"}},
+		{"short added to", "-1", "100", "-3", "200", &requests.SimPosition{Pos: "-4", AvgPx: "175"}},
+		{"long reduced", "3", "100", "-1", "200", &requests.SimPosition{Pos: "2", AvgPx: "100"}},
+		{"short reduced", "-3", "100", "2", "50", &requests.SimPosition{Pos: "-1", AvgPx: "100"}},
+		{"long flipped", "2", "100", "-5", "200", &requests.SimPosition{Pos: "-3", AvgPx: "200"}},
+		{"short flipped", "-2", "100", "3", "90", &requests.SimPosition{Pos: "1", AvgPx: "90"}},
+		{"long closed", "2", "100", "-2", "200", nil},
+		{"short closed", "-0.5", "100", "0.5", "200", nil},
+		{"average of a repeating decimal", "1", "100", "2", "101", &requests.SimPosition{Pos: "3", AvgPx: "100.6666666666666667"}},
+	}
+	for _, tt := range tests {
+		s := NewScenario().AddPosition("BTC-USDT-SWAP", tt.pos, tt.avgPx).AddPosition("ETH-USDT-SWAP", "1", "2000")
+		s.AddPosition("BTC-USDT-SWAP", tt.add, tt.px)
+		got := s.position("BTC-USDT-SWAP")
+		if tt.want == nil {
+			if got != nil {
+				t.Errorf("%s: got %s @ %s, want the position removed", tt.name, got.Pos, got.AvgPx)
+			}
+		} else if got == nil || !got.Pos.Equal(tt.want.Pos) || !got.AvgPx.Equal(tt.want.AvgPx) {
+			t.Errorf("%s: got %+v, want %s @ %s", tt.name, got, tt.want.Pos, tt.want.AvgPx)
+		}
+		// the other instrument is left alone
+		if eth := s.position("ETH-USDT-SWAP"); eth == nil || eth.Pos != "1" || eth.AvgPx != "2000" {
+			t.Errorf("%s: ETH-USDT-SWAP changed to %+v", tt.name, eth)
+		}
+	}
+
+	s := NewScenario().AddPosition("BTC-USDT-SWAP", "0", "100")
+	if len(s.Request().SimPos) != 0 {
+		t.Error("empty position added")
+	}
+}
+
+func TestAddPositions(t *testing.T) {
+	s := FromPositions([]*account.Position{
+		{InstID: "BTC-USDT-SWAP", InstType: okex.SwapInstrument, PosSide: okex.PositionLongSide, Pos: 2, AvgPx: 100, Lever: 10},
+		{InstID: "BTC-USDT-SWAP", InstType: okex.SwapInstrument, PosSide: okex.PositionShortSide, Pos: 1, AvgPx: 200, Lever: 5},
+		{InstID: "ETH-USDT-SWAP", InstType: okex.SwapInstrument, PosSide: okex.PositionNetSide, Pos: -3, AvgPx: 2000},
+		{InstID: "BTC-USDT", InstType: okex.MarginInstrument, PosSide: okex.PositionNetSide, Pos: 1, AvgPx: 100},
+	})
+	req := s.Request()
+	if req.InclRealPosAndEq {
+		t.Error("FromPositions includes the real positions")
+	}
+	if len(req.SimPos) != 2 {
+		t.Fatalf("got %d positions, want 2", len(req.SimPos))
+	}
+	// the short reduces the long, the leverage of the first one is kept
+	if btc := req.SimPos[0]; btc.Pos != "1" || btc.AvgPx != "100" || btc.Lever != "10" {
+		t.Errorf("BTC-USDT-SWAP = %+v, want 1 @ 100 with lever 10", btc)
+	}
+	if eth := req.SimPos[1]; eth.Pos != "-3" || eth.Lever != "" {
+		t.Errorf("ETH-USDT-SWAP = %+v, want -3 without lever", eth)
+	}
+}
+
+func TestAddOrder(t *testing.T) {
+	tests := []struct {
+		name        string
+		o           tradeRequests.PlaceOrder
+		px          okex.Decimal
+		base, quote okex.Decimal // the BTC and USDT assets
+	}{
+		{"limit buy", tradeRequests.PlaceOrder{Side: okex.OrderBuy, OrdType: okex.OrderLimit, TdMode: okex.TradeCashMode, Sz: "0.5", Px: "20000"}, "", "0.5", "-10000"},
+		{"limit sell filled better", tradeRequests.PlaceOrder{Side: okex.OrderSell, OrdType: okex.OrderLimit, TdMode: okex.TradeCashMode, Sz: "0.5", Px: "20000"}, "21000", "-0.5", "10500"},
+		// spot market buys are sized in quote currency by default
+		{"market buy", tradeRequests.PlaceOrder{Side: okex.OrderBuy, OrdType: okex.OrderMarket, TdMode: okex.TradeCashMode, Sz: "100"}, "20000", "0.005", "-100"},
+		{"market buy in base", tradeRequests.PlaceOrder{Side: okex.OrderBuy, OrdType: okex.OrderMarket, TdMode: okex.TradeCashMode, TgtCcy: okex.QuantityBaseCcy, Sz: "0.005"}, "20000", "0.005", "-100"},
+		{"market sell", tradeRequests.PlaceOrder{Side: okex.OrderSell, OrdType: okex.OrderMarket, TdMode: okex.TradeCashMode, Sz: "0.005"}, "20000", "-0.005", "100"},
+		{"market sell in quote", tradeRequests.PlaceOrder{Side: okex.OrderSell, OrdType: okex.OrderMarket, TdMode: okex.TradeCashMode, TgtCcy: okex.QuantityQuoteCcy, Sz: "100"}, "20000", "-0.005", "100"},
+		// margin market buys are sized in base currency
+		{"margin market buy", tradeRequests.PlaceOrder{Side: okex.OrderBuy, OrdType: okex.OrderMarket, TdMode: okex.TradeCrossMode, Sz: "0.005"}, "20000", "0.005", "-100"},
+		// quote amounts are rounded down to the 16 places of the computed amounts
+		{"market buy of a repeating decimal", tradeRequests.PlaceOrder{Side: okex.OrderBuy, OrdType: okex.OrderMarket, TdMode: okex.TradeCashMode, Sz: "100"}, "30000", "0.0033333333333333", "-100"},
+	}
+	for _, tt := range tests {
+		tt.o.InstID = "BTC-USDT"
+		s := NewScenario()
+		if err := s.AddOrder(tt.o, tt.px); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		assets := map[string]okex.Decimal{}
+		for _, a := range s.Request().SimAsset {
+			assets[a.Ccy] = a.Amt
+		}
+		if !assets["BTC"].Equal(tt.base) || !assets["USDT"].Equal(tt.quote) {
+			t.Errorf("%s: got BTC %s and USDT %s, want %s and %s", tt.name, assets["BTC"], assets["USDT"], tt.base, tt.quote)
+		}
+		if len(s.Request().SimPos) != 0 {
+			t.Errorf("%s: spot order added a position", tt.name)
+		}
+	}
+
+	// derivatives orders change the position, sells are shorts
+	s := NewScenario()
+	if err := s.AddOrder(tradeRequests.PlaceOrder{InstID: "BTC-USDT-SWAP", Side: okex.OrderSell, OrdType: okex.OrderLimit, Sz: "3", Px: "20000"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if p := s.position("BTC-USDT-SWAP"); p == nil || p.Pos != "-3" || p.AvgPx != "20000" {
+		t.Errorf("BTC-USDT-SWAP = %+v, want -3 @ 20000", p)
+	}
+	if len(s.Request().SimAsset) != 0 {
+		t.Error("derivatives order added assets")
+	}
+}
+
+func TestAddOrderInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		o    tradeRequests.PlaceOrder
+		px   okex.Decimal
+		want string
+	}{
+		{"no price", tradeRequests.PlaceOrder{InstID: "BTC-USDT", Side: okex.OrderBuy, OrdType: okex.OrderMarket, Sz: "1"}, "", "a fill price is needed"},
+		{"no size", tradeRequests.PlaceOrder{InstID: "BTC-USDT", Side: okex.OrderBuy, OrdType: okex.OrderLimit, Px: "100"}, "", "invalid size"},
+		{"negative size", tradeRequests.PlaceOrder{InstID: "BTC-USDT", Side: okex.OrderBuy, OrdType: okex.OrderLimit, Sz: "-1"}, "100", "invalid size"},
+		{"invalid instId", tradeRequests.PlaceOrder{InstID: "BTC", Side: okex.OrderBuy, OrdType: okex.OrderLimit, Sz: "1"}, "100", "invalid instrument id"},
+	}
+	for _, tt := range tests {
+		s := NewScenario()
+		err := s.AddOrder(tt.o, tt.px)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: got %v, want %q", tt.name, err, tt.want)
+		}
+		if req := s.Request(); len(req.SimPos) != 0 || len(req.SimAsset) != 0 {
+			t.Errorf("%s: the rejected order changed the scenario", tt.name)
+		}
+	}
+}
+
+func TestWhatIfInvalidOrder(t *testing.T) {
+	orders := []tradeRequests.PlaceOrder{
+		{InstID: "BTC-USDT-SWAP", Side: okex.OrderBuy, OrdType: okex.OrderLimit, Sz: "1", Px: "20000"},
+		{InstID: "BTC-USDT-SWAP", Side: okex.OrderBuy, OrdType: okex.OrderMarket, Sz: "1"},
+	}
+	// the position builder isn't called, the account is never used
+	_, err := WhatIf(nil, orders, "")
+	if err == nil || !strings.Contains(err.Error(), "order 1") {
+		t.Errorf("got %v, want the error of order 1", err)
+	}
+}
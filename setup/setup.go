@@ -0,0 +1,329 @@
+// Package setup brings the account configuration a strategy expects about: the account level, the position mode,
+// auto loan and the leverage and margin mode of each instrument. Only the differences are applied.
+package setup
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	okex "github.com/pefish/go-okx"
+	"github.com/pefish/go-okx/api/rest"
+	requests "github.com/pefish/go-okx/requests/rest/account"
+	tradeRequests "github.com/pefish/go-okx/requests/rest/trade"
+	"github.com/pkg/errors"
+)
+
+// leverageBatch is the maximum number of instruments of a GetLeverage request
+const leverageBatch = 20
+
+// ErrBusy is returned when a change needs the account without open positions or pending orders
+var ErrBusy = errors.New("account has open positions or pending orders")
+
+type ChangeKind string
+
+const (
+	AccountLevelChange = ChangeKind("acctLv")
+	PositionModeChange = ChangeKind("posMode")
+	AutoLoanChange     = ChangeKind("autoLoan")
+	LeverageChange     = ChangeKind("lever")
+)
+
+// Desired is the configuration a strategy expects, the zero value of a field leaves it as it is
+type Desired struct {
+	AcctLv    okex.AccountLevel
+	PosMode   okex.PositionType
+	AutoLoan  *bool // only applicable to multi-currency margin and portfolio margin
+	Leverages []Leverage
+}
+
+// Leverage is the leverage of an instrument in a margin mode.
+// PosSide is only used by isolated positions in long/short mode, it's empty to set both sides.
+type Leverage struct {
+	InstID  string
+	MgnMode okex.MarginMode
+	PosSide okex.PositionSide
+	Lever   int64
+}
+
+// Change is a difference between the current and the desired configuration
+type Change struct {
+	Kind    ChangeKind
+	InstID  string // leverage changes only
+	MgnMode okex.MarginMode
+	PosSide okex.PositionSide
+	From    string
+	To      string
+	Applied bool
+	Err     error
+}
+
+func (c *Change) String() string {
+	s := string(c.Kind)
+	if c.InstID != "" {
+		s += " " + c.InstID + " " + string(c.MgnMode)
+		if c.PosSide != "" {
+			s += " " + string(c.PosSide)
+		}
+	}
+	s += ": " + c.From + " -> " + c.To
+	if c.Err != nil {
+		s += fmt.Sprintf(" (failed: %v)", c.Err)
+	} else if c.Applied {
+		s += " (applied)"
+	}
+	return s
+}
+
+// Plan is the list of changes in the order they are applied
+type Plan struct {
+	Changes []*Change
+}
+
+// Empty reports whether the configuration already matches
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// String is the diff, one change a line
+func (p *Plan) String() string {
+	lines := make([]string, len(p.Changes))
+	for i, c := range p.Changes {
+		lines[i] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reconciler reads the configuration through GetConfig and GetLeverage and applies the differences
+type Reconciler struct {
+	rest   *rest.ClientRest
+	DryRun bool // Apply only returns the plan
+}
+
+// NewReconciler returns a pointer to a fresh Reconciler
+func NewReconciler(r *rest.ClientRest) *Reconciler {
+	return &Reconciler{rest: r}
+}
+
+// Diff returns the changes needed, nothing is applied.
+// The leverage of an instrument is compared with the current position mode, a position mode change can change what is listed.
+func (r *Reconciler) Diff(d Desired) (*Plan, error) {
+	p, err := r.accountChanges(d)
+	if err != nil {
+		return nil, err
+	}
+	lev, err := r.leverageChanges(d.Leverages)
+	if err != nil {
+		return nil, err
+	}
+	p.Changes = append(p.Changes, lev...)
+	return p, nil
+}
+
+// Apply applies the changes in the order the server needs them: account level, position mode, auto loan, then leverage.
+// The account level and the position mode are only changed without open positions and pending orders, ErrBusy is returned otherwise.
+// It stops at the first failure, the returned plan tells what was applied.
+func (r *Reconciler) Apply(d Desired) (*Plan, error) {
+	p, err := r.accountChanges(d)
+	if err != nil {
+		return nil, err
+	}
+	if r.DryRun {
+		lev, err := r.leverageChanges(d.Leverages)
+		if err != nil {
+			return nil, err
+		}
+		p.Changes = append(p.Changes, lev...)
+		return p, nil
+	}
+	for _, c := range p.Changes {
+		if c.Kind == AccountLevelChange || c.Kind == PositionModeChange {
+			if err := r.checkIdle(); err != nil {
+				c.Err = err
+				return p, errors.Wrapf(err, "%s", c.Kind)
+			}
+		}
+		if err := r.apply(c); err != nil {
+			c.Err = err
+			return p, errors.Wrapf(err, "%s", c)
+		}
+		c.Applied = true
+	}
+	// read after the position mode changed, the leverage of long/short mode is by side
+	lev, err := r.leverageChanges(d.Leverages)
+	if err != nil {
+		return p, err
+	}
+	p.Changes = append(p.Changes, lev...)
+	for _, c := range lev {
+		if err := r.apply(c); err != nil {
+			c.Err = err
+			return p, errors.Wrapf(err, "%s", c)
+		}
+		c.Applied = true
+	}
+	return p, nil
+}
+
+func (r *Reconciler) accountChanges(d Desired) (*Plan, error) {
+	res, err := r.rest.Account.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	if res.Code != 0 || len(res.Configs) == 0 {
+		return nil, errors.Errorf("GetConfig failed. err: %s, code: %d", res.Msg, res.Code)
+	}
+	cfg := res.Configs[0]
+	p := &Plan{}
+	if d.AcctLv != "" && d.AcctLv != cfg.AcctLv {
+		p.Changes = append(p.Changes, &Change{Kind: AccountLevelChange, From: string(cfg.AcctLv), To: string(d.AcctLv)})
+	}
+	if d.PosMode != "" && d.PosMode != cfg.PosMode {
+		p.Changes = append(p.Changes, &Change{Kind: PositionModeChange, From: string(cfg.PosMode), To: string(d.PosMode)})
+	}
+	if d.AutoLoan != nil && *d.AutoLoan != cfg.AutoLoan {
+		lv := cfg.AcctLv
+		if d.AcctLv != "" {
+			lv = d.AcctLv
+		}
+		if lv != okex.AccountLevelMultiCurrencyMargin && lv != okex.AccountLevelPortfolioMargin {
+			return nil, errors.Errorf("auto loan needs multi-currency margin or portfolio margin, account level is %s", lv)
+		}
+		p.Changes = append(p.Changes, &Change{Kind: AutoLoanChange, From: strconv.FormatBool(cfg.AutoLoan), To: strconv.FormatBool(*d.AutoLoan)})
+	}
+	return p, nil
+}
+
+func (r *Reconciler) leverageChanges(list []Leverage) ([]*Change, error) {
+	byMode := make(map[okex.MarginMode][]string)
+	for _, l := range list {
+		if l.Lever <= 0 {
+			return nil, errors.Errorf("%s: invalid leverage %d", l.InstID, l.Lever)
+		}
+		byMode[l.MgnMode] = append(byMode[l.MgnMode], l.InstID)
+	}
+	// instId + mgnMode + posSide -> leverage
+	current := make(map[string]float64)
+	for mode, ids := range byMode {
+		for i := 0; i < len(ids); i += leverageBatch {
+			res, err := r.rest.Account.GetLeverage(requests.GetLeverage{InstID: ids[i:min(i+leverageBatch, len(ids))], MgnMode: mode})
+			if err != nil {
+				return nil, err
+			}
+			if res.Code != 0 {
+				return nil, errors.Errorf("GetLeverage failed. err: %s, code: %d", res.Msg, res.Code)
+			}
+			for _, l := range res.Leverages {
+				current[l.InstID+"/"+string(l.MgnMode)+"/"+string(l.PosSide)] = float64(l.Lever)
+			}
+		}
+	}
+
+	changes := make([]*Change, 0)
+	for _, l := range list {
+		prefix := l.InstID + "/" + string(l.MgnMode) + "/"
+		found := false
+		bySide := make(map[okex.PositionSide]string)
+		for k, v := range current {
+			side := okex.PositionSide(strings.TrimPrefix(k, prefix))
+			if !strings.HasPrefix(k, prefix) || (l.PosSide != "" && side != l.PosSide) {
+				continue
+			}
+			found = true
+			if v == float64(l.Lever) {
+				continue
+			}
+			// isolated long/short positions have a leverage by side, it's set side by side
+			if l.PosSide == "" && (l.MgnMode != okex.MarginIsolatedMode || (side != okex.PositionLongSide && side != okex.PositionShortSide)) {
+				side = ""
+			}
+			bySide[side] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		if !found {
+			bySide[l.PosSide] = "none"
+		}
+		for _, side := range []okex.PositionSide{"", okex.PositionLongSide, okex.PositionShortSide, okex.PositionNetSide} {
+			from, ok := bySide[side]
+			if !ok {
+				continue
+			}
+			changes = append(changes, &Change{
+				Kind:    LeverageChange,
+				InstID:  l.InstID,
+				MgnMode: l.MgnMode,
+				PosSide: side,
+				From:    from,
+				To:      strconv.FormatInt(l.Lever, 10),
+			})
+		}
+	}
+	return changes, nil
+}
+
+// checkIdle returns ErrBusy when a position is open or an order is pending
+func (r *Reconciler) checkIdle() error {
+	pos, err := r.rest.Account.GetPositions(requests.GetPositions{})
+	if err != nil {
+		return err
+	}
+	if pos.Code != 0 {
+		return errors.Errorf("GetPositions failed. err: %s, code: %d", pos.Msg, pos.Code)
+	}
+	for _, p := range pos.Positions {
+		if p.Pos != 0 {
+			return errors.Wrapf(ErrBusy, "%s position open", p.InstID)
+		}
+	}
+	orders, err := r.rest.Trade.GetOrderList(tradeRequests.OrderList{Limit: 1})
+	if err != nil {
+		return err
+	}
+	if orders.Code != 0 {
+		return errors.Errorf("GetOrderList failed. err: %s, code: %d", orders.Msg, orders.Code)
+	}
+	if len(orders.Orders) > 0 {
+		return errors.Wrapf(ErrBusy, "%s order pending", orders.Orders[0].InstID)
+	}
+	return nil
+}
+
+func (r *Reconciler) apply(c *Change) error {
+	var code int
+	var msg string
+	switch c.Kind {
+	case AccountLevelChange:
+		res, err := r.rest.Account.SetAccountLevel(requests.SetAccountLevel{AcctLv: okex.AccountLevel(c.To)})
+		if err != nil {
+			return err
+		}
+		code, msg = res.Code, res.Msg
+	case PositionModeChange:
+		res, err := r.rest.Account.SetPositionMode(requests.SetPositionMode{PositionMode: okex.PositionType(c.To)})
+		if err != nil {
+			return err
+		}
+		code, msg = res.Code, res.Msg
+	case AutoLoanChange:
+		res, err := r.rest.Account.SetAutoLoan(requests.SetAutoLoan{AutoLoan: c.To == "true"})
+		if err != nil {
+			return err
+		}
+		code, msg = res.Code, res.Msg
+	case LeverageChange:
+		lever, _ := strconv.ParseInt(c.To, 10, 64)
+		res, err := r.rest.Account.SetLeverage(requests.SetLeverage{
+			Lever:   lever,
+			InstID:  c.InstID,
+			MgnMode: c.MgnMode,
+			PosSide: c.PosSide,
+		})
+		if err != nil {
+			return err
+		}
+		code, msg = res.Code, res.Msg
+	}
+	if code != 0 {
+		return errors.Errorf("err: %s, code: %d", msg, code)
+	}
+	return nil
+}